@@ -0,0 +1,153 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// OpReview describes what one patch op does to the plan, applied after the
+// accepted ops before it.
+type OpReview struct {
+	Index   int
+	Op      PatchOp
+	ItemID  string
+	Title   string
+	Changes []plan.FieldChange
+	// Rationale holds the op's rationale and the rationale of any deps it adds.
+	Rationale []string
+	// Err is set when the op does not apply on top of the accepted ops before it.
+	Err error
+}
+
+// Summary is a one-line description of the op, e.g. `update task-a "Title"`.
+func (r OpReview) Summary() string {
+	summary := fmt.Sprintf("%s %s", r.Op.Op, r.ItemID)
+	if r.Op.Op == PatchAddDep || r.Op.Op == PatchRemoveDep {
+		summary += " -> " + r.Op.DepID
+	}
+	if r.Title != "" {
+		summary += fmt.Sprintf(" %q", r.Title)
+	}
+	return summary
+}
+
+// ReviewPatch reviews every op as if all the ops before it were accepted.
+func ReviewPatch(base plan.WorkGraph, ops []PatchOp, now time.Time) []OpReview {
+	return ReviewSelectedOps(base, ops, nil, now)
+}
+
+// ReviewSelectedOps reviews each op on top of base with only the accepted ops
+// before it applied (accepted[i] for ops[i]; nil accepts every op). An op that
+// does not apply there gets Err and is treated as rejected.
+func ReviewSelectedOps(base plan.WorkGraph, ops []PatchOp, accepted []bool, now time.Time) []OpReview {
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	g := plan.Clone(base)
+	reviews := make([]OpReview, 0, len(ops))
+	for i, op := range ops {
+		review, next := ReviewOp(g, i, op, now)
+		if review.Err == nil && (accepted == nil || (i < len(accepted) && accepted[i])) {
+			g = next
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews
+}
+
+// ReviewOp reports the field-level changes ops[i] makes to the item it
+// targets when applied to g, and returns a copy of g with it applied. When the
+// op does not apply, Err is set and g is returned unchanged.
+func ReviewOp(g plan.WorkGraph, i int, op PatchOp, now time.Time) (OpReview, plan.WorkGraph) {
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	id := patchOpItemID(op)
+	// Missing items compare as zero values, so adds and deletes list every
+	// field they set or clear.
+	before := g.Items[id]
+	review := OpReview{Index: i, Op: op, ItemID: id}
+
+	trial := plan.Clone(g)
+	if trial.Items == nil {
+		trial.Items = map[string]plan.WorkItem{}
+	}
+	if err := applyOp(&trial, i, op, now); err != nil {
+		review.Err = err
+		review.Title = before.Title
+		return review, g
+	}
+
+	after, hasAfter := trial.Items[id]
+	review.Title = after.Title
+	if !hasAfter {
+		review.Title = before.Title
+	}
+	review.Changes = plan.ItemFieldChanges(before, after)
+	review.Rationale = opRationale(op, before.Deps, after.Deps)
+	return review, trial
+}
+
+// ApplySelectedOps applies the accepted ops (accepted[i] for ops[i]) to a
+// copy of base and validates the result.
+func ApplySelectedOps(base plan.WorkGraph, ops []PatchOp, accepted []bool, now time.Time) (plan.WorkGraph, error) {
+	selected := make([]PatchOp, 0, len(ops))
+	for i, op := range ops {
+		if i < len(accepted) && accepted[i] {
+			selected = append(selected, op)
+		}
+	}
+	next := plan.Clone(base)
+	if len(selected) == 0 {
+		return next, nil
+	}
+	if err := ApplyPatch(&next, selected, now); err != nil {
+		if errs := plan.Validate(next); len(errs) != 0 {
+			return plan.WorkGraph{}, fmt.Errorf("%w: %s", err, errs[0].Error())
+		}
+		return plan.WorkGraph{}, err
+	}
+	return next, nil
+}
+
+func patchOpItemID(op PatchOp) string {
+	if op.ID != "" {
+		return op.ID
+	}
+	if op.Item != nil {
+		return op.Item.ID
+	}
+	return ""
+}
+
+func opRationale(op PatchOp, beforeDeps, afterDeps []string) []string {
+	var out []string
+	if strings.TrimSpace(op.Rationale) != "" {
+		out = append(out, strings.TrimSpace(op.Rationale))
+	}
+	rationale := op.DepRationale
+	if rationale == nil && op.Item != nil {
+		rationale = op.Item.DepRationale
+	}
+	had := map[string]bool{}
+	for _, dep := range beforeDeps {
+		had[dep] = true
+	}
+	var added []string
+	for _, dep := range afterDeps {
+		if !had[dep] {
+			added = append(added, dep)
+		}
+	}
+	sort.Strings(added)
+	for _, dep := range added {
+		if reason := strings.TrimSpace(rationale[dep]); reason != "" {
+			out = append(out, fmt.Sprintf("%s: %s", dep, reason))
+		}
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func patchReviewTestGraph(now time.Time) plan.WorkGraph {
+	item := func(id string, deps ...string) plan.WorkItem {
+		return plan.WorkItem{
+			ID:                 id,
+			Title:              "Task " + id,
+			AcceptanceCriteria: []string{"works"},
+			Prompt:             "do " + id,
+			ChildIDs:           []string{},
+			Deps:               append([]string{}, deps...),
+			Status:             plan.StatusTodo,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+	}
+	return plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{
+		"a": item("a"),
+		"b": item("b", "a"),
+	}}
+}
+
+func TestReviewPatchReportsFieldChangesAndRationale(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	base := patchReviewTestGraph(now)
+	updated := base.Items["b"]
+	updated.Title = "Task b, narrowed"
+	updated.AcceptanceCriteria = []string{"works", "has tests"}
+	updated.Prompt = "do b\nand add tests"
+	ops := []PatchOp{
+		{Op: PatchUpdate, ID: "b", Item: &updated, Rationale: "narrow the scope"},
+		{Op: PatchAdd, Item: &plan.WorkItem{ID: "c", Title: "Task c", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo}},
+		{Op: PatchAddDep, ID: "c", DepID: "b", DepRationale: map[string]string{"b": "uses b's output"}},
+		{Op: PatchDelete, ID: "missing"},
+	}
+
+	reviews := ReviewPatch(base, ops, now)
+	if len(reviews) != 4 {
+		t.Fatalf("reviews = %d, want 4", len(reviews))
+	}
+	wantUpdate := []plan.FieldChange{
+		{Field: "title", Before: "Task b", After: "Task b, narrowed"},
+		{Field: "prompt", Lines: []plan.LineChange{{Op: plan.LineEqual, Text: "do b"}, {Op: plan.LineAdded, Text: "and add tests"}}},
+		{Field: "acceptanceCriteria", Added: []string{"has tests"}},
+	}
+	if !reflect.DeepEqual(reviews[0].Changes, wantUpdate) || !reflect.DeepEqual(reviews[0].Rationale, []string{"narrow the scope"}) {
+		t.Fatalf("update review = %+v", reviews[0])
+	}
+	if reviews[1].Summary() != `add c "Task c"` || reviews[1].Changes[0].Field != "title" {
+		t.Fatalf("add review = %+v", reviews[1])
+	}
+	wantDep := []plan.FieldChange{
+		{Field: "deps", Added: []string{"b"}},
+		{Field: "depRationale", Added: []string{"b: uses b's output"}},
+	}
+	if !reflect.DeepEqual(reviews[2].Changes, wantDep) || !reflect.DeepEqual(reviews[2].Rationale, []string{"b: uses b's output"}) {
+		t.Fatalf("add_dep review = %+v", reviews[2])
+	}
+	if reviews[3].Err == nil {
+		t.Fatalf("expected delete of an unknown id to fail, got %+v", reviews[3])
+	}
+}
+
+func TestApplySelectedOpsRevalidatesPartialPatch(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	base := patchReviewTestGraph(now)
+	ops := []PatchOp{
+		{Op: PatchAdd, Item: &plan.WorkItem{ID: "c", Title: "Task c", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo}},
+		{Op: PatchAddDep, ID: "c", DepID: "a"},
+		{Op: PatchSetDeps, ID: "b", Deps: []string{}},
+	}
+
+	next, err := ApplySelectedOps(base, ops, []bool{true, false, true}, now)
+	if err != nil {
+		t.Fatalf("ApplySelectedOps: %v", err)
+	}
+	if _, ok := next.Items["c"]; !ok || len(next.Items["c"].Deps) != 0 || len(next.Items["b"].Deps) != 0 {
+		t.Fatalf("next = %+v", next.Items)
+	}
+	if len(base.Items) != 2 {
+		t.Fatalf("base mutated: %+v", base.Items)
+	}
+
+	if _, err := ApplySelectedOps(base, ops, []bool{false, true, false}, now); err == nil || !strings.Contains(err.Error(), "patch[0] add_dep") {
+		t.Fatalf("expected add_dep without its add to fail, got %v", err)
+	}
+}
+
+func TestReviewSelectedOpsReviewsAgainstAcceptedOps(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	base := patchReviewTestGraph(now)
+	ops := []PatchOp{
+		{Op: PatchAdd, Item: &plan.WorkItem{ID: "c", Title: "Task c", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo}},
+		{Op: PatchAddDep, ID: "c", DepID: "a"},
+	}
+
+	if reviews := ReviewSelectedOps(base, ops, []bool{true, true}, now); reviews[1].Err != nil {
+		t.Fatalf("add_dep after accepted add = %+v", reviews[1])
+	}
+	reviews := ReviewSelectedOps(base, ops, []bool{false, true}, now)
+	if reviews[0].Err != nil || reviews[1].Err == nil {
+		t.Fatalf("add_dep after rejected add = %+v", reviews)
+	}
+}
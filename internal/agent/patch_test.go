@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -117,3 +118,45 @@ func TestApplyPatch_DeleteWithChildrenFails(t *testing.T) {
 		t.Fatalf("expected delete error due to children, got nil")
 	}
 }
+
+func TestApplyPatch_ChecksAddedExternalDeps(t *testing.T) {
+	now := time.Now().UTC()
+	root := t.TempDir()
+	item := func(id string) plan.WorkItem {
+		return plan.WorkItem{ID: id, Title: id, AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo, CreatedAt: now, UpdatedAt: now}
+	}
+	backend := plan.NewEmptyWorkGraph()
+	backend.Items["api"] = item("api")
+	if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(root, "backend"), plan.DefaultPlanFilename), backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+	planPath := filepath.Join(root, plan.DefaultPlanFilename)
+	web := plan.NewEmptyWorkGraph()
+	web.Items["web"] = item("web")
+	if err := plan.SaveAtomic(planPath, web); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	g, err := plan.Load(planPath)
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+
+	withDeps := item("client")
+	withDeps.Deps = []string{"plan:backend#missing"}
+	for name, ops := range map[string][]PatchOp{
+		"add_dep":  {{Op: PatchAddDep, ID: "web", DepID: "plan:backend#missing"}},
+		"set_deps": {{Op: PatchSetDeps, ID: "web", Deps: []string{"plan:backend#missing"}}},
+		"add":      {{Op: PatchAdd, Item: &withDeps}},
+		"update":   {{Op: PatchUpdate, ID: "web", Item: &withDeps}},
+	} {
+		next := plan.Clone(g)
+		if err := ApplyPatch(&next, ops, now); err == nil {
+			t.Fatalf("%s: expected the unknown external item to be rejected", name)
+		}
+	}
+
+	next := plan.Clone(g)
+	if err := ApplyPatch(&next, []PatchOp{{Op: PatchAddDep, ID: "web", DepID: "plan:backend#api"}}, now); err != nil {
+		t.Fatalf("apply patch with a known external item: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultRetryBackoff = 5 * time.Second
+	maxRetryBackoff     = 2 * time.Minute
+)
+
+// transientMarkers are lowercase stderr fragments that point at rate limits or
+// network trouble rather than a problem with the request itself.
+var transientMarkers = []string{
+	"rate limit",
+	"rate_limit",
+	"ratelimit",
+	"too many requests",
+	"overloaded",
+	"service unavailable",
+	"bad gateway",
+	"gateway timeout",
+	"econnreset",
+	"econnrefused",
+	"etimedout",
+	"eai_again",
+	"enotfound",
+	"connection reset",
+	"connection refused",
+	"network error",
+	"network is unreachable",
+	"temporary failure in name resolution",
+	"socket hang up",
+}
+
+// IsTransientFailure reports whether stderr from a command that exited
+// non-zero carries a rate-limit or network marker.
+func IsTransientFailure(stderr string) bool {
+	lower := strings.ToLower(stderr)
+	for _, marker := range transientMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryDelay is the wait before retry n (1-based): base doubled per retry,
+// capped at two minutes.
+func RetryDelay(base time.Duration, n int) time.Duration {
+	if base <= 0 {
+		base = DefaultRetryBackoff
+	}
+	delay := base
+	for i := 1; i < n && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryBackoff)
+}
+
+// SleepContext waits for d or until ctx is done, returning ctx.Err() in the
+// latter case.
+func SleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsTransientFailure(t *testing.T) {
+	cases := map[string]bool{
+		"Error: 429 Too Many Requests":                 true,
+		"API Error: Overloaded":                        true,
+		"request failed: read ECONNRESET":              true,
+		"dial tcp: connection refused":                 true,
+		"error: unknown flag --foo":                    false,
+		"":                                             false,
+		"panic: runtime error: index out of range [3]": false,
+	}
+	for stderr, want := range cases {
+		if got := IsTransientFailure(stderr); got != want {
+			t.Fatalf("IsTransientFailure(%q) = %v, want %v", stderr, got, want)
+		}
+	}
+
+	transient := RuntimeError{Message: "agent command failed", Diag: Diagnostics{Stderr: "rate limit exceeded"}}
+	if !isTransientRuntimeError(transient) {
+		t.Fatalf("expected failed command with rate-limit stderr to be transient")
+	}
+	timedOut := RuntimeError{Message: "agent command timed out", Diag: Diagnostics{Stderr: "rate limit exceeded"}}
+	if isTransientRuntimeError(timedOut) || isTransientRuntimeError(errors.New("rate limit")) {
+		t.Fatalf("expected timeouts and plain errors not to be transient")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if got := RetryDelay(time.Second, 1); got != time.Second {
+		t.Fatalf("first delay = %v", got)
+	}
+	if got := RetryDelay(time.Second, 3); got != 4*time.Second {
+		t.Fatalf("third delay = %v", got)
+	}
+	if got := RetryDelay(time.Minute, 5); got != maxRetryBackoff {
+		t.Fatalf("capped delay = %v", got)
+	}
+	if got := RetryDelay(0, 1); got != DefaultRetryBackoff {
+		t.Fatalf("default delay = %v", got)
+	}
+}
+
+func TestNewRuntimeFromEnvAppliesAgentConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(dir)
+	t.Setenv(EnvProvider, "")
+	t.Setenv(EnvCommand, "my-agent")
+
+	cfg := `{"schemaVersion":1,"agent":{"executionTimeoutSeconds":120,"planTimeoutSeconds":300,"maxRetries":3}}`
+	if err := os.MkdirAll(filepath.Join(dir, ".blackbird"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".blackbird", "config.json"), []byte(cfg), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	runtime, err := NewRuntimeFromEnv()
+	if err != nil {
+		t.Fatalf("NewRuntimeFromEnv() error = %v", err)
+	}
+	if runtime.Timeout != 5*time.Minute || runtime.TaskTimeout() != 2*time.Minute || runtime.MaxRetries != 3 {
+		t.Fatalf("runtime limits = timeout %v, task %v, retries %d", runtime.Timeout, runtime.TaskTimeout(), runtime.MaxRetries)
+	}
+}
@@ -11,6 +11,9 @@ import (
 	"os/exec"
 	"strings"
 	"time"
+
+	"github.com/jbonatakis/blackbird/internal/config"
+	"github.com/jbonatakis/blackbird/internal/plan"
 )
 
 const (
@@ -19,15 +22,25 @@ const (
 	EnvProvider    = "BLACKBIRD_AGENT_PROVIDER"
 	EnvCommand     = "BLACKBIRD_AGENT_CMD"
 	EnvStream      = "BLACKBIRD_AGENT_STREAM"
+	EnvMCP         = "BLACKBIRD_AGENT_MCP"
 )
 
 type Runtime struct {
-	Provider   string
-	Command    string
-	Args       []string
-	UseShell   bool
-	Timeout    time.Duration
-	MaxRetries int
+	Provider string
+	Command  string
+	Args     []string
+	UseShell bool
+	// Timeout limits each plan request attempt.
+	Timeout time.Duration
+	// ExecutionTimeout limits each task run attempt; zero falls back to Timeout.
+	ExecutionTimeout time.Duration
+	MaxRetries       int
+	// RetryBackoff is the wait before the first retry of a transient failure;
+	// it doubles for each further retry.
+	RetryBackoff time.Duration
+	// MCP registers the blackbird MCP server with execution runs
+	// (claude and codex commands only; see BLACKBIRD_AGENT_MCP).
+	MCP bool
 }
 
 type Diagnostics struct {
@@ -53,27 +66,35 @@ func (e RuntimeError) Unwrap() error {
 	return e.Cause
 }
 
+// NewRuntimeFromEnv builds the runtime for the current plan (see plan.PlanPath).
 func NewRuntimeFromEnv() (Runtime, error) {
+	return NewRuntimeForPlan(plan.PlanPath())
+}
+
+// NewRuntimeForPlan builds the runtime from the env vars, the agent selection
+// and the project config of the plan at planPath.
+func NewRuntimeForPlan(planPath string) (Runtime, error) {
 	provider := strings.ToLower(strings.TrimSpace(os.Getenv(EnvProvider)))
 	override := strings.TrimSpace(os.Getenv(EnvCommand))
 
 	if override != "" {
 		if provider == "" {
-			if selection, err := LoadAgentSelection(AgentSelectionPath()); err == nil && selection.Agent.ID != "" {
+			if selection, err := LoadAgentSelection(AgentSelectionPathFor(planPath)); err == nil && selection.Agent.ID != "" {
 				provider = string(selection.Agent.ID)
 			}
 		}
-		return Runtime{
+		return withConfiguredLimits(planPath, Runtime{
 			Provider:   provider,
 			Command:    override,
 			UseShell:   true,
 			Timeout:    DefaultTimeout,
 			MaxRetries: DefaultRetries,
-		}, nil
+			MCP:        mcpFromEnv(),
+		}), nil
 	}
 
 	if provider == "" {
-		if selection, err := LoadAgentSelection(AgentSelectionPath()); err == nil && selection.Agent.ID != "" {
+		if selection, err := LoadAgentSelection(AgentSelectionPathFor(planPath)); err == nil && selection.Agent.ID != "" {
 			provider = string(selection.Agent.ID)
 		}
 	}
@@ -86,12 +107,42 @@ func NewRuntimeFromEnv() (Runtime, error) {
 		return Runtime{}, fmt.Errorf("unsupported agent provider %q (set %s or %s)", provider, EnvProvider, EnvCommand)
 	}
 
-	return Runtime{
+	return withConfiguredLimits(planPath, Runtime{
 		Provider:   provider,
 		Command:    cmd,
 		Timeout:    DefaultTimeout,
 		MaxRetries: DefaultRetries,
-	}, nil
+		MCP:        mcpFromEnv(),
+	}), nil
+}
+
+// withConfiguredLimits applies the agent timeouts and retry count from the
+// project config of the plan at planPath and the global config.
+func withConfiguredLimits(planPath string, r Runtime) Runtime {
+	cfg, err := config.LoadConfig(plan.ProjectRoot(planPath))
+	if err != nil {
+		cfg = config.DefaultResolvedConfig()
+	}
+	r.Timeout = time.Duration(cfg.Agent.PlanTimeoutSeconds) * time.Second
+	r.ExecutionTimeout = time.Duration(cfg.Agent.ExecutionTimeoutSeconds) * time.Second
+	r.MaxRetries = cfg.Agent.MaxRetries
+	return r
+}
+
+// TaskTimeout is the time limit for one task run attempt.
+func (r Runtime) TaskTimeout() time.Duration {
+	switch {
+	case r.ExecutionTimeout > 0:
+		return r.ExecutionTimeout
+	case r.Timeout > 0:
+		return r.Timeout
+	default:
+		return DefaultTimeout
+	}
+}
+
+func mcpFromEnv() bool {
+	return strings.TrimSpace(os.Getenv(EnvMCP)) == "1"
 }
 
 func (r Runtime) Run(ctx context.Context, req Request) (Response, Diagnostics, error) {
@@ -127,11 +178,26 @@ func (r Runtime) Run(ctx context.Context, req Request) (Response, Diagnostics, e
 		}
 		lastErr = err
 		lastDiag = diag
+		if i+1 < attempts && isTransientRuntimeError(err) {
+			if sleepErr := SleepContext(ctx, RetryDelay(r.RetryBackoff, i+1)); sleepErr != nil {
+				break
+			}
+		}
 	}
 
 	return Response{}, lastDiag, lastErr
 }
 
+// isTransientRuntimeError reports a non-zero exit (not a timeout) whose stderr
+// looks like a rate limit or network failure.
+func isTransientRuntimeError(err error) bool {
+	var rtErr RuntimeError
+	if !errors.As(err, &rtErr) || rtErr.Message != "agent command failed" {
+		return false
+	}
+	return IsTransientFailure(rtErr.Diag.Stderr)
+}
+
 func (r Runtime) runOnce(ctx context.Context, payload []byte, meta RequestMetadata) (Response, Diagnostics, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.Timeout)
 	defer cancel()
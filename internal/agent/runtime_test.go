@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
 )
 
 func TestBuildFlagArgsJSONSchemaClaudeOnly(t *testing.T) {
@@ -114,3 +117,26 @@ func containsArg(args []string, target string) bool {
 	}
 	return false
 }
+
+func TestNewRuntimeForPlanReadsLimitsFromPlanProject(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".blackbird"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	cfg := `{"schemaVersion":1,"agent":{"planTimeoutSeconds":120,"maxRetries":3}}`
+	if err := os.WriteFile(filepath.Join(root, ".blackbird", "config.json"), []byte(cfg), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	t.Setenv(EnvProvider, "claude")
+	t.Setenv(EnvCommand, "")
+
+	runtime, err := NewRuntimeForPlan(filepath.Join(root, plan.DefaultPlanFilename))
+	if err != nil {
+		t.Fatalf("NewRuntimeForPlan() error = %v", err)
+	}
+	if runtime.Timeout != 120*time.Second || runtime.MaxRetries != 3 {
+		t.Fatalf("limits = %s/%d, want the plan project's 2m0s/3", runtime.Timeout, runtime.MaxRetries)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
 )
 
 const AgentSelectionSchemaVersion = 1
@@ -22,12 +24,15 @@ type agentSelectionFile struct {
 	SelectedAgent string `json:"selectedAgent"`
 }
 
+// AgentSelectionPath returns the agent selection file of the current plan,
+// so each named plan keeps its own selection.
 func AgentSelectionPath() string {
-	wd, err := os.Getwd()
-	if err != nil {
-		return filepath.Join(".blackbird", "agent.json")
-	}
-	return filepath.Join(wd, ".blackbird", "agent.json")
+	return AgentSelectionPathFor(plan.PlanPath())
+}
+
+// AgentSelectionPathFor returns the agent selection file of the plan at planPath.
+func AgentSelectionPathFor(planPath string) string {
+	return filepath.Join(filepath.Dir(planPath), ".blackbird", "agent.json")
 }
 
 func LoadAgentSelection(path string) (AgentSelection, error) {
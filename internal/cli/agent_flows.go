@@ -18,13 +18,19 @@ import (
 
 func runPlan(args []string) error {
 	if len(args) == 0 {
-		return UsageError{Message: "plan requires a subcommand: generate|refine"}
+		return UsageError{Message: "plan requires a subcommand: generate|refine|convert|split|join"}
 	}
 	switch args[0] {
 	case "generate":
 		return runPlanGenerate(args[1:])
 	case "refine":
 		return runPlanRefine(args[1:])
+	case "convert":
+		return runPlanConvert(args[1:])
+	case "split":
+		return runPlanSplit(args[1:])
+	case "join":
+		return runPlanJoin(args[1:])
 	default:
 		return UsageError{Message: fmt.Sprintf("unknown plan subcommand: %q", args[0])}
 	}
@@ -96,6 +102,10 @@ func runPlanGenerate(args []string) error {
 		return runAgentWithQuestions(ctx, runtime, req, agent.MaxPlanQuestionRounds)
 	}
 	qualityGatePassLimit := plangen.ResolveMaxAutoRefinePassesFromPlanPath(path)
+	qualityRules, err := plangen.ResolveQualityRulesFromPlanPath(path)
+	if err != nil {
+		return err
+	}
 	refineViaPlanRequest := func(ctx context.Context, changeRequest string, currentPlan plan.WorkGraph) (plan.WorkGraph, error) {
 		refineReqMeta := buildAgentMetadata(meta)
 		refineReqMeta.JSONSchema = agent.DefaultPlanJSONSchema()
@@ -118,11 +128,11 @@ func runPlanGenerate(args []string) error {
 		return plan.Clone(*refineResult.Plan), nil
 	}
 	applyQualityGate := func(candidate plan.WorkGraph) (planquality.QualityGateResult, error) {
-		initialFindings := planquality.Lint(candidate)
+		initialFindings := planquality.LintWithRules(candidate, qualityRules)
 		printQualitySummary(os.Stdout, "initial", initialFindings)
 
 		autoRefinePass := 0
-		result, err := plangen.RunQualityGate(context.Background(), candidate, qualityGatePassLimit, func(ctx context.Context, changeRequest string, currentPlan plan.WorkGraph) (plan.WorkGraph, error) {
+		result, err := plangen.RunQualityGateWithRules(context.Background(), candidate, qualityGatePassLimit, qualityRules, func(ctx context.Context, changeRequest string, currentPlan plan.WorkGraph) (plan.WorkGraph, error) {
 			autoRefinePass++
 			fmt.Fprintf(os.Stdout, "quality auto-refine pass %d/%d\n", autoRefinePass, qualityGatePassLimit)
 			return refineViaPlanRequest(ctx, changeRequest, currentPlan)
@@ -224,7 +234,7 @@ func runPlanGenerate(args []string) error {
 				}
 			case "accept_anyway":
 				fmt.Fprintln(os.Stdout, "WARNING: blocking findings were overridden; saving plan anyway")
-				if err := plan.SaveAtomic(path, proposed); err != nil {
+				if err := plan.SaveWithHistory(path, proposed, "plan generate"); err != nil {
 					return fmt.Errorf("write plan file: %w", err)
 				}
 				fmt.Fprintf(os.Stdout, "saved plan: %s\n", path)
@@ -242,7 +252,7 @@ func runPlanGenerate(args []string) error {
 		}
 		switch choice {
 		case "yes":
-			if err := plan.SaveAtomic(path, proposed); err != nil {
+			if err := plan.SaveWithHistory(path, proposed, "plan generate"); err != nil {
 				return fmt.Errorf("write plan file: %w", err)
 			}
 			fmt.Fprintf(os.Stdout, "saved plan: %s\n", path)
@@ -264,6 +274,7 @@ func runPlanRefine(args []string) error {
 	fs.SetOutput(io.Discard)
 
 	change := fs.String("change", "", "change request")
+	yes := fs.Bool("yes", false, "apply every operation without review or impact confirmation")
 	meta := addAgentMetaFlags(fs)
 
 	if err := fs.Parse(args); err != nil {
@@ -312,17 +323,34 @@ func runPlanRefine(args []string) error {
 		return formatAgentRunError(err, diag)
 	}
 
-	next, err := agent.ResponseToPlan(g, resp, time.Now().UTC())
+	now := time.Now().UTC()
+	next, err := agent.ResponseToPlan(g, resp, now)
 	if err != nil {
 		return err
 	}
 
-	diff := plan.Diff(g, next)
 	fmt.Fprintln(os.Stdout, "")
 	printProviderSummary(os.Stdout, runtime, req.Metadata)
-	printDiffSummary(os.Stdout, diff)
+	if len(resp.Patch) > 0 && !*yes && isTerminal(os.Stdin.Fd()) {
+		reviewed, accepted, err := reviewPatchOps(g, resp.Patch, now)
+		if err != nil {
+			return err
+		}
+		if accepted == 0 {
+			fmt.Fprintln(os.Stdout, "no operations accepted; plan unchanged")
+			return nil
+		}
+		next = reviewed
+	}
+	printDiffSummary(os.Stdout, plan.Diff(g, next))
+	if ok, err := previewImpact(path, g, next, *yes); err != nil || !ok {
+		if err == nil {
+			fmt.Fprintln(os.Stdout, "refine cancelled; plan unchanged")
+		}
+		return err
+	}
 
-	if err := plan.SaveAtomic(path, next); err != nil {
+	if err := plan.SaveWithHistory(path, next, "plan refine"); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 	fmt.Fprintf(os.Stdout, "updated plan: %s\n", path)
@@ -372,27 +400,40 @@ func runDepsInfer(args []string) error {
 		return formatAgentRunError(err, diag)
 	}
 
-	next, err := agent.ResponseToPlan(g, resp, time.Now().UTC())
+	now := time.Now().UTC()
+	next, err := agent.ResponseToPlan(g, resp, now)
 	if err != nil {
 		return err
 	}
 
-	diff := plan.Diff(g, next)
 	fmt.Fprintln(os.Stdout, "")
 	printProviderSummary(os.Stdout, runtime, req.Metadata)
-	printDiffSummary(os.Stdout, diff)
-	printDepsRationaleExcerpt(os.Stdout, next, 6)
+	if len(resp.Patch) > 0 && isTerminal(os.Stdin.Fd()) {
+		reviewed, accepted, err := reviewPatchOps(g, resp.Patch, now)
+		if err != nil {
+			return err
+		}
+		if accepted == 0 {
+			fmt.Fprintln(os.Stdout, "no operations accepted; plan unchanged")
+			return nil
+		}
+		next = reviewed
+		printDiffSummary(os.Stdout, plan.Diff(g, next))
+	} else {
+		printDiffSummary(os.Stdout, plan.Diff(g, next))
+		printDepsRationaleExcerpt(os.Stdout, next, 6)
 
-	confirm, err := promptConfirm("Apply dependency updates")
-	if err != nil {
-		return err
-	}
-	if !confirm {
-		fmt.Fprintln(os.Stdout, "aborted; plan unchanged")
-		return nil
+		confirm, err := promptConfirm("Apply dependency updates")
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Fprintln(os.Stdout, "aborted; plan unchanged")
+			return nil
+		}
 	}
 
-	if err := plan.SaveAtomic(path, next); err != nil {
+	if err := plan.SaveWithHistory(path, next, "deps infer"); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 	fmt.Fprintf(os.Stdout, "updated plan: %s\n", path)
@@ -419,5 +419,6 @@ func loadPlanIfExists(path string) (bool, plan.WorkGraph, error) {
 	if errs := plan.Validate(g); len(errs) != 0 {
 		return true, plan.WorkGraph{}, fmt.Errorf("plan is invalid (run `blackbird validate`): %s", path)
 	}
+	warnExternalDeps(os.Stderr, g)
 	return true, g, nil
 }
@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+type analyzeJSON struct {
+	SchemaVersion       int                  `json:"schemaVersion"`
+	Path                string               `json:"path"`
+	DurationSeconds     int64                `json:"durationSeconds"`
+	WaveDurationSeconds int64                `json:"waveDurationSeconds"`
+	MaxParallelism      int                  `json:"maxParallelism"`
+	CriticalPath        []string             `json:"criticalPath"`
+	Waves               []analyzeWaveJSON    `json:"waves"`
+	Tasks               []analyzeTaskJSON    `json:"tasks"`
+	WaitingOnExternal   []analyzeWaitingJSON `json:"waitingOnExternal"`
+	Unschedulable       []analyzeBlockedJSON `json:"unschedulable"`
+}
+
+type analyzeWaveJSON struct {
+	Wave            int      `json:"wave"`
+	TaskIDs         []string `json:"taskIds"`
+	Parallelism     int      `json:"parallelism"`
+	DurationSeconds int64    `json:"durationSeconds"`
+}
+
+type analyzeTaskJSON struct {
+	ID                   string              `json:"id"`
+	Title                string              `json:"title"`
+	Wave                 int                 `json:"wave"`
+	DurationSeconds      int64               `json:"durationSeconds"`
+	DurationSource       plan.DurationSource `json:"durationSource"`
+	EarliestStartSeconds int64               `json:"earliestStartSeconds"`
+	SlackSeconds         int64               `json:"slackSeconds"`
+	Critical             bool                `json:"critical"`
+	Deps                 []string            `json:"deps"`
+}
+
+type analyzeWaitingJSON struct {
+	ID        string   `json:"id"`
+	WaitingOn []string `json:"waitingOn"`
+}
+
+type analyzeBlockedJSON struct {
+	ID        string   `json:"id"`
+	BlockedBy []string `json:"blockedBy"`
+}
+
+func runAnalyze(args []string) error {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "analyze takes only flags (no positional args)"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	path := plan.PlanPath()
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+	ids := make([]string, 0, len(g.Items))
+	for id := range g.Items {
+		ids = append(ids, id)
+	}
+	history, err := execution.AverageRunDurations(filepath.Dir(path), ids)
+	if err != nil {
+		return err
+	}
+	schedule := plan.AnalyzeSchedule(g, history)
+
+	if asJSON {
+		return writeJSON(os.Stdout, newAnalyzeJSON(path, g, schedule))
+	}
+	printSchedule(os.Stdout, g, schedule)
+	return nil
+}
+
+func newAnalyzeJSON(path string, g plan.WorkGraph, s plan.Schedule) analyzeJSON {
+	doc := analyzeJSON{
+		SchemaVersion:       OutputSchemaVersion,
+		Path:                path,
+		DurationSeconds:     int64(s.Duration / time.Second),
+		WaveDurationSeconds: int64(s.WaveDuration / time.Second),
+		MaxParallelism:      s.MaxParallelism,
+		CriticalPath:        append([]string{}, s.CriticalPath...),
+		Waves:               []analyzeWaveJSON{},
+		Tasks:               []analyzeTaskJSON{},
+		WaitingOnExternal:   []analyzeWaitingJSON{},
+		Unschedulable:       []analyzeBlockedJSON{},
+	}
+	for i, w := range s.Waves {
+		doc.Waves = append(doc.Waves, analyzeWaveJSON{
+			Wave:            i + 1,
+			TaskIDs:         w.TaskIDs,
+			Parallelism:     len(w.TaskIDs),
+			DurationSeconds: int64(w.Duration / time.Second),
+		})
+	}
+	for _, t := range s.Tasks {
+		doc.Tasks = append(doc.Tasks, analyzeTaskJSON{
+			ID:                   t.ID,
+			Title:                g.Items[t.ID].Title,
+			Wave:                 t.Wave + 1,
+			DurationSeconds:      int64(t.Duration / time.Second),
+			DurationSource:       t.Source,
+			EarliestStartSeconds: int64(t.EarliestStart / time.Second),
+			SlackSeconds:         int64(t.Slack / time.Second),
+			Critical:             t.Critical,
+			Deps:                 append([]string{}, t.Deps...),
+		})
+	}
+	for _, e := range s.WaitingOnExternal {
+		doc.WaitingOnExternal = append(doc.WaitingOnExternal, analyzeWaitingJSON{ID: e.ID, WaitingOn: e.WaitingOn})
+	}
+	for _, u := range s.Unschedulable {
+		doc.Unschedulable = append(doc.Unschedulable, analyzeBlockedJSON{ID: u.ID, BlockedBy: u.BlockedBy})
+	}
+	return doc
+}
+
+func printSchedule(w io.Writer, g plan.WorkGraph, s plan.Schedule) {
+	if len(s.Tasks) == 0 && len(s.WaitingOnExternal) == 0 && len(s.Unschedulable) == 0 {
+		fmt.Fprintln(w, "no remaining tasks")
+		return
+	}
+	fmt.Fprintf(w, "Remaining tasks: %d", len(s.Tasks)+len(s.WaitingOnExternal)+len(s.Unschedulable))
+	var notes []string
+	if len(s.WaitingOnExternal) > 0 {
+		notes = append(notes, fmt.Sprintf("%d waiting on other plans", len(s.WaitingOnExternal)))
+	}
+	if len(s.Unschedulable) > 0 {
+		notes = append(notes, fmt.Sprintf("%d unschedulable", len(s.Unschedulable)))
+	}
+	if len(notes) > 0 {
+		fmt.Fprintf(w, " (%s)", strings.Join(notes, ", "))
+	}
+	fmt.Fprintln(w)
+	if len(s.Tasks) > 0 {
+		fmt.Fprintf(w, "Critical path: %s (%s)\n", formatScheduleDuration(s.Duration), strings.Join(s.CriticalPath, " -> "))
+		fmt.Fprintf(w, "Wave-by-wave finish: %s\n", formatScheduleDuration(s.WaveDuration))
+		fmt.Fprintf(w, "Max parallelism: %d\n", s.MaxParallelism)
+	}
+
+	byID := map[string]plan.ScheduledTask{}
+	for _, t := range s.Tasks {
+		byID[t.ID] = t
+	}
+	for i, wave := range s.Waves {
+		fmt.Fprintf(w, "\nWave %d (%d task(s), %s):\n", i+1, len(wave.TaskIDs), formatScheduleDuration(wave.Duration))
+		tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+		for _, id := range wave.TaskIDs {
+			t := byID[id]
+			marker := " "
+			if t.Critical {
+				marker = "*"
+			}
+			duration := formatScheduleDuration(t.Duration)
+			if t.Source == plan.DurationUnknown {
+				duration = "-"
+			}
+			fmt.Fprintf(tw, "  %s %s\t%s\t%s\t%s\tslack %s\n", marker, id, g.Items[id].Title, duration, t.Source, formatScheduleDuration(t.Slack))
+		}
+		_ = tw.Flush()
+	}
+	if len(s.Waves) > 0 {
+		fmt.Fprintln(w, "\n* no slack: a delay here delays the finish")
+	}
+
+	if len(s.WaitingOnExternal) > 0 {
+		fmt.Fprintln(w, "\nWaiting on other plans:")
+		for _, e := range s.WaitingOnExternal {
+			fmt.Fprintf(w, "  %s waits on %s\n", e.ID, strings.Join(e.WaitingOn, ", "))
+		}
+	}
+	if len(s.Unschedulable) > 0 {
+		fmt.Fprintln(w, "\nUnschedulable:")
+		for _, u := range s.Unschedulable {
+			fmt.Fprintf(w, "  %s waits on %s\n", u.ID, strings.Join(u.BlockedBy, ", "))
+		}
+	}
+}
+
+// formatScheduleDuration renders d to the second without zero trailing units
+// ("1h30m", "45m", "20s").
+func formatScheduleDuration(d time.Duration) string {
+	s := d.Round(time.Second).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
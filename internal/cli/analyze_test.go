@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestRunAnalyzeUsesEstimatesAndRunHistory(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	a := newWorkItem("a", now)
+	estimate := 30
+	a.EstimateMinutes = &estimate
+	b := newWorkItem("b", now)
+	c := newWorkItem("c", now)
+	c.Deps = []string{"a", "b"}
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"a": a, "b": b, "c": c}}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	completed := now.Add(time.Hour)
+	if err := execution.SaveRun(tempDir, execution.RunRecord{
+		ID:          "run-1",
+		TaskID:      "b",
+		StartedAt:   now,
+		CompletedAt: &completed,
+		Status:      execution.RunStatusSuccess,
+		Context:     execution.ContextPack{SchemaVersion: execution.ContextPackSchemaVersion, Task: execution.TaskContext{ID: "b"}},
+	}); err != nil {
+		t.Fatalf("save run: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runAnalyze(nil) })
+	if err != nil {
+		t.Fatalf("runAnalyze: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"Remaining tasks: 3",
+		"Critical path: 2h (b -> c)",
+		"Wave-by-wave finish: 2h",
+		"Max parallelism: 2",
+		"Wave 1 (2 task(s), 1h):",
+		"a", "30m", "estimate", "slack 30m",
+		"* b", "1h", "history",
+		"Wave 2 (1 task(s), 1h):",
+		"* c", "1h", "average",
+	})
+
+	output, err = captureStdout(func() error { return runAnalyze([]string{"--json"}) })
+	if err != nil {
+		t.Fatalf("runAnalyze --json: %v", err)
+	}
+	var doc analyzeJSON
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, output)
+	}
+	if doc.DurationSeconds != 7200 || doc.MaxParallelism != 2 || !reflect.DeepEqual(doc.CriticalPath, []string{"b", "c"}) {
+		t.Fatalf("json = %+v", doc)
+	}
+	if len(doc.Waves) != 2 || doc.Waves[0].Parallelism != 2 || len(doc.Tasks) != 3 {
+		t.Fatalf("waves = %+v tasks = %+v", doc.Waves, doc.Tasks)
+	}
+}
+
+func TestRunAnalyzeListsTasksWaitingOnOtherPlans(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	t.Setenv(plan.PlanEnvVar, "")
+
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	backend := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": newWorkItem("api", now)}}
+	if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(tempDir, "backend"), plan.DefaultPlanFilename), backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+	web := newWorkItem("web", now)
+	web.Deps = []string{"plan:backend#api"}
+	docs := newWorkItem("docs", now)
+	docs.Deps = []string{"web"}
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"web": web, "docs": docs}}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runAnalyze(nil) })
+	if err != nil {
+		t.Fatalf("runAnalyze: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"Remaining tasks: 2 (2 waiting on other plans)",
+		"Waiting on other plans:",
+		"docs waits on web",
+		"web waits on plan:backend#api",
+	})
+
+	output, err = captureStdout(func() error { return runAnalyze([]string{"--json"}) })
+	if err != nil {
+		t.Fatalf("runAnalyze --json: %v", err)
+	}
+	var doc analyzeJSON
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, output)
+	}
+	if len(doc.WaitingOnExternal) != 2 || len(doc.Unschedulable) != 0 {
+		t.Fatalf("waiting = %+v unschedulable = %+v", doc.WaitingOnExternal, doc.Unschedulable)
+	}
+}
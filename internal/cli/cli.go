@@ -26,33 +26,72 @@ func Usage() string {
 
 Usage:
   blackbird init
-  blackbird validate
+  blackbird validate [--json]
+  blackbird lint [--severity blocking|warning] [--task <id>] [--fix] [--json]
+  blackbird analyze [--json]
+  blackbird impact <id> [--json]
+  blackbird diff [<git-rev>|<file>] [--json]
+  blackbird import <file.md> [--yes]
   blackbird plan generate [--description <text>] [--constraint <text> ...] [--granularity <text>] [--model <model>] [--max-tokens <n>] [--temperature <n>] [--response-format <fmt>]
-  blackbird plan refine [--change <text>] [--model <model>] [--max-tokens <n>] [--temperature <n>] [--response-format <fmt>]
-  blackbird list [--all] [--blocked] [--tree] [--features] [--status <status>]
-  blackbird pick [--include-non-leaf] [--all] [--blocked]
-  blackbird show <id>
+  blackbird plan refine [--change <text>] [--yes] [--model <model>] [--max-tokens <n>] [--temperature <n>] [--response-format <fmt>]
+  blackbird plan convert [--to json|yaml] [--keep]
+  blackbird plan split
+  blackbird plan join
+  blackbird list [--all] [--blocked] [--tree] [--features] [--status <status>] [--json]
+  blackbird pick [--include-non-leaf] [--all] [--blocked] [--json]
+  blackbird show <id> [--json]
   blackbird set-status <id> <status>
   blackbird add [--id <id>] [--title <title>] [--description <text>] [--prompt <text>] [--notes <text>] [--ac <text> ...] [--parent <parentId|root>] [--index <n>]
-  blackbird edit <id> [--title <title>] [--description <text>|--clear-description] [--prompt <text>|--clear-prompt] [--notes <text>] [--clear-notes] [--ac <text> ...] [--ac-clear]
-  blackbird delete <id> [--cascade-children] [--force]
-  blackbird move <id> --parent <parentId|root> [--index <n>]
+  blackbird add --template <name> [--param <name=value> ...] [--parent <parentId|root>] [--index <n>]
+  blackbird templates [--json]
+  blackbird plans [--json]
+  blackbird edit <id> [--title <title>] [--description <text>|--clear-description] [--prompt <text>|--clear-prompt] [--notes <text>] [--clear-notes] [--estimate <minutes>|--clear-estimate] [--ac <text> ...] [--ac-clear]
+  blackbird delete <id> [--cascade-children] [--force] [--prune-runs] [--yes]
+  blackbird move <id> --parent <parentId|root> [--index <n>] [--yes]
   blackbird deps add <id> <depId>
   blackbird deps remove <id> <depId>
   blackbird deps set <id> [<depId> ...]
   blackbird deps infer [--hint <text> ...] [--model <model>] [--max-tokens <n>] [--temperature <n>] [--response-format <fmt>]
-  blackbird runs <taskID> [--verbose]
+  blackbird undo [--force]
+  blackbird redo [--force]
+  blackbird history [--json]
+  blackbird runs <taskID> [--verbose] [--json]
+  blackbird gc [--keep <n>] [--older-than <age>] [--archive] [--orphans] [--dry-run] [--json]
   blackbird execute
   blackbird resume <taskID>
+  blackbird questions [--json]
+  blackbird questions answer [<taskID> ...]
   blackbird retry <taskID>
+  blackbird serve [--addr <host:port>]
+  blackbird mcp [--task <id>]
+  blackbird proposals [list|show <id>|approve <id>|reject <id>]
+  blackbird webhooks test
+  blackbird merge-driver <base> <ours> <theirs> [<path>]
   blackbird --version
 
+Read commands accept --json (or --output json) for machine-readable output.
+blackbird --plan <name|path> <command> ... (or BLACKBIRD_PLAN) picks a plan other than blackbird.plan.json.
+
 Statuses:
   todo | queued | in_progress | waiting_user | blocked | done | failed | skipped
 `
 }
 
 func Run(args []string) error {
+	args, planRef, err := extractPlanFlag(args)
+	if err != nil {
+		return err
+	}
+	if planRef != "" {
+		if err := os.Setenv(plan.PlanEnvVar, planRef); err != nil {
+			return err
+		}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if err := plan.CheckPlanRef(wd, os.Getenv(plan.PlanEnvVar)); err != nil {
+			return err
+		}
+	}
 	if len(args) == 0 {
 		return tui.Start()
 	}
@@ -67,10 +106,24 @@ func Run(args []string) error {
 		}
 		return runInit()
 	case "validate":
-		if len(args) != 1 {
-			return UsageError{Message: "validate takes no arguments"}
+		return runValidate(args[1:])
+	case "lint":
+		return runLint(args[1:])
+	case "analyze":
+		return runAnalyze(args[1:])
+	case "impact":
+		return runImpact(args[1:])
+	case "diff":
+		return runDiff(args[1:])
+	case "templates":
+		return runTemplates(args[1:])
+	case "plans":
+		return runPlans(args[1:])
+	case "import":
+		if len(args) < 2 {
+			return UsageError{Message: "import requires a file: import <file.md> [--yes]"}
 		}
-		return runValidate()
+		return runImport(args[1], args[2:])
 	case "list":
 		return runList(args[1:])
 	case "pick":
@@ -78,10 +131,7 @@ func Run(args []string) error {
 	case "plan":
 		return runPlan(args[1:])
 	case "show":
-		if len(args) != 2 {
-			return UsageError{Message: "show requires exactly 1 argument: <id>"}
-		}
-		return runShow(args[1])
+		return runShowCommand(args[1:])
 	case "set-status":
 		if len(args) != 3 {
 			return UsageError{Message: "set-status requires exactly 2 arguments: <id> <status>"}
@@ -106,8 +156,16 @@ func Run(args []string) error {
 		return runMove(args[1], args[2:])
 	case "deps":
 		return runDeps(args[1:])
+	case "undo":
+		return runUndo(args[1:])
+	case "redo":
+		return runRedo(args[1:])
+	case "history":
+		return runHistory(args[1:])
 	case "runs":
 		return runRuns(args[1:])
+	case "gc":
+		return runGC(args[1:])
 	case "execute":
 		return runExecute(args[1:])
 	case "resume":
@@ -120,6 +178,18 @@ func Run(args []string) error {
 			return UsageError{Message: "retry requires exactly 1 argument: <taskID>"}
 		}
 		return runRetry(args[1])
+	case "serve":
+		return runServe(args[1:])
+	case "mcp":
+		return runMCP(args[1:])
+	case "proposals":
+		return runProposals(args[1:])
+	case "webhooks":
+		return runWebhooks(args[1:])
+	case "questions":
+		return runQuestions(args[1:])
+	case "merge-driver":
+		return runMergeDriver(args[1:])
 	default:
 		return UsageError{Message: fmt.Sprintf("unknown command: %q", args[0])}
 	}
@@ -128,6 +198,10 @@ func Run(args []string) error {
 func runInit() error {
 	path := plan.PlanPath()
 
+	if plan.IsSplit(path) {
+		fmt.Fprintf(os.Stdout, "plan already exists: %s\n", plan.SplitDir(path))
+		return nil
+	}
 	_, err := os.Stat(path)
 	if err == nil {
 		fmt.Fprintf(os.Stdout, "plan already exists: %s\n", path)
@@ -138,7 +212,7 @@ func runInit() error {
 	}
 
 	g := plan.NewEmptyWorkGraph()
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "init"); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 
@@ -146,7 +220,21 @@ func runInit() error {
 	return nil
 }
 
-func runValidate() error {
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "validate takes only flags (no positional args)"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
 	path := plan.PlanPath()
 
 	g, err := plan.Load(path)
@@ -157,7 +245,21 @@ func runValidate() error {
 		return err
 	}
 
-	errs := plan.Validate(g)
+	errs := append(plan.Validate(g), plan.ExternalDepErrors(g)...)
+	if asJSON {
+		if err := writeJSON(os.Stdout, validateJSON{
+			SchemaVersion: OutputSchemaVersion,
+			Path:          path,
+			Valid:         len(errs) == 0,
+			Errors:        newValidationErrorsJSON(errs),
+		}); err != nil {
+			return err
+		}
+		if len(errs) != 0 {
+			return errors.New("validation failed")
+		}
+		return nil
+	}
 	if len(errs) == 0 {
 		fmt.Fprintln(os.Stdout, "OK")
 		return nil
@@ -179,6 +281,7 @@ func runList(args []string) error {
 	tree := fs.Bool("tree", false, "show the full hierarchy tree")
 	features := fs.Bool("features", false, "show top-level items only")
 	statusStr := fs.String("status", "", "filter by status")
+	out := addOutputFlags(fs)
 
 	if err := fs.Parse(args); err != nil {
 		return UsageError{Message: err.Error()}
@@ -186,6 +289,10 @@ func runList(args []string) error {
 	if fs.NArg() != 0 {
 		return UsageError{Message: "list takes only flags (no positional args)"}
 	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
 
 	var statusFilter *plan.Status
 	if *statusStr != "" {
@@ -197,18 +304,19 @@ func runList(args []string) error {
 	}
 
 	path := plan.PlanPath()
-	g, err := plan.Load(path)
+	g, err := loadValidatedPlan(path)
 	if err != nil {
-		if errors.Is(err, plan.ErrPlanNotFound) {
-			return fmt.Errorf("plan file not found: %s (run `blackbird init`)", path)
-		}
 		return err
 	}
-	if errs := plan.Validate(g); len(errs) != 0 {
-		return fmt.Errorf("plan is invalid (run `blackbird validate`): %s", path)
-	}
 
 	if *tree {
+		if asJSON {
+			items := make([]itemJSON, 0, len(g.Items))
+			for _, id := range treeOrder(g) {
+				items = append(items, newItemJSON(g, g.Items[id]))
+			}
+			return writeJSON(os.Stdout, listJSON{SchemaVersion: OutputSchemaVersion, Items: items})
+		}
 		printTree(os.Stdout, g)
 		return nil
 	}
@@ -228,6 +336,7 @@ func runList(args []string) error {
 	}
 
 	var rows []row
+	items := []itemJSON{}
 	for _, id := range ids {
 		it, ok := g.Items[id]
 		if !ok {
@@ -264,6 +373,7 @@ func runList(args []string) error {
 			details = "manually blocked (deps satisfied)"
 		}
 
+		items = append(items, newItemJSON(g, it))
 		rows = append(rows, row{
 			id:      it.ID,
 			status:  it.Status,
@@ -273,6 +383,10 @@ func runList(args []string) error {
 		})
 	}
 
+	if asJSON {
+		return writeJSON(os.Stdout, listJSON{SchemaVersion: OutputSchemaVersion, Items: items})
+	}
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
 	for _, r := range rows {
 		if r.details != "" {
@@ -285,18 +399,50 @@ func runList(args []string) error {
 	return nil
 }
 
+func runShowCommand(args []string) error {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	id, err := parseWithPositional(fs, args)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return UsageError{Message: "show requires exactly 1 argument: <id>"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return runShowJSON(id)
+	}
+	return runShow(id)
+}
+
+func runShowJSON(id string) error {
+	g, err := loadValidatedPlan(plan.PlanPath())
+	if err != nil {
+		return err
+	}
+	it, ok := g.Items[id]
+	if !ok {
+		return fmt.Errorf("unknown id %q", id)
+	}
+	return writeJSON(os.Stdout, showJSON{
+		SchemaVersion: OutputSchemaVersion,
+		Item:          newItemJSON(g, it),
+		Deps:          newItemRefs(g, it.Deps),
+		Dependents:    newItemRefs(g, plan.Dependents(g, id)),
+	})
+}
+
 func runShow(id string) error {
 	path := plan.PlanPath()
-	g, err := plan.Load(path)
+	g, err := loadValidatedPlan(path)
 	if err != nil {
-		if errors.Is(err, plan.ErrPlanNotFound) {
-			return fmt.Errorf("plan file not found: %s (run `blackbird init`)", path)
-		}
 		return err
 	}
-	if errs := plan.Validate(g); len(errs) != 0 {
-		return fmt.Errorf("plan is invalid (run `blackbird validate`): %s", path)
-	}
 
 	it, ok := g.Items[id]
 	if !ok {
@@ -333,11 +479,20 @@ func runShow(id string) error {
 		fmt.Fprintf(os.Stdout, "%s\n\n", *it.Notes)
 	}
 
+	var localDeps, externalDeps []string
+	for _, depID := range it.Deps {
+		if plan.IsExternalDep(depID) {
+			externalDeps = append(externalDeps, depID)
+		} else {
+			localDeps = append(localDeps, depID)
+		}
+	}
+
 	fmt.Fprintln(os.Stdout, "Deps:")
-	if len(it.Deps) == 0 {
+	if len(localDeps) == 0 {
 		fmt.Fprintln(os.Stdout, "- (none)")
 	} else {
-		for _, depID := range it.Deps {
+		for _, depID := range localDeps {
 			dep, ok := g.Items[depID]
 			if !ok {
 				fmt.Fprintf(os.Stdout, "- %s [unknown]\n", depID)
@@ -348,6 +503,19 @@ func runShow(id string) error {
 	}
 	fmt.Fprintln(os.Stdout)
 
+	if len(externalDeps) > 0 {
+		fmt.Fprintln(os.Stdout, "External deps:")
+		for _, depID := range externalDeps {
+			_, dep, err := plan.ResolveExternalDep(g, depID)
+			if err != nil {
+				fmt.Fprintf(os.Stdout, "- %s [unknown]\n", depID)
+				continue
+			}
+			fmt.Fprintf(os.Stdout, "- %s [%s] %s\n", depID, dep.Status, dep.Title)
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+
 	fmt.Fprintln(os.Stdout, "Dependents:")
 	if len(dependents) == 0 {
 		fmt.Fprintln(os.Stdout, "- (none)")
@@ -393,23 +561,17 @@ func runSetStatus(id string, statusStr string) error {
 	}
 
 	path := plan.PlanPath()
-	g, err := plan.Load(path)
+	g, err := loadValidatedPlan(path)
 	if err != nil {
-		if errors.Is(err, plan.ErrPlanNotFound) {
-			return fmt.Errorf("plan file not found: %s (run `blackbird init`)", path)
-		}
 		return err
 	}
-	if errs := plan.Validate(g); len(errs) != 0 {
-		return fmt.Errorf("plan is invalid (run `blackbird validate`): %s", path)
-	}
 
 	now := time.Now().UTC()
 	if err := plan.SetStatus(&g, id, s, now); err != nil {
 		return err
 	}
 
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "set-status "+id+" "+string(s)); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 
@@ -432,6 +594,31 @@ func rootIDs(g plan.WorkGraph) []string {
 	return append([]string{}, tree.Roots...)
 }
 
+// treeOrder returns item ids in depth-first tree order, as printTree renders them.
+func treeOrder(g plan.WorkGraph) []string {
+	tree := plan.BuildTaskTree(g)
+	out := make([]string, 0, len(g.Items))
+	visited := map[string]bool{}
+	var walk func(id string)
+	walk = func(id string) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		if _, ok := g.Items[id]; !ok {
+			return
+		}
+		out = append(out, id)
+		for _, cid := range tree.Children[id] {
+			walk(cid)
+		}
+	}
+	for _, id := range tree.Roots {
+		walk(id)
+	}
+	return out
+}
+
 func printTree(w io.Writer, g plan.WorkGraph) {
 	tree := plan.BuildTaskTree(g)
 	if len(tree.Roots) == 0 {
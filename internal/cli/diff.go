@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+type diffJSON struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Path          string         `json:"path"`
+	Against       string         `json:"against"`
+	Summary       diffCountsJSON `json:"summary"`
+	Items         []diffItemJSON `json:"items"`
+}
+
+type diffCountsJSON struct {
+	Added       int `json:"added"`
+	Removed     int `json:"removed"`
+	Updated     int `json:"updated"`
+	Moved       int `json:"moved"`
+	DepsAdded   int `json:"depsAdded"`
+	DepsRemoved int `json:"depsRemoved"`
+}
+
+type diffItemJSON struct {
+	ID     string          `json:"id"`
+	Kind   string          `json:"kind"`
+	Title  string          `json:"title"`
+	Fields []diffFieldJSON `json:"fields,omitempty"`
+}
+
+type diffFieldJSON struct {
+	Field     string         `json:"field"`
+	Before    string         `json:"before,omitempty"`
+	After     string         `json:"after,omitempty"`
+	Lines     []diffLineJSON `json:"lines,omitempty"`
+	Removed   []string       `json:"removed,omitempty"`
+	Added     []string       `json:"added,omitempty"`
+	Reordered bool           `json:"reordered,omitempty"`
+}
+
+type diffLineJSON struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+func runDiff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	against, err := parseWithPositional(fs, args)
+	if err != nil {
+		return err
+	}
+	if against == "" {
+		against = "HEAD"
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	path := plan.PlanPath()
+	current, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+	other, err := loadPlanToCompare(path, against)
+	if err != nil {
+		return err
+	}
+
+	d := plan.DetailedDiff(other, current)
+	if asJSON {
+		return writeJSON(os.Stdout, newDiffJSON(path, against, d))
+	}
+	if len(d.Items) == 0 {
+		fmt.Fprintf(os.Stdout, "no differences from %s\n", against)
+		return nil
+	}
+	printDiffSummary(os.Stdout, d.DiffSummary)
+	printDetailedDiff(os.Stdout, d)
+	return nil
+}
+
+// loadPlanToCompare loads against as a plan file if it exists, else as the
+// plan at planPath as of that git revision.
+func loadPlanToCompare(planPath, against string) (plan.WorkGraph, error) {
+	if _, err := os.Stat(against); err == nil {
+		return plan.Load(against)
+	}
+	return loadPlanAtRevision(planPath, against)
+}
+
+// loadPlanAtRevision reads the plan at planPath as of a git revision, trying
+// the plan's own file name first (a plan selected by path may use any name),
+// then the same layouts as plan.PlanPathIn: JSON, YAML, then the split layout.
+func loadPlanAtRevision(planPath, rev string) (plan.WorkGraph, error) {
+	dir := filepath.Dir(planPath)
+	if _, err := gitShow(dir, "rev-parse", "--verify", "--quiet", rev+"^{commit}"); err != nil {
+		return plan.WorkGraph{}, fmt.Errorf("%q is neither a plan file nor a git revision", rev)
+	}
+	show := func(file string) ([]byte, error) {
+		return gitShow(dir, "show", rev+":./"+file)
+	}
+	names := []string{filepath.Base(planPath)}
+	for _, name := range []string{plan.DefaultPlanFilename, plan.YAMLPlanFilename, plan.YMLPlanFilename} {
+		if name != names[0] {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		if b, err := show(name); err == nil {
+			return plan.Decode(rev+":"+name, b, plan.FormatForPath(name))
+		}
+	}
+	if _, err := show(path.Join(plan.SplitPlanDirName, plan.SplitIndexFilename)); err == nil {
+		return plan.LoadSplitFrom(rev+":"+plan.SplitPlanDirName, func(file string) ([]byte, error) {
+			return show(path.Join(plan.SplitPlanDirName, file))
+		})
+	}
+	return plan.WorkGraph{}, fmt.Errorf("no plan at %s", rev)
+}
+
+func gitShow(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func printDetailedDiff(w io.Writer, d plan.DetailedDiffSummary) {
+	marks := map[plan.ItemChangeKind]string{plan.ItemAdded: "+", plan.ItemRemoved: "-", plan.ItemUpdated: "~"}
+	for _, it := range d.Items {
+		fmt.Fprintf(w, "\n%s %s %q\n", marks[it.Kind], it.ID, it.Title)
+		printFieldChanges(w, it.Fields, "  ")
+	}
+}
+
+// printFieldChanges prints one field per line, or a field header followed by
+// its line or list changes, each line prefixed with indent.
+func printFieldChanges(w io.Writer, fields []plan.FieldChange, indent string) {
+	for _, f := range fields {
+		switch {
+		case f.Reordered:
+			fmt.Fprintf(w, "%s%s: reordered\n", indent, f.Field)
+		case len(f.Lines) > 0:
+			fmt.Fprintf(w, "%s%s:\n", indent, f.Field)
+			for _, line := range f.Lines {
+				fmt.Fprintf(w, "%s  %s %s\n", indent, line.Op, line.Text)
+			}
+		case len(f.Removed) > 0 || len(f.Added) > 0:
+			fmt.Fprintf(w, "%s%s:\n", indent, f.Field)
+			for _, v := range f.Removed {
+				fmt.Fprintf(w, "%s  - %s\n", indent, v)
+			}
+			for _, v := range f.Added {
+				fmt.Fprintf(w, "%s  + %s\n", indent, v)
+			}
+		default:
+			fmt.Fprintf(w, "%s%s: %s -> %s\n", indent, f.Field, diffScalar(f.Before), diffScalar(f.After))
+		}
+	}
+}
+
+func diffScalar(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return fmt.Sprintf("%q", v)
+}
+
+func newDiffJSON(path, against string, d plan.DetailedDiffSummary) diffJSON {
+	doc := diffJSON{
+		SchemaVersion: OutputSchemaVersion,
+		Path:          path,
+		Against:       against,
+		Summary: diffCountsJSON{
+			Added:       len(d.Added),
+			Removed:     len(d.Removed),
+			Updated:     len(d.Updated),
+			Moved:       len(d.Moved),
+			DepsAdded:   len(d.DepsAdded),
+			DepsRemoved: len(d.DepsRemoved),
+		},
+		Items: []diffItemJSON{},
+	}
+	for _, it := range d.Items {
+		item := diffItemJSON{ID: it.ID, Kind: string(it.Kind), Title: it.Title}
+		for _, f := range it.Fields {
+			field := diffFieldJSON{
+				Field:     f.Field,
+				Before:    f.Before,
+				After:     f.After,
+				Removed:   f.Removed,
+				Added:     f.Added,
+				Reordered: f.Reordered,
+			}
+			for _, line := range f.Lines {
+				field.Lines = append(field.Lines, diffLineJSON{Op: string(line.Op), Text: line.Text})
+			}
+			item.Fields = append(item.Fields, field)
+		}
+		doc.Items = append(doc.Items, item)
+	}
+	return doc
+}
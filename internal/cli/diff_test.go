@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func diffTestPlans(t *testing.T, now time.Time) (before, after plan.WorkGraph) {
+	t.Helper()
+	a := newWorkItem("A", now)
+	a.Description = "first\nsecond"
+	a.AcceptanceCriteria = []string{"works"}
+	before = plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"A": a, "B": newWorkItem("B", now)}}
+
+	after = plan.Clone(before)
+	changed := after.Items["A"]
+	changed.Title = "A renamed"
+	changed.Description = "first\n2nd"
+	changed.AcceptanceCriteria = []string{"works", "tested"}
+	after.Items["A"] = changed
+	delete(after.Items, "B")
+	after.Items["C"] = newWorkItem("C", now)
+	return before, after
+}
+
+func TestRunDiffAgainstFile(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	before, after := diffTestPlans(t, time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))
+	oldPath := filepath.Join(tempDir, "old.plan.json")
+	if err := plan.SaveAtomic(oldPath, before); err != nil {
+		t.Fatalf("save old plan: %v", err)
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), after); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runDiff([]string{oldPath}) })
+	if err != nil {
+		t.Fatalf("runDiff: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"Diff summary: added 1, removed 1, updated 1",
+		`~ A "A renamed"`,
+		`  title: "A" -> "A renamed"`,
+		"  description:",
+		"      first",
+		"    - second",
+		"    + 2nd",
+		"  acceptanceCriteria:",
+		"    + tested",
+		`- B "B"`,
+		`+ C "C"`,
+	})
+
+	output, err = captureStdout(func() error { return runDiff([]string{oldPath, "--json"}) })
+	if err != nil {
+		t.Fatalf("runDiff --json: %v", err)
+	}
+	var doc diffJSON
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, output)
+	}
+	if doc.Against != oldPath || doc.Summary.Updated != 1 || len(doc.Items) != 3 {
+		t.Fatalf("doc = %+v", doc)
+	}
+	if fields := doc.Items[0].Fields; len(fields) != 3 || fields[1].Field != "description" || len(fields[1].Lines) != 3 {
+		t.Fatalf("A fields = %+v", fields)
+	}
+}
+
+func TestRunDiffAgainstGitRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	before, after := diffTestPlans(t, time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))
+	git("init", "-q")
+	if err := plan.SaveAtomic(plan.PlanPath(), before); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	git("add", ".")
+	git("commit", "-qm", "plan")
+	if err := plan.SaveAtomic(plan.PlanPath(), after); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runDiff(nil) })
+	if err != nil {
+		t.Fatalf("runDiff: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{`~ A "A renamed"`, `- B "B"`, `+ C "C"`})
+
+	if _, err := captureStdout(func() error { return runDiff([]string{"no-such-rev"}) }); err == nil {
+		t.Fatalf("expected error for unknown revision")
+	}
+
+	// A plan selected by path is read at the revision under its own name.
+	t.Setenv(plan.PlanEnvVar, filepath.Join("roadmap", "q3.json"))
+	if err := plan.SaveAtomic(plan.PlanPath(), before); err != nil {
+		t.Fatalf("save roadmap plan: %v", err)
+	}
+	git("add", ".")
+	git("commit", "-qm", "roadmap")
+	if err := plan.SaveAtomic(plan.PlanPath(), after); err != nil {
+		t.Fatalf("save roadmap plan: %v", err)
+	}
+	output, err = captureStdout(func() error { return runDiff(nil) })
+	if err != nil {
+		t.Fatalf("runDiff roadmap: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{`~ A "A renamed"`, `- B "B"`, `+ C "C"`})
+}
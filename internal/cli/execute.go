@@ -16,6 +16,7 @@ import (
 	"github.com/jbonatakis/blackbird/internal/config"
 	"github.com/jbonatakis/blackbird/internal/execution"
 	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
 )
 
 func runExecute(args []string) error {
@@ -39,19 +40,38 @@ func runExecute(args []string) error {
 		return err
 	}
 
-	cfg, err := config.LoadConfig(filepath.Dir(path))
+	cfg, err := config.LoadConfig(plan.ProjectRoot(path))
 	if err != nil {
 		cfg = config.DefaultResolvedConfig()
 	}
 
+	webhooks := webhook.New(cfg.Webhooks, filepath.Dir(path))
+	// Let queued notifications (and their retries) go out before exiting.
+	defer webhooks.Wait()
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	controller := execution.ExecutionController{
-		PlanPath:            path,
-		Runtime:             runtime,
-		StopAfterEachTask:   cfg.Execution.StopAfterEachTask,
-		ParentReviewEnabled: cfg.Execution.ParentReviewEnabled,
+	controller := newExecutionController(path, runtime, cfg, webhooks)
+	result, err := controller.Execute(ctx)
+	if err != nil {
+		return err
+	}
+
+	return handleExecuteResult(ctx, controller, result)
+}
+
+// newExecutionController builds the controller used by execute (and by resume
+// when execution.autoContinue is on), printing task progress to stdout.
+func newExecutionController(path string, runtime agent.Runtime, cfg config.ResolvedConfig, webhooks *webhook.Dispatcher) execution.ExecutionController {
+	return execution.ExecutionController{
+		PlanPath:              path,
+		Runtime:               runtime,
+		StopAfterEachTask:     cfg.Execution.StopAfterEachTask,
+		ParentReviewEnabled:   cfg.Execution.ParentReviewEnabled,
+		ContinueOnWaitingUser: cfg.Execution.AutoContinue,
+		Hooks:                 execution.HooksFromConfig(cfg.Hooks),
+		Webhooks:              webhooks,
 		OnTaskStart: func(taskID string) {
 			fmt.Fprintf(os.Stdout, "starting %s\n", taskID)
 		},
@@ -68,13 +88,6 @@ func runExecute(args []string) error {
 			}
 		},
 	}
-
-	result, err := controller.Execute(ctx)
-	if err != nil {
-		return err
-	}
-
-	return handleExecuteResult(ctx, controller, result)
 }
 
 func handleExecuteResult(ctx context.Context, controller execution.ExecutionController, result execution.ExecuteResult) error {
@@ -82,9 +95,19 @@ func handleExecuteResult(ctx context.Context, controller execution.ExecutionCont
 		switch result.Reason {
 		case execution.ExecuteReasonCompleted:
 			fmt.Fprintln(os.Stdout, "no ready tasks remaining")
+			for _, hook := range result.Hooks {
+				if hook.Failed() {
+					fmt.Fprintf(os.Stdout, "warning: %s\n", hook.Error)
+				}
+			}
 			return nil
 		case execution.ExecuteReasonWaitingUser:
-			if result.TaskID != "" {
+			if len(result.Waiting) > 0 {
+				for _, taskID := range result.Waiting {
+					fmt.Fprintf(os.Stdout, "%s is waiting for user input\n", taskID)
+				}
+				fmt.Fprintln(os.Stdout, "next step: blackbird questions answer")
+			} else if result.TaskID != "" {
 				fmt.Fprintf(os.Stdout, "%s is waiting for user input\n", result.TaskID)
 			} else {
 				fmt.Fprintln(os.Stdout, "waiting for user input")
@@ -67,9 +67,9 @@ func TestRunExecuteSingleTask(t *testing.T) {
 	}
 
 	runsDir := filepath.Join(tempDir, ".blackbird", "runs", "task")
-	entries, err := os.ReadDir(runsDir)
+	entries, err := filepath.Glob(filepath.Join(runsDir, "*.json"))
 	if err != nil {
-		t.Fatalf("read runs dir: %v", err)
+		t.Fatalf("list run records: %v", err)
 	}
 	if len(entries) != 1 {
 		t.Fatalf("expected 1 run record, got %d", len(entries))
@@ -537,9 +537,9 @@ func TestRunExecuteDecisionRequestChangesCancelReturnsToPrompt(t *testing.T) {
 	}
 
 	runsDir := filepath.Join(tempDir, ".blackbird", "runs", "a")
-	entries, err := os.ReadDir(runsDir)
+	entries, err := filepath.Glob(filepath.Join(runsDir, "*.json"))
 	if err != nil {
-		t.Fatalf("read runs dir: %v", err)
+		t.Fatalf("list run records: %v", err)
 	}
 	if len(entries) != 1 {
 		t.Fatalf("expected 1 run record after cancel, got %d", len(entries))
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func runUndo(args []string) error {
+	return runHistoryStep("undo", args, plan.Undo)
+}
+
+func runRedo(args []string) error {
+	return runHistoryStep("redo", args, plan.Redo)
+}
+
+func runHistoryStep(name string, args []string, step func(string, bool) (plan.HistoryEntry, error)) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	force := fs.Bool("force", false, "discard plan changes made outside the history")
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: fmt.Sprintf("%s takes no arguments (only --force)", name)}
+	}
+
+	path := plan.PlanPath()
+	entry, err := step(path, *force)
+	if err != nil {
+		var conflict plan.HistoryConflictError
+		if errors.As(err, &conflict) {
+			return fmt.Errorf("%w; rerun with --force to %s anyway (unrecorded changes will be lost)", err, name)
+		}
+		return err
+	}
+
+	// Undo moves the plan from entry.After back to entry.Before; redo the reverse.
+	from, to := &entry.After, entry.Before
+	verb := "undid"
+	if name == "redo" {
+		from, to = entry.Before, &entry.After
+		verb = "redid"
+	}
+	fmt.Fprintf(os.Stdout, "%s #%d: %s\n", verb, entry.ID, entry.Label)
+	if to == nil {
+		fmt.Fprintf(os.Stdout, "removed plan file: %s\n", path)
+		return nil
+	}
+	var prev plan.WorkGraph
+	if from != nil {
+		prev = *from
+	}
+	printDiffSummary(os.Stdout, plan.Diff(prev, *to))
+	return nil
+}
+
+func runHistory(args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "history takes only flags (no positional args)"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	path := plan.PlanPath()
+	entries, applied, err := plan.ListHistory(path)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		res := historyJSON{SchemaVersion: OutputSchemaVersion, Applied: applied, Entries: []historyEntryJSON{}}
+		for i := len(entries) - 1; i >= 0; i-- {
+			e := entries[i]
+			var before plan.WorkGraph
+			if e.Before != nil {
+				before = *e.Before
+			}
+			d := plan.Diff(before, e.After)
+			res.Entries = append(res.Entries, historyEntryJSON{
+				ID:        e.ID,
+				Label:     e.Label,
+				CreatedAt: e.CreatedAt,
+				Undone:    e.ID > applied,
+				Added:     nonNilIDs(d.Added),
+				Removed:   nonNilIDs(d.Removed),
+				Updated:   nonNilIDs(d.Updated),
+				Moved:     nonNilIDs(d.Moved),
+			})
+		}
+		return writeJSON(os.Stdout, res)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(os.Stdout, "no plan history")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tID\tWHEN\tCOMMAND\tCHANGES")
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		marker := ""
+		switch {
+		case e.ID == applied:
+			marker = "*"
+		case e.ID > applied:
+			marker = "undone"
+		}
+		var before plan.WorkGraph
+		if e.Before != nil {
+			before = *e.Before
+		}
+		d := plan.Diff(before, e.After)
+		changes := fmt.Sprintf("+%d -%d ~%d", len(d.Added), len(d.Removed), len(d.Updated)+len(d.Moved))
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", marker, e.ID, e.CreatedAt.Local().Format(time.DateTime), e.Label, changes)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestUndoRedoDelete(t *testing.T) {
+	chdirForTest(t, t.TempDir())
+
+	now := time.Now().UTC()
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items: map[string]plan.WorkItem{
+			"A": newWorkItem("A", now),
+			"B": newWorkItem("B", now),
+		},
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	if _, err := captureStdout(func() error { return runDelete("A", nil) }); err != nil {
+		t.Fatalf("runDelete: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runHistory(nil) })
+	if err != nil {
+		t.Fatalf("runHistory: %v", err)
+	}
+	if !strings.Contains(output, "delete A") || !strings.Contains(output, "+0 -1 ~0") {
+		t.Fatalf("history output missing entry: %q", output)
+	}
+
+	output, err = captureStdout(func() error { return runUndo(nil) })
+	if err != nil {
+		t.Fatalf("runUndo: %v", err)
+	}
+	if !strings.Contains(output, "undid #1: delete A") || !strings.Contains(output, "Added: A") {
+		t.Fatalf("unexpected undo output: %q", output)
+	}
+	restored, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, ok := restored.Items["A"]; !ok {
+		t.Fatalf("expected A to be restored")
+	}
+
+	if _, err := captureStdout(func() error { return runRedo(nil) }); err != nil {
+		t.Fatalf("runRedo: %v", err)
+	}
+	redone, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, ok := redone.Items["A"]; ok {
+		t.Fatalf("expected A to be deleted again")
+	}
+}
+
+func TestUndoRequiresForceAfterUnrecordedChange(t *testing.T) {
+	chdirForTest(t, t.TempDir())
+
+	now := time.Now().UTC()
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"A": newWorkItem("A", now)},
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	if _, err := captureStdout(func() error { return runEdit("A", []string{"--title", "Renamed"}) }); err != nil {
+		t.Fatalf("runEdit: %v", err)
+	}
+	changed, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if err := plan.SetStatus(&changed, "A", plan.StatusDone, now); err != nil {
+		t.Fatalf("set status: %v", err)
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), changed); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	if _, err := captureStdout(func() error { return runUndo(nil) }); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected --force hint, got %v", err)
+	}
+	if _, err := captureStdout(func() error { return runUndo([]string{"--force"}) }); err != nil {
+		t.Fatalf("runUndo --force: %v", err)
+	}
+	restored, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if restored.Items["A"].Title != "A" {
+		t.Fatalf("title = %q, want original", restored.Items["A"].Title)
+	}
+}
@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// changeImpact is the plan impact of a change plus what it does to the run
+// history under the plan's directory.
+type changeImpact struct {
+	plan.Impact
+	// ExternalDependents are the items of other plans depending on a changed
+	// item.
+	ExternalDependents []plan.ExternalDependent
+	// OrphanedRuns are removed items that still have run history.
+	OrphanedRuns []string
+	Reviews      []execution.InvalidatedParentReview
+}
+
+// reachesBeyondChange reports whether the change touches anything other than
+// the items it edits: dependents in this plan or others, run history or
+// recorded parent reviews.
+func (c changeImpact) reachesBeyondChange() bool {
+	return len(c.Dependents) != 0 || len(c.ExternalDependents) != 0 || len(c.OrphanedRuns) != 0 || len(c.Reviews) != 0
+}
+
+type impactJSON struct {
+	SchemaVersion      int                `json:"schemaVersion"`
+	ID                 string             `json:"id"`
+	Changed            []string           `json:"changed"`
+	Dependents         []itemRefJSON      `json:"dependents"`
+	DoneDependents     []string           `json:"doneDependents"`
+	ExternalDependents []itemRefJSON      `json:"externalDependents"`
+	OrphanedRuns       []string           `json:"orphanedRuns"`
+	ParentReviews      []impactReviewJSON `json:"parentReviews"`
+}
+
+type impactReviewJSON struct {
+	ParentID string `json:"parentId"`
+	RunID    string `json:"runId"`
+	Reason   string `json:"reason"`
+}
+
+func runImpact(args []string) error {
+	fs := flag.NewFlagSet("impact", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	id, err := parseWithPositional(fs, args)
+	if err != nil {
+		return err
+	}
+	if id == "" {
+		return UsageError{Message: "impact requires exactly 1 argument: <id>"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	path := plan.PlanPath()
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := g.Items[id]; !ok {
+		return fmt.Errorf("unknown id %q", id)
+	}
+
+	// Deleting the item and its subtree is the widest change to it; rescoping
+	// touches the same dependents and reviews but keeps the runs.
+	after := plan.Clone(g)
+	if _, err := plan.DeleteItem(&after, id, true, true, time.Now().UTC()); err != nil {
+		return err
+	}
+	impact, err := analyzeChangeImpact(path, g, after)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		return writeJSON(os.Stdout, newImpactJSON(id, g, impact))
+	}
+	if !impact.reachesBeyondChange() {
+		fmt.Fprintf(os.Stdout, "nothing outside %s depends on it\n", id)
+		return nil
+	}
+	printImpact(os.Stdout, g, impact)
+	return nil
+}
+
+func newImpactJSON(id string, g plan.WorkGraph, c changeImpact) impactJSON {
+	doc := impactJSON{
+		SchemaVersion:      OutputSchemaVersion,
+		ID:                 id,
+		Changed:            append([]string{}, c.Changed...),
+		Dependents:         newItemRefs(g, c.Dependents),
+		DoneDependents:     append([]string{}, c.DoneDependents...),
+		ExternalDependents: []itemRefJSON{},
+		OrphanedRuns:       append([]string{}, c.OrphanedRuns...),
+		ParentReviews:      []impactReviewJSON{},
+	}
+	for _, d := range c.ExternalDependents {
+		doc.ExternalDependents = append(doc.ExternalDependents, itemRefJSON{ID: d.Item.ID, Title: d.Item.Title, Status: d.Item.Status, Known: true, Plan: d.Plan})
+	}
+	for _, r := range c.Reviews {
+		doc.ParentReviews = append(doc.ParentReviews, impactReviewJSON{ParentID: r.ParentTaskID, RunID: r.RunID, Reason: r.Reason})
+	}
+	return doc
+}
+
+// analyzeChangeImpact reports the impact of changing before, the plan at path,
+// into after.
+func analyzeChangeImpact(path string, before, after plan.WorkGraph) (changeImpact, error) {
+	baseDir := filepath.Dir(path)
+	c := changeImpact{Impact: plan.AnalyzeImpact(before, after)}
+	external, err := plan.ExternalDependents(path, before, c.Changed)
+	if err != nil {
+		return changeImpact{}, err
+	}
+	c.ExternalDependents = external
+	if len(c.Removed) != 0 {
+		taskIDs, err := execution.RunDirTaskIDs(baseDir)
+		if err != nil {
+			return changeImpact{}, err
+		}
+		removed := map[string]bool{}
+		for _, id := range c.Removed {
+			removed[id] = true
+		}
+		for _, id := range taskIDs {
+			if removed[id] {
+				c.OrphanedRuns = append(c.OrphanedRuns, id)
+			}
+		}
+	}
+	reviews, err := execution.ParentReviewsInvalidatedBy(baseDir, before, after, c.Parents)
+	if err != nil {
+		return changeImpact{}, err
+	}
+	c.Reviews = reviews
+	return c, nil
+}
+
+// printImpact lists what the change reaches beyond the items it edits. g is
+// the graph before the change.
+func printImpact(w io.Writer, g plan.WorkGraph, c changeImpact) {
+	fmt.Fprintf(w, "Impact: %d dependent(s), %d done, %d item(s) with runs, %d parent review(s)\n",
+		len(c.Dependents), len(c.DoneDependents), len(c.OrphanedRuns), len(c.Reviews))
+	done := map[string]bool{}
+	for _, id := range c.DoneDependents {
+		done[id] = true
+	}
+	if len(c.Dependents) > 0 {
+		fmt.Fprintln(w, "Dependents:")
+		for _, id := range c.Dependents {
+			note := ""
+			if done[id] {
+				note = " (done: built on the old version)"
+			}
+			fmt.Fprintf(w, "- %s [%s] %s%s\n", id, g.Items[id].Status, g.Items[id].Title, note)
+		}
+	}
+	if len(c.ExternalDependents) > 0 {
+		fmt.Fprintln(w, "Dependents in other plans:")
+		for _, d := range c.ExternalDependents {
+			name := d.Plan
+			if name == "" {
+				name = "(default)"
+			}
+			fmt.Fprintf(w, "- %s: %s [%s] %s (via %s)\n", name, d.Item.ID, d.Item.Status, d.Item.Title, d.Dep)
+		}
+	}
+	if len(c.OrphanedRuns) > 0 {
+		fmt.Fprintf(w, "Run history orphaned: %s\n", strings.Join(c.OrphanedRuns, ", "))
+	}
+	if len(c.Reviews) > 0 {
+		fmt.Fprintln(w, "Parent reviews invalidated:")
+		for _, r := range c.Reviews {
+			fmt.Fprintf(w, "- %s (run %s): %s\n", r.ParentTaskID, r.RunID, r.Reason)
+		}
+	}
+}
+
+// previewImpact prints the impact of changing before into after. When the
+// change reaches beyond the items it edits it asks to continue, unless yes is
+// set or stdin is not a terminal.
+func previewImpact(path string, before, after plan.WorkGraph, yes bool) (bool, error) {
+	impact, err := analyzeChangeImpact(path, before, after)
+	if err != nil {
+		return false, err
+	}
+	if !impact.reachesBeyondChange() {
+		return true, nil
+	}
+	printImpact(os.Stdout, before, impact)
+	if yes || !isTerminal(os.Stdin.Fd()) {
+		return true, nil
+	}
+	return promptConfirm("Continue")
+}
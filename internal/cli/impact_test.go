@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func impactTestPlan(t *testing.T, tempDir string, now time.Time) {
+	t.Helper()
+	parent := newWorkItem("P", now)
+	parent.ChildIDs = []string{"A", "B"}
+	parent.Status = plan.StatusDone
+	a := newWorkItem("A", now)
+	a.ParentID = &parent.ID
+	a.Status = plan.StatusDone
+	b := newWorkItem("B", now)
+	b.ParentID = &parent.ID
+	b.Status = plan.StatusDone
+	c := newWorkItem("C", now)
+	c.Deps = []string{"A"}
+	c.Status = plan.StatusDone
+	d := newWorkItem("D", now)
+	d.Deps = []string{"C"}
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"P": parent, "A": a, "B": b, "C": c, "D": d}}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	signature, err := execution.ParentReviewCompletionSignatureFromMap("P", map[string]time.Time{"A": now, "B": now})
+	if err != nil {
+		t.Fatalf("signature: %v", err)
+	}
+	for _, record := range []execution.RunRecord{
+		{ID: "run-a", TaskID: "A", Status: execution.RunStatusSuccess},
+		{ID: "review-p", TaskID: "P", Type: execution.RunTypeReview, Status: execution.RunStatusSuccess, ParentReviewCompletionSignature: signature},
+	} {
+		record.StartedAt = now
+		record.Context = execution.ContextPack{SchemaVersion: execution.ContextPackSchemaVersion, Task: execution.TaskContext{ID: record.TaskID}}
+		if err := execution.SaveRun(tempDir, record); err != nil {
+			t.Fatalf("save run: %v", err)
+		}
+	}
+}
+
+func TestRunImpactReportsDependentsRunsAndReviews(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	impactTestPlan(t, tempDir, time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))
+
+	output, err := captureStdout(func() error { return runImpact([]string{"A"}) })
+	if err != nil {
+		t.Fatalf("runImpact: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"Impact: 2 dependent(s), 1 done, 1 item(s) with runs, 1 parent review(s)",
+		"- C [done] C (done: built on the old version)",
+		"- D [todo] D",
+		"Run history orphaned: A",
+		"- P (run review-p): child completions changed",
+	})
+
+	output, err = captureStdout(func() error { return runImpact([]string{"A", "--json"}) })
+	if err != nil {
+		t.Fatalf("runImpact --json: %v", err)
+	}
+	var doc impactJSON
+	if err := json.Unmarshal([]byte(output), &doc); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, output)
+	}
+	if !reflect.DeepEqual(doc.DoneDependents, []string{"C"}) || len(doc.Dependents) != 2 || len(doc.ParentReviews) != 1 {
+		t.Fatalf("json = %+v", doc)
+	}
+
+	output, err = captureStdout(func() error { return runImpact([]string{"D"}) })
+	if err != nil {
+		t.Fatalf("runImpact D: %v", err)
+	}
+	if output != "nothing outside D depends on it\n" {
+		t.Fatalf("output = %q", output)
+	}
+}
+
+func TestRunDeletePrintsImpactPreview(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	impactTestPlan(t, tempDir, time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC))
+
+	output, err := captureStdout(func() error { return runDelete("A", []string{"--force"}) })
+	if err != nil {
+		t.Fatalf("runDelete: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"Impact: 2 dependent(s), 1 done, 1 item(s) with runs, 1 parent review(s)",
+		"deleted 1 item(s)",
+		"detached deps from: C",
+	})
+}
+
+func TestImpactListsDependentsInOtherPlans(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	t.Setenv(plan.PlanEnvVar, "")
+
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	ui := newWorkItem("ui", now)
+	ui.Title = "Build UI"
+	ui.Deps = []string{"plan:backend#api"}
+	other := newWorkItem("other", now)
+	other.Deps = []string{"plan:backend#auth"}
+	for name, g := range map[string]plan.WorkGraph{
+		"backend":  {SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": newWorkItem("api", now), "auth": newWorkItem("auth", now)}},
+		"frontend": {SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"ui": ui, "other": other}},
+	} {
+		if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(tempDir, name), plan.DefaultPlanFilename), g); err != nil {
+			t.Fatalf("save %s plan: %v", name, err)
+		}
+	}
+	run := func(args ...string) string {
+		t.Helper()
+		output, err := captureStdout(func() error { return Run(args) })
+		if err != nil {
+			t.Fatalf("%v: %v\n%s", args, err, output)
+		}
+		return output
+	}
+
+	assertOutputContainsInOrder(t, run("--plan", "backend", "impact", "api"), []string{
+		"Impact: 0 dependent(s)",
+		"Dependents in other plans:",
+		"- frontend: ui [todo] Build UI (via plan:backend#api)",
+	})
+	var doc impactJSON
+	if err := json.Unmarshal([]byte(run("--plan", "backend", "impact", "api", "--json")), &doc); err != nil {
+		t.Fatalf("decode json: %v", err)
+	}
+	want := []itemRefJSON{{ID: "ui", Title: "Build UI", Status: plan.StatusTodo, Known: true, Plan: "frontend"}}
+	if !reflect.DeepEqual(doc.ExternalDependents, want) {
+		t.Fatalf("externalDependents = %+v", doc.ExternalDependents)
+	}
+
+	assertOutputContainsInOrder(t, run("--plan", "backend", "delete", "api", "--yes"), []string{
+		"- frontend: ui [todo] Build UI (via plan:backend#api)",
+		"deleted 1 item(s)",
+	})
+	if output := run("--plan", "frontend", "impact", "ui"); output != "nothing outside ui depends on it\n" {
+		t.Fatalf("impact in the dependent plan = %q", output)
+	}
+}
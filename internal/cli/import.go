@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func runImport(file string, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	yes := fs.Bool("yes", false, "save without confirmation")
+
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "import takes exactly 1 positional argument: <file.md>"}
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return fmt.Errorf("open markdown file: %w", err)
+	}
+	defer f.Close()
+
+	now := time.Now().UTC()
+	imported, err := plan.ParseMarkdown(f, now)
+	if err != nil {
+		return fmt.Errorf("import %s: %w", file, err)
+	}
+	if len(imported.Items) == 0 {
+		return fmt.Errorf("import %s: no headings or list items found", file)
+	}
+
+	path := plan.PlanPath()
+	if errs := plan.Validate(imported); len(errs) != 0 {
+		printValidationErrors(os.Stdout, file, errs)
+		return errors.New("validation failed")
+	}
+
+	exists, current, err := loadPlanIfExists(path)
+	if err != nil {
+		return err
+	}
+	if exists {
+		preserveTimestamps(current, &imported)
+	}
+
+	printPlanSummary(os.Stdout, imported)
+	fmt.Fprintln(os.Stdout, "Plan tree:")
+	printTree(os.Stdout, imported)
+	if exists {
+		printDiffSummary(os.Stdout, plan.Diff(current, imported))
+	}
+
+	if !*yes {
+		label := "Save imported plan"
+		if exists && len(current.Items) > 0 {
+			label = "Replace existing plan"
+		}
+		ok, err := promptConfirm(label)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(os.Stdout, "aborted; plan unchanged")
+			return nil
+		}
+	}
+
+	if err := plan.SaveWithHistory(path, imported, "import "+file); err != nil {
+		return fmt.Errorf("write plan file: %w", err)
+	}
+	fmt.Fprintf(os.Stdout, "imported %d item(s) into %s\n", len(imported.Items), path)
+	return nil
+}
+
+// preserveTimestamps keeps createdAt for items that already exist, and updatedAt
+// for items whose content is unchanged, so re-importing the same file is a no-op.
+func preserveTimestamps(before plan.WorkGraph, after *plan.WorkGraph) {
+	diff := plan.Diff(before, *after)
+	updated := map[string]bool{}
+	for _, id := range diff.Updated {
+		updated[id] = true
+	}
+	for _, id := range diff.Moved {
+		updated[id] = true
+	}
+	for id, it := range after.Items {
+		prev, ok := before.Items[id]
+		if !ok {
+			continue
+		}
+		it.CreatedAt = prev.CreatedAt
+		if !updated[id] {
+			it.UpdatedAt = prev.UpdatedAt
+		} else if it.UpdatedAt.Before(it.CreatedAt) {
+			it.UpdatedAt = it.CreatedAt
+		}
+		after.Items[id] = it
+	}
+}
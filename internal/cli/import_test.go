@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestRunImportShowsDiffAndPreservesTimestamps(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+
+	created := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	existing := newWorkItem("setup", created)
+	existing.Title = "Setup"
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"setup": existing},
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	mdPath := filepath.Join(tempDir, "spec.md")
+	md := "- Setup\n- [x] Build (after: setup)\n  Compile the binary.\n"
+	if err := os.WriteFile(mdPath, []byte(md), 0o644); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	output, err := captureStdout(func() error {
+		return withPromptInput("y\n", func() error {
+			return Run([]string{"import", mdPath})
+		})
+	})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !strings.Contains(output, "Diff summary: added 1, removed 0, updated 0") {
+		t.Fatalf("output missing diff summary: %q", output)
+	}
+	if !strings.Contains(output, "Replace existing plan") {
+		t.Fatalf("output missing confirmation prompt: %q", output)
+	}
+
+	g, err = plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if !g.Items["setup"].CreatedAt.Equal(created) || !g.Items["setup"].UpdatedAt.Equal(created) {
+		t.Fatalf("expected unchanged item timestamps to be preserved, got %+v", g.Items["setup"])
+	}
+	build := g.Items["build"]
+	if build.Status != plan.StatusDone || build.Description != "Compile the binary." {
+		t.Fatalf("unexpected build item: %+v", build)
+	}
+	if strings.Join(build.Deps, ",") != "setup" {
+		t.Fatalf("build deps = %v, want [setup]", build.Deps)
+	}
+}
+
+func TestRunImportDeclineLeavesPlanUnchanged(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+
+	mdPath := filepath.Join(tempDir, "spec.md")
+	if err := os.WriteFile(mdPath, []byte("# Feature\n- Task\n"), 0o644); err != nil {
+		t.Fatalf("write markdown: %v", err)
+	}
+
+	output, err := captureStdout(func() error {
+		return withPromptInput("n\n", func() error {
+			return Run([]string{"import", mdPath})
+		})
+	})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if !strings.Contains(output, "aborted; plan unchanged") {
+		t.Fatalf("output missing abort message: %q", output)
+	}
+	if _, err := os.Stat(plan.PlanPath()); !os.IsNotExist(err) {
+		t.Fatalf("expected no plan file, stat err = %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// OutputSchemaVersion versions the --json output of read commands. Fields may
+// be added within a version; renames and removals bump it.
+const OutputSchemaVersion = 1
+
+// outputFlags registers --json and --output on a read command's flag set.
+type outputFlags struct {
+	json   *bool
+	output *string
+}
+
+func addOutputFlags(fs *flag.FlagSet) outputFlags {
+	return outputFlags{
+		json:   fs.Bool("json", false, "emit JSON"),
+		output: fs.String("output", "text", "output format: text|json"),
+	}
+}
+
+// JSON reports whether JSON output was requested.
+func (o outputFlags) JSON() (bool, error) {
+	switch *o.output {
+	case "text", "":
+		return *o.json, nil
+	case "json":
+		return true, nil
+	default:
+		return false, UsageError{Message: fmt.Sprintf("invalid --output %q (expected text or json)", *o.output)}
+	}
+}
+
+// parseWithPositional parses flags that may appear before or after a single
+// positional argument, e.g. `runs <taskID> --json`.
+func parseWithPositional(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", UsageError{Message: err.Error()}
+	}
+	rest := fs.Args()
+	if len(rest) == 0 {
+		return "", nil
+	}
+	if err := fs.Parse(rest[1:]); err != nil {
+		return "", UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return "", UsageError{Message: fmt.Sprintf("unexpected arguments: %v", fs.Args())}
+	}
+	return rest[0], nil
+}
+
+// nonNilIDs keeps empty id lists as [] rather than null in JSON output.
+func nonNilIDs(ids []string) []string {
+	if ids == nil {
+		return []string{}
+	}
+	return ids
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+type itemJSON struct {
+	plan.WorkItem
+	Readiness plan.Readiness `json:"readiness"`
+}
+
+func newItemJSON(g plan.WorkGraph, it plan.WorkItem) itemJSON {
+	return itemJSON{WorkItem: it, Readiness: plan.ItemReadiness(g, it)}
+}
+
+type itemRefJSON struct {
+	ID     string      `json:"id"`
+	Title  string      `json:"title,omitempty"`
+	Status plan.Status `json:"status,omitempty"`
+	Known  bool        `json:"known"`
+	// Plan names the referenced plan of an external ("plan:<name>#<id>") dep.
+	Plan string `json:"plan,omitempty"`
+}
+
+func newItemRefs(g plan.WorkGraph, ids []string) []itemRefJSON {
+	out := make([]itemRefJSON, 0, len(ids))
+	for _, id := range ids {
+		if plan.IsExternalDep(id) {
+			d, it, err := plan.ResolveExternalDep(g, id)
+			if err != nil {
+				out = append(out, itemRefJSON{ID: id, Plan: d.Plan})
+				continue
+			}
+			out = append(out, itemRefJSON{ID: id, Title: it.Title, Status: it.Status, Known: true, Plan: d.Plan})
+			continue
+		}
+		it, ok := g.Items[id]
+		if !ok {
+			out = append(out, itemRefJSON{ID: id})
+			continue
+		}
+		out = append(out, itemRefJSON{ID: id, Title: it.Title, Status: it.Status, Known: true})
+	}
+	return out
+}
+
+type listJSON struct {
+	SchemaVersion int        `json:"schemaVersion"`
+	Items         []itemJSON `json:"items"`
+}
+
+type showJSON struct {
+	SchemaVersion int           `json:"schemaVersion"`
+	Item          itemJSON      `json:"item"`
+	Deps          []itemRefJSON `json:"deps"`
+	Dependents    []itemRefJSON `json:"dependents"`
+}
+
+type validateJSON struct {
+	SchemaVersion int                   `json:"schemaVersion"`
+	Path          string                `json:"path"`
+	Valid         bool                  `json:"valid"`
+	Errors        []validationErrorJSON `json:"errors"`
+}
+
+type validationErrorJSON struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func newValidationErrorsJSON(errs []plan.ValidationError) []validationErrorJSON {
+	out := make([]validationErrorJSON, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, validationErrorJSON{Path: e.Path, Message: e.Message})
+	}
+	return out
+}
+
+type pickJSON struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	Items         []itemJSON   `json:"items"`
+	Summary       pickStatJSON `json:"summary"`
+}
+
+type pickStatJSON struct {
+	Total           int `json:"total"`
+	Ready           int `json:"ready"`
+	BlockedOnDeps   int `json:"blockedOnDeps"`
+	ManuallyBlocked int `json:"manuallyBlocked"`
+}
+
+type runsJSON struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	TaskID        string           `json:"taskId"`
+	Runs          []runSummaryJSON `json:"runs"`
+}
+
+// runSummaryJSON is a stable subset of execution.RunRecord; the full record
+// (context pack, review state) stays internal to the run store.
+type runSummaryJSON struct {
+	ID          string              `json:"id"`
+	TaskID      string              `json:"taskId"`
+	Type        execution.RunType   `json:"type"`
+	Provider    string              `json:"provider,omitempty"`
+	Status      execution.RunStatus `json:"status"`
+	StartedAt   time.Time           `json:"startedAt"`
+	CompletedAt *time.Time          `json:"completedAt,omitempty"`
+	DurationMs  *int64              `json:"durationMs,omitempty"`
+	ExitCode    *int                `json:"exitCode,omitempty"`
+	Error       string              `json:"error,omitempty"`
+	Stdout      *string             `json:"stdout,omitempty"`
+	Stderr      *string             `json:"stderr,omitempty"`
+}
+
+func newRunSummaryJSON(r execution.RunRecord, verbose bool) runSummaryJSON {
+	out := runSummaryJSON{
+		ID:          r.ID,
+		TaskID:      r.TaskID,
+		Type:        r.Type,
+		Provider:    r.Provider,
+		Status:      r.Status,
+		StartedAt:   r.StartedAt,
+		CompletedAt: r.CompletedAt,
+		ExitCode:    r.ExitCode,
+		Error:       r.Error,
+	}
+	if out.Type == "" {
+		out.Type = execution.RunTypeExecute
+	}
+	if r.CompletedAt != nil {
+		ms := r.CompletedAt.Sub(r.StartedAt).Milliseconds()
+		if ms < 0 {
+			ms = 0
+		}
+		out.DurationMs = &ms
+	}
+	if verbose {
+		stdout, stderr := r.Stdout, r.Stderr
+		out.Stdout, out.Stderr = &stdout, &stderr
+	}
+	return out
+}
+
+type historyJSON struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	Applied       int                `json:"applied"`
+	Entries       []historyEntryJSON `json:"entries"`
+}
+
+type historyEntryJSON struct {
+	ID        int       `json:"id"`
+	Label     string    `json:"label"`
+	CreatedAt time.Time `json:"createdAt"`
+	Undone    bool      `json:"undone"`
+	Added     []string  `json:"added"`
+	Removed   []string  `json:"removed"`
+	Updated   []string  `json:"updated"`
+	Moved     []string  `json:"moved"`
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func saveJSONTestPlan(t *testing.T, now time.Time) {
+	t.Helper()
+	b := newWorkItem("B", now)
+	b.Deps = []string{"A"}
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items: map[string]plan.WorkItem{
+			"A": newWorkItem("A", now),
+			"B": b,
+		},
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+}
+
+func TestListJSON(t *testing.T) {
+	chdirForTest(t, t.TempDir())
+	saveJSONTestPlan(t, time.Now().UTC())
+
+	output, err := captureStdout(func() error { return runList([]string{"--all", "--json"}) })
+	if err != nil {
+		t.Fatalf("runList: %v", err)
+	}
+	var got listJSON
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, output)
+	}
+	if got.SchemaVersion != OutputSchemaVersion || len(got.Items) != 2 {
+		t.Fatalf("unexpected output: %+v", got)
+	}
+	b := got.Items[1]
+	if b.ID != "B" || b.Readiness.Label != "BLOCKED" || b.Readiness.Actionable || len(b.Readiness.UnmetDeps) != 1 || b.Readiness.UnmetDeps[0] != "A" {
+		t.Fatalf("unexpected item B: %+v", b)
+	}
+	if !got.Items[0].Readiness.Actionable {
+		t.Fatalf("expected A to be actionable: %+v", got.Items[0])
+	}
+}
+
+func TestShowJSONAcceptsFlagAfterID(t *testing.T) {
+	chdirForTest(t, t.TempDir())
+	saveJSONTestPlan(t, time.Now().UTC())
+
+	output, err := captureStdout(func() error { return runShowCommand([]string{"A", "--output", "json"}) })
+	if err != nil {
+		t.Fatalf("runShowCommand: %v", err)
+	}
+	var got showJSON
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, output)
+	}
+	if got.Item.ID != "A" || len(got.Dependents) != 1 || got.Dependents[0].ID != "B" || !got.Dependents[0].Known {
+		t.Fatalf("unexpected output: %+v", got)
+	}
+
+	if err := runShowCommand([]string{"A", "--output", "xml"}); err == nil {
+		t.Fatalf("expected usage error for invalid --output")
+	}
+}
+
+func TestValidateJSONReportsErrors(t *testing.T) {
+	chdirForTest(t, t.TempDir())
+	now := time.Now().UTC()
+	a := newWorkItem("A", now)
+	a.Deps = []string{"missing"}
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"A": a}}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runValidate([]string{"--json"}) })
+	if err == nil || err.Error() != "validation failed" {
+		t.Fatalf("expected validation failed error, got %v", err)
+	}
+	var got validateJSON
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, output)
+	}
+	if got.Valid || len(got.Errors) == 0 || !strings.Contains(got.Errors[0].Path, "deps") {
+		t.Fatalf("unexpected output: %+v", got)
+	}
+}
+
+func TestRunsJSON(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	now := time.Date(2026, 1, 28, 15, 0, 0, 0, time.UTC)
+	saveJSONTestPlan(t, now)
+
+	completed := now.Add(90 * time.Second)
+	exitCode := 0
+	record := execution.RunRecord{
+		ID:          "run-1",
+		TaskID:      "A",
+		StartedAt:   now,
+		CompletedAt: &completed,
+		Status:      execution.RunStatusSuccess,
+		ExitCode:    &exitCode,
+		Stdout:      "hello",
+		Context: execution.ContextPack{
+			SchemaVersion: execution.ContextPackSchemaVersion,
+			Task:          execution.TaskContext{ID: "A", Title: "Task"},
+		},
+	}
+	if err := execution.SaveRun(tempDir, record); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runRuns([]string{"A", "--json"}) })
+	if err != nil {
+		t.Fatalf("runRuns: %v", err)
+	}
+	var got runsJSON
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, output)
+	}
+	if len(got.Runs) != 1 {
+		t.Fatalf("unexpected output: %+v", got)
+	}
+	run := got.Runs[0]
+	if run.Type != execution.RunTypeExecute || run.DurationMs == nil || *run.DurationMs != 90000 || run.Stdout != nil {
+		t.Fatalf("unexpected run summary: %+v", run)
+	}
+	if strings.Contains(output, "context") {
+		t.Fatalf("expected context pack to be omitted: %s", output)
+	}
+}
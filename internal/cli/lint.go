@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/plangen"
+	"github.com/jbonatakis/blackbird/internal/planquality"
+)
+
+type lintJSON struct {
+	SchemaVersion int                              `json:"schemaVersion"`
+	Path          string                           `json:"path"`
+	Summary       planquality.FindingsSummary      `json:"summary"`
+	Findings      []planquality.PlanQualityFinding `json:"findings"`
+}
+
+func runLint(args []string) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	severity := fs.String("severity", string(planquality.SeverityWarning), "lowest severity to report: blocking|warning")
+	taskID := fs.String("task", "", "only report findings for this item and its descendants")
+	fix := fs.Bool("fix", false, "run quality auto-refine passes against the plan and save the result")
+	meta := addAgentMetaFlags(fs)
+	out := addOutputFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "lint takes only flags (no positional args)"}
+	}
+	minSeverity := planquality.Severity(strings.TrimSpace(*severity))
+	if minSeverity != planquality.SeverityBlocking && minSeverity != planquality.SeverityWarning {
+		return UsageError{Message: fmt.Sprintf("invalid --severity %q (use blocking or warning)", *severity)}
+	}
+	if *fix && *taskID != "" {
+		return UsageError{Message: "--fix refines the whole plan and cannot be combined with --task"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+	if *fix && asJSON {
+		return UsageError{Message: "--fix cannot be combined with --json"}
+	}
+
+	path := plan.PlanPath()
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+	rules, err := plangen.ResolveQualityRulesFromPlanPath(path)
+	if err != nil {
+		return err
+	}
+	if *taskID != "" {
+		if _, ok := g.Items[*taskID]; !ok {
+			return fmt.Errorf("unknown id %q", *taskID)
+		}
+	}
+
+	if *fix {
+		if g, err = lintFix(path, g, rules, meta); err != nil {
+			return err
+		}
+	}
+
+	findings := filterLintFindings(g, planquality.LintWithRules(g, rules), minSeverity, *taskID)
+	summary := planquality.Summarize(findings)
+	if asJSON {
+		if err := writeJSON(os.Stdout, lintJSON{
+			SchemaVersion: OutputSchemaVersion,
+			Path:          path,
+			Summary:       summary,
+			Findings:      findings,
+		}); err != nil {
+			return err
+		}
+	} else {
+		printQualitySummary(os.Stdout, "lint", findings)
+		printQualityFindings(os.Stdout, "Blocking findings:", findings, planquality.SeverityBlocking)
+		printQualityFindings(os.Stdout, "Warning findings (non-blocking):", findings, planquality.SeverityWarning)
+	}
+	if summary.Blocking > 0 {
+		return fmt.Errorf("lint found %d blocking finding(s)", summary.Blocking)
+	}
+	return nil
+}
+
+// lintFix runs the plan-generate quality gate against the current plan and
+// saves the refined plan when any pass ran.
+func lintFix(path string, g plan.WorkGraph, rules planquality.Rules, meta *agentMetaFlags) (plan.WorkGraph, error) {
+	if !planquality.HasBlocking(planquality.LintWithRules(g, rules)) {
+		fmt.Fprintln(os.Stdout, "no blocking findings; nothing to fix")
+		return g, nil
+	}
+
+	runtime, err := agent.NewRuntimeFromEnv()
+	if err != nil {
+		return g, err
+	}
+	requestMeta := buildAgentMetadata(meta)
+	requestMeta.JSONSchema = agent.DefaultPlanJSONSchema()
+	requestMeta = agent.ApplyRuntimeProvider(requestMeta, runtime)
+	runRequest := func(ctx context.Context, req agent.Request) (agent.Response, agent.Diagnostics, error) {
+		return runAgentWithQuestions(ctx, runtime, req, agent.MaxPlanQuestionRounds)
+	}
+
+	// --fix asks for refinement explicitly, so it runs at least one pass even
+	// when planning.maxPlanAutoRefinePasses is 0.
+	passes := max(plangen.ResolveMaxAutoRefinePassesFromPlanPath(path), 1)
+	pass := 0
+	result, err := plangen.RunQualityGateWithRules(context.Background(), g, passes, rules, func(ctx context.Context, changeRequest string, currentPlan plan.WorkGraph) (plan.WorkGraph, error) {
+		pass++
+		fmt.Fprintf(os.Stdout, "quality auto-refine pass %d/%d\n", pass, passes)
+		refineResult, err := plangen.Refine(ctx, runRequest, plangen.RefineInput{
+			ChangeRequest: strings.TrimSpace(changeRequest),
+			CurrentPlan:   currentPlan,
+			Metadata:      requestMeta,
+		})
+		if err != nil {
+			return plan.WorkGraph{}, formatAgentRunError(err, refineResult.Diagnostics)
+		}
+		if len(refineResult.Questions) > 0 {
+			return plan.WorkGraph{}, errors.New("unexpected clarification request during quality auto-refine")
+		}
+		if refineResult.Plan == nil {
+			return plan.WorkGraph{}, errors.New("plan refine returned no plan")
+		}
+		return plan.Clone(*refineResult.Plan), nil
+	})
+	if err != nil {
+		return g, err
+	}
+
+	printDiffSummary(os.Stdout, plan.Diff(g, result.FinalPlan))
+	if err := plan.SaveWithHistory(path, result.FinalPlan, "lint --fix"); err != nil {
+		return g, fmt.Errorf("write plan file: %w", err)
+	}
+	fmt.Fprintf(os.Stdout, "updated plan: %s\n", path)
+	return result.FinalPlan, nil
+}
+
+// filterLintFindings keeps findings at or above minSeverity and, when taskID
+// is set, those for taskID and its descendants.
+func filterLintFindings(g plan.WorkGraph, findings []planquality.PlanQualityFinding, minSeverity planquality.Severity, taskID string) []planquality.PlanQualityFinding {
+	var scope map[string]bool
+	if taskID != "" {
+		scope = map[string]bool{}
+		var walk func(id string)
+		walk = func(id string) {
+			if scope[id] {
+				return
+			}
+			scope[id] = true
+			for _, child := range g.Items[id].ChildIDs {
+				walk(child)
+			}
+		}
+		walk(taskID)
+	}
+
+	out := make([]planquality.PlanQualityFinding, 0, len(findings))
+	for _, finding := range findings {
+		if minSeverity == planquality.SeverityBlocking && finding.Severity != planquality.SeverityBlocking {
+			continue
+		}
+		if scope != nil && !scope[finding.TaskID] {
+			continue
+		}
+		out = append(out, finding)
+	}
+	return out
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestRunLintReportsFindingsAndFailsOnBlocking(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	if err := plan.SaveAtomic(plan.PlanPath(), blockingPlan("task-1")); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runLint(nil) })
+	if err == nil || !strings.Contains(err.Error(), "3 blocking finding(s)") {
+		t.Fatalf("expected blocking error, got %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"Quality summary (lint): blocking=3, warning=0, total=3",
+		"Blocking findings:",
+		"- task-1.",
+	})
+
+	output, err = captureStdout(func() error { return runLint([]string{"--json"}) })
+	if err == nil {
+		t.Fatalf("expected blocking error with --json")
+	}
+	var doc lintJSON
+	if jerr := json.Unmarshal([]byte(output), &doc); jerr != nil {
+		t.Fatalf("decode json: %v\n%s", jerr, output)
+	}
+	if doc.SchemaVersion != OutputSchemaVersion || doc.Summary.Blocking != 3 || len(doc.Findings) != 3 {
+		t.Fatalf("json = %+v", doc)
+	}
+}
+
+func TestRunLintSeverityAndTaskFilters(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	if err := plan.SaveAtomic(plan.PlanPath(), validPlanWithOneWarning("task-1")); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runLint(nil) })
+	if err != nil || !strings.Contains(output, "blocking=0, warning=1, total=1") {
+		t.Fatalf("runLint = %v\n%s", err, output)
+	}
+	output, err = captureStdout(func() error { return runLint([]string{"--severity", "blocking"}) })
+	if err != nil || !strings.Contains(output, "blocking=0, warning=0, total=0") {
+		t.Fatalf("runLint --severity blocking = %v\n%s", err, output)
+	}
+	if err := runLint([]string{"--task", "missing"}); err == nil {
+		t.Fatalf("expected unknown id error")
+	}
+	if err := runLint([]string{"--severity", "info"}); err == nil {
+		t.Fatalf("expected usage error for invalid severity")
+	}
+}
+
+func TestRunLintFixRefinesAndSavesPlan(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	writePlanningConfig(t, tempDir, 0)
+	if err := plan.SaveAtomic(plan.PlanPath(), blockingPlan("task-1")); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	scriptPath := writeMockAgentScript(t, tempDir)
+	responsePath := writeAgentResponseFile(t, filepath.Join(tempDir, "response-refine.json"), agent.Response{
+		SchemaVersion: agent.SchemaVersion,
+		Type:          agent.RequestPlanRefine,
+		Plan:          ptrPlanGraph(validPlanWithOneWarning("task-1")),
+	})
+	t.Setenv("BLACKBIRD_AGENT_PROVIDER", "")
+	t.Setenv("BLACKBIRD_AGENT_CMD", scriptPath)
+	t.Setenv("BLACKBIRD_AGENT_STREAM", "")
+	t.Setenv("BLACKBIRD_AGENT_DEBUG", "")
+	t.Setenv("MOCK_AGENT_RESPONSE_PLAN_REFINE", responsePath)
+
+	output, err := captureStdout(func() error { return runLint([]string{"--fix"}) })
+	if err != nil {
+		t.Fatalf("runLint --fix: %v\n%s", err, output)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"quality auto-refine pass 1/1",
+		"updated plan: " + plan.PlanPath(),
+		"Quality summary (lint): blocking=0, warning=1, total=1",
+	})
+	saved, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if saved.Items["task-1"].Description != validPlanWithOneWarning("task-1").Items["task-1"].Description {
+		t.Fatalf("plan not refined: %+v", saved.Items["task-1"])
+	}
+}
+
+func TestRunLintUsesPlanningQualityConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	if err := plan.SaveAtomic(plan.PlanPath(), blockingPlan("task-1")); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	configDir := filepath.Join(tempDir, ".blackbird")
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("mkdir config dir: %v", err)
+	}
+	writeConfig := func(cfg string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(configDir, "config.json"), []byte(cfg), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+
+	writeConfig(`{"schemaVersion":1,"planning":{"quality":{"rules":{
+		"leaf_description_missing_or_placeholder":"warning",
+		"leaf_acceptance_criteria_missing":"off",
+		"leaf_prompt_missing":"off"
+	}}}}`)
+	output, err := captureStdout(func() error { return runLint(nil) })
+	if err != nil || !strings.Contains(output, "blocking=0, warning=1, total=1") {
+		t.Fatalf("runLint = %v\n%s", err, output)
+	}
+
+	writeConfig(`{"schemaVersion":1,"planning":{"quality":{"custom":[{"code":"bad","field":"prompt","match":"(","message":"m"}]}}}`)
+	if err := runLint(nil); err == nil || !strings.Contains(err.Error(), "planning.quality") {
+		t.Fatalf("expected planning.quality error, got %v", err)
+	}
+}
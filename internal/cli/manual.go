@@ -2,16 +2,16 @@ package cli
 
 import (
 	"bufio"
-	"crypto/rand"
-	"encoding/hex"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/jbonatakis/blackbird/internal/execution"
 	"github.com/jbonatakis/blackbird/internal/plan"
 )
 
@@ -42,6 +42,9 @@ func runAdd(args []string) error {
 	indexStr := fs.String("index", "", "insert index within parent's childIds (optional)")
 	var ac multiStringFlag
 	fs.Var(&ac, "ac", "acceptance criteria (repeatable)")
+	template := fs.String("template", "", "instantiate a template instead of adding one item")
+	var params multiStringFlag
+	fs.Var(&params, "param", "template parameter name=value (repeatable)")
 
 	if err := fs.Parse(args); err != nil {
 		return UsageError{Message: err.Error()}
@@ -49,6 +52,9 @@ func runAdd(args []string) error {
 	if fs.NArg() != 0 {
 		return UsageError{Message: "add takes only flags (no positional args)"}
 	}
+	if *template == "" && len(params) != 0 {
+		return UsageError{Message: "--param requires --template"}
+	}
 
 	path := plan.PlanPath()
 	g, err := loadValidatedPlan(path)
@@ -56,6 +62,35 @@ func runAdd(args []string) error {
 		return err
 	}
 
+	var parentID *string
+	if *parent != "" && *parent != "root" {
+		p := *parent
+		parentID = &p
+	}
+
+	var idx *int
+	if strings.TrimSpace(*indexStr) != "" {
+		n, err := parseInt(*indexStr)
+		if err != nil {
+			return UsageError{Message: err.Error()}
+		}
+		idx = &n
+	}
+
+	if *template != "" {
+		var itemFlags []string
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "id", "title", "description", "prompt", "notes", "ac":
+				itemFlags = append(itemFlags, "--"+f.Name)
+			}
+		})
+		if len(itemFlags) != 0 {
+			return UsageError{Message: fmt.Sprintf("%s cannot be combined with --template", strings.Join(itemFlags, ", "))}
+		}
+		return addFromTemplate(path, g, *template, params, parentID, idx)
+	}
+
 	if strings.TrimSpace(*title) == "" {
 		v, err := promptLine("Title")
 		if err != nil {
@@ -69,7 +104,7 @@ func runAdd(args []string) error {
 
 	newID := strings.TrimSpace(*id)
 	if newID == "" {
-		newID = generateID(g)
+		newID = plan.NewItemID(g)
 	}
 	if _, ok := g.Items[newID]; ok {
 		return UsageError{Message: fmt.Sprintf("id already exists: %q", newID)}
@@ -94,21 +129,6 @@ func runAdd(args []string) error {
 		it.Notes = &n
 	}
 
-	var parentID *string
-	if *parent != "" && *parent != "root" {
-		p := *parent
-		parentID = &p
-	}
-
-	var idx *int
-	if strings.TrimSpace(*indexStr) != "" {
-		n, err := parseInt(*indexStr)
-		if err != nil {
-			return UsageError{Message: err.Error()}
-		}
-		idx = &n
-	}
-
 	if err := plan.AddItem(&g, it, parentID, idx, now); err != nil {
 		return err
 	}
@@ -116,7 +136,7 @@ func runAdd(args []string) error {
 		printValidationErrors(os.Stdout, path, errs)
 		return errors.New("validation failed")
 	}
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "add "+newID); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 
@@ -135,6 +155,8 @@ func runEdit(id string, args []string) error {
 	clearPrompt := fs.Bool("clear-prompt", false, "clear prompt field")
 	notes := fs.String("notes", "", "notes")
 	clearNotes := fs.Bool("clear-notes", false, "clear notes field")
+	estimate := fs.Int("estimate", 0, "estimated duration in minutes")
+	clearEstimate := fs.Bool("clear-estimate", false, "clear estimate field")
 	acClear := fs.Bool("ac-clear", false, "clear acceptance criteria")
 	var ac multiStringFlag
 	fs.Var(&ac, "ac", "acceptance criteria (repeatable; replaces full list when provided)")
@@ -145,6 +167,9 @@ func runEdit(id string, args []string) error {
 	if fs.NArg() != 0 {
 		return UsageError{Message: "edit takes only flags (no positional args after <id>)"}
 	}
+	if *estimate < 0 {
+		return UsageError{Message: "--estimate must be a positive number of minutes"}
+	}
 
 	path := plan.PlanPath()
 	g, err := loadValidatedPlan(path)
@@ -158,7 +183,7 @@ func runEdit(id string, args []string) error {
 	}
 
 	// If no flags were provided, do a minimal interactive edit.
-	noEdits := *title == "" && *description == "" && !*clearDescription && *prompt == "" && !*clearPrompt && *notes == "" && !*clearNotes && !*acClear && len(ac) == 0
+	noEdits := *title == "" && *description == "" && !*clearDescription && *prompt == "" && !*clearPrompt && *notes == "" && !*clearNotes && *estimate == 0 && !*clearEstimate && !*acClear && len(ac) == 0
 	if noEdits {
 		v, err := promptLineDefault("Title", it.Title)
 		if err != nil {
@@ -225,6 +250,15 @@ func runEdit(id string, args []string) error {
 		changed = true
 	}
 
+	if *clearEstimate {
+		it.EstimateMinutes = nil
+		changed = true
+	} else if *estimate > 0 {
+		minutes := *estimate
+		it.EstimateMinutes = &minutes
+		changed = true
+	}
+
 	if *acClear {
 		it.AcceptanceCriteria = []string{}
 		changed = true
@@ -246,7 +280,7 @@ func runEdit(id string, args []string) error {
 		printValidationErrors(os.Stdout, path, errs)
 		return errors.New("validation failed")
 	}
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "edit "+id); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 
@@ -260,6 +294,8 @@ func runDelete(id string, args []string) error {
 
 	cascade := fs.Bool("cascade-children", false, "delete this node and all descendants")
 	force := fs.Bool("force", false, "also remove dep edges from remaining nodes that depend on deleted nodes")
+	pruneRuns := fs.Bool("prune-runs", false, "also remove the run history of deleted items without asking")
+	yes := fs.Bool("yes", false, "delete without confirming the impact preview")
 
 	if err := fs.Parse(args); err != nil {
 		return UsageError{Message: err.Error()}
@@ -274,17 +310,38 @@ func runDelete(id string, args []string) error {
 		return err
 	}
 
+	before := plan.Clone(g)
 	now := time.Now().UTC()
 	res, err := plan.DeleteItem(&g, id, *cascade, *force, now)
 	if err != nil {
 		return err
 	}
+	prune, asked := *pruneRuns, false
+	impact, err := analyzeChangeImpact(path, before, g)
+	if err != nil {
+		return err
+	}
+	if impact.reachesBeyondChange() {
+		printImpact(os.Stdout, before, impact)
+		if !*yes && isTerminal(os.Stdin.Fd()) {
+			var ok bool
+			ok, prune, err = confirmDelete(len(impact.OrphanedRuns), prune)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(os.Stdout, "delete cancelled")
+				return nil
+			}
+			asked = true
+		}
+	}
 
 	if errs := plan.Validate(g); len(errs) != 0 {
 		printValidationErrors(os.Stdout, path, errs)
 		return errors.New("validation failed")
 	}
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "delete "+id); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 
@@ -292,6 +349,63 @@ func runDelete(id string, args []string) error {
 	if len(res.DetachedIDs) > 0 {
 		fmt.Fprintf(os.Stdout, "detached deps from: %s\n", strings.Join(res.DetachedIDs, ", "))
 	}
+	return pruneDeletedRuns(filepath.Dir(path), res.DeletedIDs, prune, asked)
+}
+
+// confirmDelete asks once whether to apply a previewed delete and, when the
+// deleted items have run history, whether to remove it as well.
+func confirmDelete(orphanedRuns int, prune bool) (ok, pruneRuns bool, err error) {
+	if orphanedRuns == 0 || prune {
+		ok, err := promptConfirm("Continue")
+		return ok, prune, err
+	}
+	fmt.Fprintln(os.Stdout, "yes: delete and remove the run history; keep: delete but keep it; no: cancel")
+	choice, err := promptChoice(fmt.Sprintf("Delete and remove run history for %d item(s)?", orphanedRuns), []string{"no", "yes", "keep"})
+	if err != nil {
+		return false, false, err
+	}
+	return choice != "no", choice == "yes", nil
+}
+
+// pruneDeletedRuns removes the run directories left behind by deleted items.
+// Without force it asks first, unless the delete confirmation already did
+// (asked) or stdin is not a terminal; then the history is kept.
+func pruneDeletedRuns(baseDir string, deletedIDs []string, force, asked bool) error {
+	taskIDs, err := execution.RunDirTaskIDs(baseDir)
+	if err != nil {
+		return err
+	}
+	deleted := map[string]bool{}
+	for _, id := range deletedIDs {
+		deleted[id] = true
+	}
+	withRuns := []string{}
+	for _, id := range taskIDs {
+		if deleted[id] {
+			withRuns = append(withRuns, id)
+		}
+	}
+	if len(withRuns) == 0 {
+		return nil
+	}
+	if !force {
+		if asked || !isTerminal(os.Stdin.Fd()) {
+			fmt.Fprintf(os.Stdout, "run history kept for %d deleted item(s); remove it with `blackbird gc --orphans`\n", len(withRuns))
+			return nil
+		}
+		ok, err := promptConfirm(fmt.Sprintf("Remove run history for %d deleted item(s)?", len(withRuns)))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+	removed, err := execution.RemoveTaskRuns(baseDir, withRuns)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "removed run history for %d item(s)\n", len(removed))
 	return nil
 }
 
@@ -301,6 +415,7 @@ func runMove(id string, args []string) error {
 
 	parent := fs.String("parent", "", "parent id or 'root' (required)")
 	indexStr := fs.String("index", "", "insert index within parent's childIds (optional)")
+	yes := fs.Bool("yes", false, "move without confirming the impact preview")
 
 	if err := fs.Parse(args); err != nil {
 		return UsageError{Message: err.Error()}
@@ -332,16 +447,23 @@ func runMove(id string, args []string) error {
 		idx = &n
 	}
 
+	before := plan.Clone(g)
 	now := time.Now().UTC()
 	if err := plan.MoveItem(&g, id, parentID, idx, now); err != nil {
 		return err
 	}
+	if ok, err := previewImpact(path, before, g, *yes); err != nil || !ok {
+		if err == nil {
+			fmt.Fprintln(os.Stdout, "move cancelled")
+		}
+		return err
+	}
 
 	if errs := plan.Validate(g); len(errs) != 0 {
 		printValidationErrors(os.Stdout, path, errs)
 		return errors.New("validation failed")
 	}
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "move "+id); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 	fmt.Fprintf(os.Stdout, "moved %s\n", id)
@@ -390,7 +512,7 @@ func runDepsAdd(id, depID string) error {
 		printValidationErrors(os.Stdout, path, errs)
 		return errors.New("validation failed")
 	}
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "deps add "+id+" "+depID); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 	fmt.Fprintf(os.Stdout, "added dep %s -> %s\n", id, depID)
@@ -412,7 +534,7 @@ func runDepsRemove(id, depID string) error {
 		printValidationErrors(os.Stdout, path, errs)
 		return errors.New("validation failed")
 	}
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "deps remove "+id+" "+depID); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 	fmt.Fprintf(os.Stdout, "removed dep %s -> %s\n", id, depID)
@@ -434,7 +556,7 @@ func runDepsSet(id string, deps []string) error {
 		printValidationErrors(os.Stdout, path, errs)
 		return errors.New("validation failed")
 	}
-	if err := plan.SaveAtomic(path, g); err != nil {
+	if err := plan.SaveWithHistory(path, g, "deps set "+id); err != nil {
 		return fmt.Errorf("write plan file: %w", err)
 	}
 	fmt.Fprintf(os.Stdout, "set %s deps (%d)\n", id, len(deps))
@@ -452,9 +574,19 @@ func loadValidatedPlan(path string) (plan.WorkGraph, error) {
 	if errs := plan.Validate(g); len(errs) != 0 {
 		return plan.WorkGraph{}, fmt.Errorf("plan is invalid (run `blackbird validate`): %s", path)
 	}
+	warnExternalDeps(os.Stderr, g)
 	return g, nil
 }
 
+// warnExternalDeps prints the external deps of g whose plan or item no longer
+// exists. They do not stop the plan from loading, so that the deps can be
+// removed; `blackbird validate` reports them as errors.
+func warnExternalDeps(w io.Writer, g plan.WorkGraph) {
+	for _, e := range plan.ExternalDepErrors(g) {
+		fmt.Fprintf(w, "warning: %s: %s\n", e.Path, e.Message)
+	}
+}
+
 func printValidationErrors(w io.Writer, path string, errs []plan.ValidationError) {
 	fmt.Fprintf(w, "invalid plan: %s\n", path)
 	for _, e := range errs {
@@ -462,22 +594,6 @@ func printValidationErrors(w io.Writer, path string, errs []plan.ValidationError
 	}
 }
 
-func generateID(g plan.WorkGraph) string {
-	// Small, stable-enough ID for human CLI usage: "w_<hex>".
-	// (Generated only on creation; never re-generated.)
-	for {
-		var b [6]byte
-		_, _ = rand.Read(b[:])
-		id := "w_" + hex.EncodeToString(b[:])
-		if g.Items == nil {
-			return id
-		}
-		if _, ok := g.Items[id]; !ok {
-			return id
-		}
-	}
-}
-
 func promptLine(label string) (string, error) {
 	if label != "" {
 		fmt.Fprintf(os.Stdout, "%s: ", label)
@@ -3,6 +3,7 @@ package cli
 import (
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -88,3 +89,41 @@ func captureStdout(fn func() error) (string, error) {
 	}
 	return string(data), runErr
 }
+
+func TestRunDelete_PruneRunsRemovesRunHistory(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	now := time.Now().UTC()
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items: map[string]plan.WorkItem{
+			"A": newWorkItem("A", now),
+			"B": newWorkItem("B", now),
+		},
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	for _, id := range []string{"A", "B"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, ".blackbird", "runs", id), 0o755); err != nil {
+			t.Fatalf("mkdir runs: %v", err)
+		}
+	}
+
+	output, err := captureStdout(func() error {
+		return runDelete("A", []string{"--prune-runs"})
+	})
+	if err != nil {
+		t.Fatalf("runDelete: %v", err)
+	}
+	if !strings.Contains(output, "removed run history for 1 item(s)") {
+		t.Fatalf("output missing prune note: %q", output)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".blackbird", "runs", "A")); !os.IsNotExist(err) {
+		t.Fatalf("expected A runs removed, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, ".blackbird", "runs", "B")); err != nil {
+		t.Fatalf("expected B runs kept: %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/mcp"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func runMCP(args []string) error {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	task := fs.String("task", "", "task the agent is working on (default for tool ids)")
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "mcp takes only flags (no positional args)"}
+	}
+
+	path := plan.PlanPath()
+	if _, err := loadValidatedPlan(path); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Stdout carries the protocol; nothing else may be printed to it.
+	srv := &mcp.Server{PlanPath: path, TaskID: strings.TrimSpace(*task)}
+	return srv.Serve(ctx, os.Stdin, os.Stdout)
+}
+
+func runProposals(args []string) error {
+	if len(args) == 0 || args[0] == "list" {
+		if len(args) > 1 {
+			return UsageError{Message: "proposals list takes no arguments"}
+		}
+		return runProposalsList()
+	}
+	switch args[0] {
+	case "show":
+		if len(args) != 2 {
+			return UsageError{Message: "proposals show requires: <proposalId>"}
+		}
+		return runProposalsShow(args[1])
+	case "approve":
+		if len(args) != 2 {
+			return UsageError{Message: "proposals approve requires: <proposalId>"}
+		}
+		return runProposalsApprove(args[1])
+	case "reject":
+		if len(args) != 2 {
+			return UsageError{Message: "proposals reject requires: <proposalId>"}
+		}
+		return runProposalsReject(args[1])
+	default:
+		return UsageError{Message: fmt.Sprintf("unknown proposals subcommand: %q", args[0])}
+	}
+}
+
+func runProposalsList() error {
+	proposals, err := mcp.ListProposals(filepath.Dir(plan.PlanPath()))
+	if err != nil {
+		return err
+	}
+	if len(proposals) == 0 {
+		fmt.Fprintln(os.Stdout, "no pending proposals")
+		return nil
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTASK\tCREATED\tCHANGE")
+	for _, p := range proposals {
+		task := p.TaskID
+		if task == "" {
+			task = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", p.ID, task, p.CreatedAt.Local().Format("2006-01-02 15:04"), describeProposal(p))
+	}
+	return tw.Flush()
+}
+
+func runProposalsShow(id string) error {
+	p, err := mcp.LoadProposal(filepath.Dir(plan.PlanPath()), id)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "Proposal: %s\n", p.ID)
+	if p.TaskID != "" {
+		fmt.Fprintf(os.Stdout, "From task: %s\n", p.TaskID)
+	}
+	fmt.Fprintf(os.Stdout, "Created: %s\n", p.CreatedAt.Format(time.RFC3339))
+	if p.Rationale != "" {
+		fmt.Fprintf(os.Stdout, "Rationale: %s\n", p.Rationale)
+	}
+	for _, op := range p.Ops {
+		fmt.Fprintf(os.Stdout, "- %s\n", describeOp(op))
+		if op.Item == nil {
+			continue
+		}
+		if op.Item.Description != "" {
+			fmt.Fprintf(os.Stdout, "  Description: %s\n", op.Item.Description)
+		}
+		for _, ac := range op.Item.AcceptanceCriteria {
+			fmt.Fprintf(os.Stdout, "  AC: %s\n", ac)
+		}
+		if len(op.Item.Deps) > 0 {
+			fmt.Fprintf(os.Stdout, "  Deps: %s\n", strings.Join(op.Item.Deps, ", "))
+		}
+	}
+	return nil
+}
+
+func runProposalsApprove(id string) error {
+	path := plan.PlanPath()
+	if _, err := loadValidatedPlan(path); err != nil {
+		return err
+	}
+	before, after, err := mcp.ApplyProposal(path, id, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "approved %s\n", id)
+	printDiffSummary(os.Stdout, plan.Diff(before, after))
+	return nil
+}
+
+func runProposalsReject(id string) error {
+	if err := mcp.DeleteProposal(filepath.Dir(plan.PlanPath()), id); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "rejected %s\n", id)
+	return nil
+}
+
+func describeProposal(p mcp.Proposal) string {
+	parts := make([]string, 0, len(p.Ops))
+	for _, op := range p.Ops {
+		parts = append(parts, describeOp(op))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describeOp(op agent.PatchOp) string {
+	switch op.Op {
+	case agent.PatchAdd:
+		if op.Item == nil {
+			return "add"
+		}
+		parent := "root"
+		if op.ParentID != nil {
+			parent = *op.ParentID
+		}
+		return fmt.Sprintf("add %s %q under %s", op.Item.ID, op.Item.Title, parent)
+	case agent.PatchAddDep, agent.PatchRemoveDep:
+		return fmt.Sprintf("%s %s -> %s", op.Op, op.ID, op.DepID)
+	default:
+		id := op.ID
+		if id == "" && op.Item != nil {
+			id = op.Item.ID
+		}
+		return fmt.Sprintf("%s %s", op.Op, id)
+	}
+}
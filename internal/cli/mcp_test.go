@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/mcp"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestProposalsListApproveReject(t *testing.T) {
+	chdirForTest(t, t.TempDir())
+
+	now := time.Now().UTC()
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"A": newWorkItem("A", now)},
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	baseDir := filepath.Dir(plan.PlanPath())
+	parent := "A"
+	propose := func(id string) mcp.Proposal {
+		item := newWorkItem(id, now)
+		p, err := mcp.SaveProposal(baseDir, mcp.Proposal{
+			TaskID: "A",
+			Ops:    []agent.PatchOp{{Op: agent.PatchAdd, Item: &item, ParentID: &parent}},
+		})
+		if err != nil {
+			t.Fatalf("SaveProposal: %v", err)
+		}
+		return p
+	}
+	keep := propose("B")
+	drop := propose("C")
+
+	output, err := captureStdout(func() error { return runProposals(nil) })
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if !strings.Contains(output, keep.ID) || !strings.Contains(output, `add B "B" under A`) {
+		t.Fatalf("unexpected list output: %q", output)
+	}
+
+	output, err = captureStdout(func() error { return runProposals([]string{"approve", keep.ID}) })
+	if err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+	if !strings.Contains(output, "Added: B") {
+		t.Fatalf("unexpected approve output: %q", output)
+	}
+	if _, err := captureStdout(func() error { return runProposals([]string{"reject", drop.ID}) }); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+
+	updated, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if _, ok := updated.Items["B"]; !ok {
+		t.Fatalf("expected B to be added")
+	}
+	if _, ok := updated.Items["C"]; ok {
+		t.Fatalf("rejected proposal was applied")
+	}
+	if left, _ := mcp.ListProposals(baseDir); len(left) != 0 {
+		t.Fatalf("expected no pending proposals, got %d", len(left))
+	}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// runMergeDriver implements a git merge driver:
+//
+//	[merge "blackbird"]
+//		driver = blackbird merge-driver %O %A %B %P
+//
+// The merged plan is written to the %A file. A non-nil error (non-zero exit)
+// tells git the merge is conflicted and leaves the file for manual resolution.
+func runMergeDriver(args []string) error {
+	if len(args) != 3 && len(args) != 4 {
+		return UsageError{Message: "merge-driver requires 3 or 4 arguments: <base> <ours> <theirs> [<path>]"}
+	}
+	basePath, oursPath, theirsPath := args[0], args[1], args[2]
+	name := oursPath
+	if len(args) == 4 {
+		name = args[3]
+	}
+
+	base, err := os.ReadFile(basePath)
+	if err != nil {
+		return fmt.Errorf("read base: %w", err)
+	}
+	ours, err := os.ReadFile(oursPath)
+	if err != nil {
+		return fmt.Errorf("read ours: %w", err)
+	}
+	theirs, err := os.ReadFile(theirsPath)
+	if err != nil {
+		return fmt.Errorf("read theirs: %w", err)
+	}
+
+	if filepath.Base(name) == plan.SplitIndexFilename || isSplitIndexData(ours) {
+		return mergeSplitIndexFiles(name, base, ours, theirs, oursPath)
+	}
+
+	format := plan.DetectFormat(ours)
+	if len(args) == 4 && filepath.Ext(name) != "" {
+		format = plan.FormatForPath(name)
+	}
+	decode := func(label string, b []byte) (plan.WorkGraph, error) {
+		if strings.TrimSpace(string(b)) == "" {
+			return plan.NewEmptyWorkGraph(), nil
+		}
+		return plan.Decode(label, b, format)
+	}
+	baseGraph, err := decode(name+" (base)", base)
+	if err != nil {
+		return err
+	}
+	oursGraph, err := decode(name+" (ours)", ours)
+	if err != nil {
+		return err
+	}
+	theirsGraph, err := decode(name+" (theirs)", theirs)
+	if err != nil {
+		return err
+	}
+
+	result := plan.Merge3(baseGraph, oursGraph, theirsGraph, time.Now().UTC())
+	out, err := plan.Marshal(result.Graph, format)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(oursPath, out, 0o644); err != nil {
+		return fmt.Errorf("write merged plan: %w", err)
+	}
+
+	for _, c := range result.Conflicts {
+		fmt.Fprintf(os.Stdout, "blackbird merge: %s %s: %s\n", c.ItemID, c.Field, c.Resolution)
+	}
+	// With the plan's path known, external deps the merge brings in must
+	// resolve, as when they are added by hand; those ours already had are
+	// left to `blackbird validate`.
+	if len(args) == 4 {
+		plan.ResolveExternalDeps(name, &oursGraph)
+		result.Graph.External = oursGraph.External
+		result.Graph.ExternalRoot = oursGraph.ExternalRoot
+	}
+	if errs := plan.Validate(result.Graph); len(errs) != 0 {
+		printValidationErrors(os.Stdout, name, errs)
+		return errors.New("merged plan is invalid; resolve manually")
+	}
+	fmt.Fprintf(os.Stdout, "blackbird merge: merged %s (%d item(s), %d conflict note(s))\n", name, len(result.Graph.Items), len(result.Conflicts))
+	return nil
+}
+
+func isSplitIndexData(b []byte) bool {
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(b, &probe); err != nil {
+		return false
+	}
+	_, hasFeatures := probe["features"]
+	_, hasItems := probe["items"]
+	return hasFeatures && !hasItems
+}
+
+func mergeSplitIndexFiles(name string, base, ours, theirs []byte, oursPath string) error {
+	decode := func(label string, b []byte) (plan.SplitIndex, error) {
+		if strings.TrimSpace(string(b)) == "" {
+			return plan.SplitIndex{SchemaVersion: plan.SchemaVersion}, nil
+		}
+		return plan.DecodeSplitIndex(label, b)
+	}
+	baseIdx, err := decode(name+" (base)", base)
+	if err != nil {
+		return err
+	}
+	oursIdx, err := decode(name+" (ours)", ours)
+	if err != nil {
+		return err
+	}
+	theirsIdx, err := decode(name+" (theirs)", theirs)
+	if err != nil {
+		return err
+	}
+	merged := plan.MergeSplitIndex(baseIdx, oursIdx, theirsIdx)
+	b, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal plan index: %w", err)
+	}
+	if err := os.WriteFile(oursPath, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write merged plan index: %w", err)
+	}
+	fmt.Fprintf(os.Stdout, "blackbird merge: merged %s (%d feature(s))\n", name, len(merged.Features))
+	return nil
+}
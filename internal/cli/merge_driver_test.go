@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestRunMergeDriverMergesIntoOursFile(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"A": newWorkItem("A", now)},
+	}
+	ours := plan.Clone(base)
+	ours.Items["B"] = newWorkItem("B", now)
+	theirs := plan.Clone(base)
+	theirs.Items["C"] = newWorkItem("C", now)
+
+	// Git passes temp files without the plan's extension.
+	paths := map[string]plan.WorkGraph{"base": base, "ours": ours, "theirs": theirs}
+	for name, g := range paths {
+		if err := plan.SaveFile(filepath.Join(dir, name), g); err != nil {
+			t.Fatalf("save %s: %v", name, err)
+		}
+	}
+
+	output, err := captureStdout(func() error {
+		return Run([]string{"merge-driver", filepath.Join(dir, "base"), filepath.Join(dir, "ours"), filepath.Join(dir, "theirs"), plan.DefaultPlanFilename})
+	})
+	if err != nil {
+		t.Fatalf("merge-driver: %v\n%s", err, output)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "ours"))
+	if err != nil {
+		t.Fatalf("read merged: %v", err)
+	}
+	merged, err := plan.Decode("merged", data, plan.FormatJSON)
+	if err != nil {
+		t.Fatalf("decode merged: %v", err)
+	}
+	for _, id := range []string{"A", "B", "C"} {
+		if _, ok := merged.Items[id]; !ok {
+			t.Fatalf("merged plan missing %s", id)
+		}
+	}
+	if !strings.Contains(output, "merged blackbird.plan.json (3 item(s)") {
+		t.Fatalf("unexpected output: %q", output)
+	}
+}
+
+func TestRunMergeDriverFailsOnInvalidResult(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"A": newWorkItem("A", now), "B": newWorkItem("B", now)},
+	}
+	ours := plan.Clone(base)
+	a := ours.Items["A"]
+	a.Deps = []string{"B"}
+	ours.Items["A"] = a
+	theirs := plan.Clone(base)
+	b := theirs.Items["B"]
+	b.Deps = []string{"A"}
+	theirs.Items["B"] = b
+
+	for name, g := range map[string]plan.WorkGraph{"base": base, "ours": ours, "theirs": theirs} {
+		if err := plan.SaveFile(filepath.Join(dir, name+".json"), g); err != nil {
+			t.Fatalf("save %s: %v", name, err)
+		}
+	}
+	_, err := captureStdout(func() error {
+		return Run([]string{"merge-driver", filepath.Join(dir, "base.json"), filepath.Join(dir, "ours.json"), filepath.Join(dir, "theirs.json")})
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Fatalf("expected invalid merge error for dependency cycle, got %v", err)
+	}
+}
+
+func TestRunMergeDriverChecksExternalDepsFromTheirs(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	backend := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": newWorkItem("api", now)}}
+	if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(tempDir, "backend"), plan.DefaultPlanFilename), backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+
+	base := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"A": newWorkItem("A", now), "B": newWorkItem("B", now)},
+	}
+	// Ours already has a dep whose item has gone; that is left to validate.
+	ours := plan.Clone(base)
+	a := ours.Items["A"]
+	a.Deps = []string{"plan:backend#gone"}
+	ours.Items["A"] = a
+
+	merge := func(theirsDep string) (string, error) {
+		theirs := plan.Clone(base)
+		b := theirs.Items["B"]
+		b.Deps = []string{theirsDep}
+		theirs.Items["B"] = b
+		for name, g := range map[string]plan.WorkGraph{"base": base, "ours": ours, "theirs": theirs} {
+			if err := plan.SaveFile(filepath.Join(tempDir, name), g); err != nil {
+				t.Fatalf("save %s: %v", name, err)
+			}
+		}
+		return captureStdout(func() error {
+			return Run([]string{"merge-driver", "base", "ours", "theirs", plan.DefaultPlanFilename})
+		})
+	}
+
+	if output, err := merge("plan:backend#api"); err != nil {
+		t.Fatalf("merge-driver: %v\n%s", err, output)
+	}
+	output, err := merge("plan:backend#missing")
+	if err == nil || !strings.Contains(output, `unknown dep id "missing" in plan "backend"`) {
+		t.Fatalf("merge-driver = %v\n%s, want the unknown external item from theirs reported", err, output)
+	}
+	if strings.Contains(output, `"gone"`) {
+		t.Fatalf("merge-driver reported ours' existing dep:\n%s", output)
+	}
+}
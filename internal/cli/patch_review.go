@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// reviewPatchOps walks the user through ops one at a time and returns base
+// with the accepted ops applied, and how many were accepted. Each accepted op
+// is applied as soon as it is accepted, so later ops are previewed against
+// what was actually accepted and ops that no longer apply are skipped. When
+// the accepted ops leave the plan invalid, the review starts over.
+func reviewPatchOps(base plan.WorkGraph, ops []agent.PatchOp, now time.Time) (plan.WorkGraph, int, error) {
+	for {
+		next, count, err := reviewPatchOpsOnce(base, ops, now)
+		if err != nil {
+			return plan.WorkGraph{}, 0, err
+		}
+		errs := plan.Validate(next)
+		if count == 0 || len(errs) == 0 {
+			return next, count, nil
+		}
+		fmt.Fprintf(os.Stdout, "\naccepted operations leave the plan invalid: %s\nreviewing the operations again\n", errs[0].Error())
+	}
+}
+
+func reviewPatchOpsOnce(base plan.WorkGraph, ops []agent.PatchOp, now time.Time) (plan.WorkGraph, int, error) {
+	g := plan.Clone(base)
+	count := 0
+	rest := ""
+	for i, op := range ops {
+		review, next := agent.ReviewOp(g, i, op, now)
+		fmt.Fprintln(os.Stdout, "")
+		printOpReview(os.Stdout, review, len(ops))
+		if review.Err != nil {
+			continue
+		}
+		answer := rest
+		if answer == "" {
+			line, err := promptLine("Accept? [y]es / [N]o / [a]ccept rest / [r]eject rest")
+			if err != nil {
+				return plan.WorkGraph{}, 0, err
+			}
+			answer = strings.ToLower(strings.TrimSpace(line))
+			if answer == "a" || answer == "r" {
+				rest = answer
+			}
+		}
+		if answer == "y" || answer == "yes" || answer == "a" {
+			g = next
+			count++
+		}
+	}
+	return g, count, nil
+}
+
+func printOpReview(w io.Writer, r agent.OpReview, total int) {
+	fmt.Fprintf(w, "[%d/%d] %s\n", r.Index+1, total, r.Summary())
+	if r.Err != nil {
+		fmt.Fprintf(w, "  skipped: %v\n", r.Err)
+		return
+	}
+	printFieldChanges(w, r.Changes, "  ")
+	for _, line := range r.Rationale {
+		fmt.Fprintf(w, "  why: %s\n", line)
+	}
+}
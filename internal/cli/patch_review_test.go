@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestReviewPatchOpsAppliesAcceptedOps(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	base := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{
+		"A": newWorkItem("A", now),
+		"B": newWorkItem("B", now),
+		"C": newWorkItem("C", now),
+	}}
+	renamed := base.Items["A"]
+	renamed.Title = "A, renamed"
+	ops := []agent.PatchOp{
+		{Op: agent.PatchUpdate, ID: "A", Item: &renamed, Rationale: "clearer title"},
+		{Op: agent.PatchAddDep, ID: "B", DepID: "A", DepRationale: map[string]string{"A": "needs A"}},
+		{Op: agent.PatchAddDep, ID: "C", DepID: "A"},
+		{Op: agent.PatchAddDep, ID: "C", DepID: "B"},
+	}
+
+	setPromptReader(strings.NewReader("n\ny\nr\n"))
+	t.Cleanup(func() { setPromptReader(os.Stdin) })
+
+	var next plan.WorkGraph
+	var accepted int
+	output, err := captureStdout(func() error {
+		var err error
+		next, accepted, err = reviewPatchOps(base, ops, now)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("reviewPatchOps: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		`[1/4] update A "A, renamed"`,
+		`title: "A" -> "A, renamed"`, "why: clearer title",
+		`[2/4] add_dep B -> A "B"`,
+		"deps:", "+ A", "why: A: needs A",
+		`[3/4] add_dep C -> A "C"`,
+		`[4/4] add_dep C -> B "C"`,
+	})
+	if strings.Count(output, "Accept?") != 3 {
+		t.Fatalf("expected 3 prompts (reject rest skips the last), got output:\n%s", output)
+	}
+	if accepted != 1 || next.Items["A"].Title != "A" || len(next.Items["B"].Deps) != 1 || len(next.Items["C"].Deps) != 0 {
+		t.Fatalf("accepted = %d, items = %+v", accepted, next.Items)
+	}
+}
+
+func TestReviewPatchOpsSkipsOpsOnRejectedAdds(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	base := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{
+		"A": newWorkItem("A", now),
+	}}
+	added := newWorkItem("N", now)
+	renamed := base.Items["A"]
+	renamed.Title = "A, renamed"
+	ops := []agent.PatchOp{
+		{Op: agent.PatchAdd, Item: &added},
+		{Op: agent.PatchAddDep, ID: "A", DepID: "N"},
+		{Op: agent.PatchUpdate, ID: "A", Item: &renamed},
+	}
+
+	// Reject the add; the add_dep on it is skipped without a prompt.
+	setPromptReader(strings.NewReader("n\ny\n"))
+	t.Cleanup(func() { setPromptReader(os.Stdin) })
+
+	var next plan.WorkGraph
+	var accepted int
+	output, err := captureStdout(func() error {
+		var err error
+		next, accepted, err = reviewPatchOps(base, ops, now)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("reviewPatchOps: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{`[1/3] add N`, `[2/3] add_dep A -> N`, "skipped:", `[3/3] update A`})
+	if strings.Count(output, "Accept?") != 2 {
+		t.Fatalf("expected 2 prompts, got output:\n%s", output)
+	}
+	if accepted != 1 || next.Items["A"].Title != "A, renamed" || len(next.Items) != 1 {
+		t.Fatalf("accepted = %d, items = %+v", accepted, next.Items)
+	}
+}
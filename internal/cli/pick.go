@@ -36,6 +36,7 @@ func runPick(args []string) error {
 	includeNonLeaf := fs.Bool("include-non-leaf", false, "include non-leaf items")
 	all := fs.Bool("all", false, "show all items in scope")
 	blocked := fs.Bool("blocked", false, "show blocked items in scope")
+	out := addOutputFlags(fs)
 
 	if err := fs.Parse(args); err != nil {
 		return UsageError{Message: err.Error()}
@@ -43,8 +44,34 @@ func runPick(args []string) error {
 	if fs.NArg() != 0 {
 		return UsageError{Message: "pick takes only flags (no positional args)"}
 	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
 
 	path := plan.PlanPath()
+	if asJSON {
+		// Non-interactive: emit the candidate list instead of prompting.
+		g, err := loadValidatedPlan(path)
+		if err != nil {
+			return err
+		}
+		rows, stats := pickRows(g, *includeNonLeaf, *all, *blocked)
+		items := make([]itemJSON, 0, len(rows))
+		for _, r := range rows {
+			items = append(items, newItemJSON(g, g.Items[r.id]))
+		}
+		return writeJSON(os.Stdout, pickJSON{
+			SchemaVersion: OutputSchemaVersion,
+			Items:         items,
+			Summary: pickStatJSON{
+				Total:           stats.total,
+				Ready:           stats.ready,
+				BlockedOnDeps:   stats.blockedOnDeps,
+				ManuallyBlocked: stats.manualBlocked,
+			},
+		})
+	}
 
 	for {
 		g, err := loadValidatedPlan(path)
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func runPlanConvert(args []string) error {
+	fs := flag.NewFlagSet("plan convert", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	to := fs.String("to", "", "target format: json|yaml (default: the other format)")
+	keep := fs.Bool("keep", false, "keep the source plan file")
+
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "plan convert takes only flags (no positional args)"}
+	}
+
+	path := plan.PlanPath()
+	if plan.IsSplit(path) {
+		return errors.New("plan uses the split layout; run `blackbird plan join` before converting")
+	}
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+
+	from := plan.FormatForPath(path)
+	target := plan.FormatYAML
+	if from == plan.FormatYAML {
+		target = plan.FormatJSON
+	}
+	if *to != "" {
+		f, ok := plan.ParseFormat(*to)
+		if !ok {
+			return UsageError{Message: fmt.Sprintf("invalid format %q (expected json or yaml)", *to)}
+		}
+		target = f
+	}
+	if target == from {
+		fmt.Fprintf(os.Stdout, "plan is already %s: %s\n", target, path)
+		return nil
+	}
+
+	dest := plan.PathForFormat(path, target)
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("target plan file already exists: %s", dest)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("stat plan file: %w", err)
+	}
+
+	if err := plan.SaveAtomic(dest, g); err != nil {
+		return fmt.Errorf("write plan file: %w", err)
+	}
+	// Round-trip check: the converted file must load back to an equivalent plan.
+	converted, err := plan.Load(dest)
+	if err != nil {
+		_ = os.Remove(dest)
+		return fmt.Errorf("verify converted plan: %w", err)
+	}
+	if diff := plan.Diff(g, converted); len(diff.Added)+len(diff.Removed)+len(diff.Updated)+len(diff.Moved) != 0 {
+		_ = os.Remove(dest)
+		return fmt.Errorf("verify converted plan: round-trip changed items")
+	}
+
+	if !*keep {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("remove source plan file: %w", err)
+		}
+	}
+	fmt.Fprintf(os.Stdout, "converted plan: %s -> %s\n", path, dest)
+	if *keep && from == plan.FormatJSON {
+		fmt.Fprintf(os.Stdout, "note: %s takes precedence while both files exist\n", path)
+	}
+	return nil
+}
+
+func runPlanSplit(args []string) error {
+	if len(args) != 0 {
+		return UsageError{Message: "plan split takes no arguments"}
+	}
+
+	path := plan.PlanPath()
+	if plan.IsSplit(path) {
+		fmt.Fprintf(os.Stdout, "plan already uses the split layout: %s\n", plan.SplitDir(path))
+		return nil
+	}
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+
+	if err := plan.SaveSplit(path, g); err != nil {
+		return fmt.Errorf("write plan files: %w", err)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("remove single-file plan: %w", err)
+	}
+	fmt.Fprintf(os.Stdout, "split plan into %s\n", plan.SplitDir(path))
+	return nil
+}
+
+func runPlanJoin(args []string) error {
+	if len(args) != 0 {
+		return UsageError{Message: "plan join takes no arguments"}
+	}
+
+	path := plan.PlanPath()
+	if !plan.IsSplit(path) {
+		fmt.Fprintf(os.Stdout, "plan does not use the split layout: %s\n", path)
+		return nil
+	}
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+
+	if err := plan.SaveFile(path, g); err != nil {
+		return fmt.Errorf("write plan file: %w", err)
+	}
+	if err := plan.RemoveSplit(path); err != nil {
+		return fmt.Errorf("remove split plan directory: %w", err)
+	}
+	fmt.Fprintf(os.Stdout, "joined plan into %s\n", path)
+	return nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestRunPlanConvertSwitchesFormat(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+
+	now := time.Now().UTC()
+	item := newWorkItem("A", now)
+	item.Prompt = "line one\nline two"
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"A": item},
+	}
+	jsonPath := filepath.Join(tempDir, plan.DefaultPlanFilename)
+	if err := plan.SaveAtomic(jsonPath, g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	if _, err := captureStdout(func() error {
+		return Run([]string{"plan", "convert"})
+	}); err != nil {
+		t.Fatalf("plan convert: %v", err)
+	}
+	yamlPath := filepath.Join(tempDir, plan.YAMLPlanFilename)
+	if _, err := os.Stat(jsonPath); !os.IsNotExist(err) {
+		t.Fatalf("expected json plan removed, stat err = %v", err)
+	}
+	if filepath.Base(plan.PlanPath()) != plan.YAMLPlanFilename {
+		t.Fatalf("PlanPath() = %s, want yaml plan", plan.PlanPath())
+	}
+	loaded, err := plan.Load(yamlPath)
+	if err != nil {
+		t.Fatalf("load yaml plan: %v", err)
+	}
+	if loaded.Items["A"].Prompt != item.Prompt {
+		t.Fatalf("prompt = %q, want %q", loaded.Items["A"].Prompt, item.Prompt)
+	}
+
+	if _, err := captureStdout(func() error {
+		return Run([]string{"plan", "convert", "--to", "json", "--keep"})
+	}); err != nil {
+		t.Fatalf("plan convert --to json: %v", err)
+	}
+	if _, err := os.Stat(yamlPath); err != nil {
+		t.Fatalf("expected yaml plan kept: %v", err)
+	}
+	if _, err := plan.Load(jsonPath); err != nil {
+		t.Fatalf("load json plan: %v", err)
+	}
+}
+
+func TestRunPlanSplitAndJoin(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+
+	now := time.Now().UTC()
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"A": newWorkItem("A", now), "B": newWorkItem("B", now)},
+	}
+	path := plan.PlanPath()
+	if err := plan.SaveAtomic(path, g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	if _, err := captureStdout(func() error { return Run([]string{"plan", "split"}) }); err != nil {
+		t.Fatalf("plan split: %v", err)
+	}
+	if !plan.IsSplit(path) {
+		t.Fatalf("expected split layout after plan split")
+	}
+	if _, err := captureStdout(func() error { return Run([]string{"set-status", "A", "done"}) }); err != nil {
+		t.Fatalf("set-status: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("set-status should save into the split layout, stat err = %v", err)
+	}
+	if err := Run([]string{"plan", "convert"}); err == nil {
+		t.Fatalf("expected convert to refuse split layout")
+	}
+
+	if _, err := captureStdout(func() error { return Run([]string{"plan", "join"}) }); err != nil {
+		t.Fatalf("plan join: %v", err)
+	}
+	if plan.IsSplit(path) {
+		t.Fatalf("expected single-file layout after plan join")
+	}
+	if _, err := os.Stat(plan.SplitDir(path)); !os.IsNotExist(err) {
+		t.Fatalf("expected split dir removed, stat err = %v", err)
+	}
+	joined, err := plan.Load(path)
+	if err != nil {
+		t.Fatalf("load joined plan: %v", err)
+	}
+	if joined.Items["A"].Status != plan.StatusDone || len(joined.Items) != 2 {
+		t.Fatalf("unexpected joined plan: %+v", joined.Items)
+	}
+}
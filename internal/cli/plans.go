@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+type plansJSON struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	Current       string          `json:"current"`
+	Plans         []namedPlanJSON `json:"plans"`
+}
+
+type namedPlanJSON struct {
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Current bool   `json:"current"`
+}
+
+// extractPlanFlag removes the global --plan <ref> / --plan=<ref> from the
+// front of args. It is only recognized before the subcommand, so flag values
+// and positional args of the subcommand are never taken for it.
+func extractPlanFlag(args []string) ([]string, string, error) {
+	ref := ""
+	for len(args) > 0 {
+		arg := args[0]
+		switch {
+		case arg == "--plan" || arg == "-plan":
+			if len(args) < 2 {
+				return nil, "", UsageError{Message: "--plan requires a plan name or path"}
+			}
+			ref = args[1]
+			args = args[2:]
+		case strings.HasPrefix(arg, "--plan=") || strings.HasPrefix(arg, "-plan="):
+			ref = arg[strings.Index(arg, "=")+1:]
+			args = args[1:]
+		default:
+			return args, ref, nil
+		}
+		if strings.TrimSpace(ref) == "" {
+			return nil, "", UsageError{Message: "--plan requires a plan name or path"}
+		}
+	}
+	return args, ref, nil
+}
+
+func runPlans(args []string) error {
+	fs := flag.NewFlagSet("plans", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "plans takes only flags (no positional args)"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	current := plan.PlanPath()
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	plans, err := plan.ListPlans(wd)
+	if err != nil {
+		return fmt.Errorf("list plans: %w", err)
+	}
+
+	if asJSON {
+		doc := plansJSON{SchemaVersion: OutputSchemaVersion, Current: current, Plans: []namedPlanJSON{}}
+		for _, p := range plans {
+			doc.Plans = append(doc.Plans, namedPlanJSON{Name: p.Name, Path: p.Path, Current: samePlanPath(p.Path, current)})
+		}
+		return writeJSON(os.Stdout, doc)
+	}
+
+	if len(plans) == 0 {
+		fmt.Fprintln(os.Stdout, "no plans (run `blackbird init` or `blackbird --plan <name> init`)")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, p := range plans {
+		mark := " "
+		if samePlanPath(p.Path, current) {
+			mark = "*"
+		}
+		name := p.Name
+		if name == "" {
+			name = "(default)"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\n", mark, name, p.Path)
+	}
+	return w.Flush()
+}
+
+func samePlanPath(a, b string) bool {
+	return filepath.Clean(a) == filepath.Clean(b)
+}
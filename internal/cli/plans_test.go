@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestExtractPlanFlag(t *testing.T) {
+	args, ref, err := extractPlanFlag([]string{"--plan", "migration", "show", "a", "--json"})
+	if err != nil || ref != "migration" || !reflect.DeepEqual(args, []string{"show", "a", "--json"}) {
+		t.Fatalf("got %v %q %v", args, ref, err)
+	}
+	args, ref, err = extractPlanFlag([]string{"--plan=svc/plan.yaml", "list", "--", "--plan", "x"})
+	if err != nil || ref != "svc/plan.yaml" || !reflect.DeepEqual(args, []string{"list", "--", "--plan", "x"}) {
+		t.Fatalf("got %v %q %v", args, ref, err)
+	}
+	// After the subcommand, --plan belongs to the subcommand.
+	args, ref, err = extractPlanFlag([]string{"add", "--title", "--plan", "--plan", "x"})
+	if err != nil || ref != "" || !reflect.DeepEqual(args, []string{"add", "--title", "--plan", "--plan", "x"}) {
+		t.Fatalf("got %v %q %v", args, ref, err)
+	}
+	var usage UsageError
+	if _, _, err := extractPlanFlag([]string{"--plan"}); !errors.As(err, &usage) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestRunWithNamedPlanScopesStorage(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	t.Setenv(plan.PlanEnvVar, "")
+
+	if err := Run([]string{"init"}); err != nil {
+		t.Fatalf("init default: %v", err)
+	}
+	if _, err := captureStdout(func() error { return Run([]string{"--plan", "migration", "init"}) }); err != nil {
+		t.Fatalf("init named: %v", err)
+	}
+	namedPath := filepath.Join(plan.NamedPlanDir(tempDir, "migration"), plan.DefaultPlanFilename)
+	if _, err := os.Stat(namedPath); err != nil {
+		t.Fatalf("named plan not created: %v", err)
+	}
+	if got := os.Getenv(plan.PlanEnvVar); got != "migration" {
+		t.Fatalf("%s = %q, want migration", plan.PlanEnvVar, got)
+	}
+
+	if _, err := captureStdout(func() error {
+		return Run([]string{"--plan", "migration", "add", "--id", "m1", "--title", "Move tables"})
+	}); err != nil {
+		t.Fatalf("add to named plan: %v", err)
+	}
+	named, err := plan.Load(namedPath)
+	if err != nil || len(named.Items) != 1 {
+		t.Fatalf("named plan = %+v, %v", named.Items, err)
+	}
+	root, err := plan.Load(filepath.Join(tempDir, plan.DefaultPlanFilename))
+	if err != nil || len(root.Items) != 0 {
+		t.Fatalf("default plan = %+v, %v", root.Items, err)
+	}
+
+	// Runs of the named plan are stored beside it, not with the default plan.
+	record := execution.RunRecord{ID: "run-1", TaskID: "m1", Status: execution.RunStatusSuccess, StartedAt: time.Now().UTC()}
+	record.Context = execution.ContextPack{SchemaVersion: execution.ContextPackSchemaVersion, Task: execution.TaskContext{ID: "m1"}}
+	if err := execution.SaveRun(filepath.Dir(plan.PlanPath()), record); err != nil {
+		t.Fatalf("save run: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(plan.NamedPlanDir(tempDir, "migration"), ".blackbird", "runs", "m1", "run-1.json")); err != nil {
+		t.Fatalf("run not scoped to named plan: %v", err)
+	}
+	if runs, err := execution.ListRuns(tempDir, "m1"); err != nil || len(runs) != 0 {
+		t.Fatalf("default plan runs = %v, %v", runs, err)
+	}
+
+	output, err := captureStdout(func() error { return runPlans(nil) })
+	if err != nil {
+		t.Fatalf("runPlans: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{"  (default)", "* migration"})
+
+	// A plan file next to the default plan would share its runs and history.
+	if err := Run([]string{"--plan", "other.json", "init"}); err == nil || !strings.Contains(err.Error(), "shares its directory") {
+		t.Fatalf("init other.json next to default plan: %v", err)
+	}
+}
+
+func TestShowListsExternalDeps(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	t.Setenv(plan.PlanEnvVar, "")
+
+	now := time.Now().UTC()
+	api := newWorkItem("api", now)
+	api.Title = "Build API"
+	api.Status = plan.StatusDone
+	backend := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": api}}
+	if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(tempDir, "backend"), plan.DefaultPlanFilename), backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+	web := newWorkItem("web", now)
+	web.Deps = []string{"plan:backend#api"}
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"web": web}}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runShow("web") })
+	if err != nil {
+		t.Fatalf("runShow: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{
+		"Deps:\n- (none)",
+		"External deps:\n- plan:backend#api [done] Build API",
+		"- deps satisfied: yes",
+		"- actionable now: true",
+	})
+	loaded, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if ready := execution.ReadyTasks(loaded); len(ready) != 1 || ready[0] != "web" {
+		t.Fatalf("ReadyTasks = %v, want [web]", ready)
+	}
+
+	if _, err := captureStdout(func() error { return runDepsAdd("web", "plan:backend#missing") }); err == nil {
+		t.Fatalf("expected deps add to reject an unknown external item")
+	}
+}
+
+func TestDanglingExternalDepCanBeRemoved(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	t.Setenv(plan.PlanEnvVar, "")
+
+	now := time.Now().UTC()
+	for name, id := range map[string]string{"backend": "api", "frontend": "ui"} {
+		g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{id: newWorkItem(id, now)}}
+		if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(tempDir, name), plan.DefaultPlanFilename), g); err != nil {
+			t.Fatalf("save %s plan: %v", name, err)
+		}
+	}
+	run := func(args ...string) (string, error) {
+		return captureStdout(func() error { return Run(args) })
+	}
+
+	if out, err := run("--plan", "frontend", "deps", "add", "ui", "plan:backend#api"); err != nil {
+		t.Fatalf("deps add: %v\n%s", err, out)
+	}
+	if out, err := run("--plan", "backend", "delete", "api", "--force", "--yes"); err != nil {
+		t.Fatalf("delete: %v\n%s", err, out)
+	}
+
+	// The dangling dep does not stop the frontend plan from loading...
+	if out, err := run("--plan", "frontend", "list", "--all"); err != nil {
+		t.Fatalf("list: %v\n%s", err, out)
+	}
+	out, err := run("--plan", "frontend", "validate")
+	if err == nil || !strings.Contains(out, `unknown dep id "api" in plan "backend"`) {
+		t.Fatalf("validate = %v\n%s, want the dangling dep reported", err, out)
+	}
+	// ...nor from being repaired, but it cannot be added again.
+	if _, err := run("--plan", "frontend", "deps", "add", "ui", "plan:backend#api"); err == nil {
+		t.Fatalf("expected deps add to reject the deleted item")
+	}
+	if out, err := run("--plan", "frontend", "deps", "remove", "ui", "plan:backend#api"); err != nil {
+		t.Fatalf("deps remove: %v\n%s", err, out)
+	}
+	if out, err := run("--plan", "frontend", "validate"); err != nil {
+		t.Fatalf("validate after removal: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/config"
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
+)
+
+type questionsJSON struct {
+	SchemaVersion int                          `json:"schemaVersion"`
+	Pending       []execution.PendingQuestions `json:"pending"`
+}
+
+func runQuestions(args []string) error {
+	if len(args) > 0 && args[0] == "answer" {
+		return runQuestionsAnswer(args[1:])
+	}
+	if len(args) > 0 && args[0] == "list" {
+		args = args[1:]
+	}
+
+	fs := flag.NewFlagSet("questions", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: fmt.Sprintf("unknown questions subcommand: %q", fs.Arg(0))}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	path := plan.PlanPath()
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+	pending, err := execution.ListPendingQuestions(path, g)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		return writeJSON(os.Stdout, questionsJSON{SchemaVersion: OutputSchemaVersion, Pending: pending})
+	}
+	if len(pending) == 0 {
+		fmt.Fprintln(os.Stdout, "no pending questions")
+		return nil
+	}
+	now := time.Now()
+	for i, p := range pending {
+		if i > 0 {
+			fmt.Fprintln(os.Stdout)
+		}
+		printPendingQuestions(os.Stdout, p, now)
+	}
+	return nil
+}
+
+func printPendingQuestions(w io.Writer, p execution.PendingQuestions, now time.Time) {
+	fmt.Fprintf(w, "%s  %s  (asked %s ago, run %s)\n", p.TaskID, p.TaskTitle, formatAge(now.Sub(p.AskedAt)), p.RunID)
+	for _, q := range p.Questions {
+		fmt.Fprintf(w, "  [%s] %s\n", q.ID, q.Prompt)
+		for i, opt := range q.Options {
+			fmt.Fprintf(w, "      %d) %s\n", i+1, opt)
+		}
+	}
+}
+
+// formatAge renders a coarse duration such as 45s, 12m, 3h or 2d.
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(max(d, 0)/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
+
+// runQuestionsAnswer prompts for every pending question set (or only the given
+// tasks), then resumes the answered tasks one after another.
+func runQuestionsAnswer(taskIDs []string) error {
+	path := plan.PlanPath()
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+	pending, err := execution.ListPendingQuestions(path, g)
+	if err != nil {
+		return err
+	}
+	if len(taskIDs) > 0 {
+		byID := map[string]execution.PendingQuestions{}
+		for _, p := range pending {
+			byID[p.TaskID] = p
+		}
+		selected := make([]execution.PendingQuestions, 0, len(taskIDs))
+		for _, id := range taskIDs {
+			p, ok := byID[id]
+			if !ok {
+				return fmt.Errorf("%s has no pending questions", id)
+			}
+			selected = append(selected, p)
+		}
+		pending = selected
+	}
+	if len(pending) == 0 {
+		fmt.Fprintln(os.Stdout, "no pending questions")
+		return nil
+	}
+
+	now := time.Now()
+	var sets []execution.AnswerSet
+	for _, p := range pending {
+		printPendingQuestions(os.Stdout, p, now)
+		if len(pending) > 1 {
+			line, err := promptLine(fmt.Sprintf("Answer %s now? [Y/n]", p.TaskID))
+			if err != nil {
+				return err
+			}
+			if v := strings.ToLower(strings.TrimSpace(line)); v == "n" || v == "no" {
+				fmt.Fprintln(os.Stdout)
+				continue
+			}
+		}
+		answers, err := promptAnswers(p.Questions)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, execution.AnswerSet{TaskID: p.TaskID, Answers: answers})
+		fmt.Fprintln(os.Stdout)
+	}
+	if len(sets) == 0 {
+		fmt.Fprintln(os.Stdout, "nothing answered")
+		return nil
+	}
+
+	return resumeAnswerSets(path, sets)
+}
+
+func resumeAnswerSets(path string, sets []execution.AnswerSet) error {
+	runtime, err := agent.NewRuntimeFromEnv()
+	if err != nil {
+		return err
+	}
+	baseDir := filepath.Dir(path)
+	cfg, err := config.LoadConfig(plan.ProjectRoot(path))
+	if err != nil {
+		cfg = config.DefaultResolvedConfig()
+	}
+	webhooks := webhook.New(cfg.Webhooks, baseDir)
+	defer webhooks.Wait()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	resumeCfg := execution.ResumeConfig{
+		PlanPath: path,
+		Runtime:  runtime,
+		Hooks:    execution.HooksFromConfig(cfg.Hooks),
+		Webhooks: webhooks,
+		OnTaskStart: func(taskID string) {
+			fmt.Fprintf(os.Stdout, "resuming %s\n", taskID)
+		},
+	}
+	err = execution.ResumeAnswered(ctx, resumeCfg, sets, func(taskID string, record execution.RunRecord, runErr error) {
+		switch {
+		case runErr != nil && record.ID == "":
+			fmt.Fprintf(os.Stdout, "failed %s: %v\n", taskID, runErr)
+		case record.Status == execution.RunStatusSuccess:
+			fmt.Fprintf(os.Stdout, "completed %s\n", taskID)
+		case record.Status == execution.RunStatusWaitingUser:
+			fmt.Fprintf(os.Stdout, "%s is waiting for user input\n", taskID)
+		case record.Error != "":
+			fmt.Fprintf(os.Stdout, "failed %s: %s\n", taskID, record.Error)
+		default:
+			fmt.Fprintf(os.Stdout, "failed %s\n", taskID)
+		}
+	})
+	if ctx.Err() != nil {
+		fmt.Fprintln(os.Stdout, "resume interrupted")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return continueAfterAnswers(ctx, path, runtime, cfg, webhooks)
+}
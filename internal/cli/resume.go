@@ -9,8 +9,10 @@ import (
 	"syscall"
 
 	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/config"
 	"github.com/jbonatakis/blackbird/internal/execution"
 	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
 )
 
 func runResume(taskID string) error {
@@ -42,11 +44,19 @@ func runResume(taskID string) error {
 	}
 	hasPendingParentFeedback := resolvedFeedback.Source == execution.ResumeFeedbackSourcePendingParentReview
 
+	cfg, err := config.LoadConfig(plan.ProjectRoot(path))
+	if err != nil {
+		cfg = config.DefaultResolvedConfig()
+	}
+
 	resumeCfg := execution.ResumeConfig{
 		PlanPath: path,
 		TaskID:   taskID,
 		Runtime:  runtime,
+		Hooks:    execution.HooksFromConfig(cfg.Hooks),
+		Webhooks: webhook.New(cfg.Webhooks, baseDir),
 	}
+	defer resumeCfg.Webhooks.Wait()
 
 	if !hasPendingParentFeedback {
 		runs, err := execution.ListRuns(baseDir, taskID)
@@ -64,6 +74,9 @@ func runResume(taskID string) error {
 			fmt.Fprintf(os.Stdout, "no waiting runs for %s\n", taskID)
 			return nil
 		}
+		if *waiting, err = execution.LoadRunOutput(baseDir, *waiting); err != nil {
+			return err
+		}
 
 		questions, err := execution.ParseQuestions(waiting.Stdout)
 		if err != nil {
@@ -107,5 +120,22 @@ func runResume(taskID string) error {
 		return fmt.Errorf("unexpected run status %q", record.Status)
 	}
 
-	return nil
+	if hasPendingParentFeedback {
+		return nil
+	}
+	return continueAfterAnswers(ctx, path, runtime, cfg, resumeCfg.Webhooks)
+}
+
+// continueAfterAnswers resumes execution once answered tasks have run, when
+// execution.autoContinue is on.
+func continueAfterAnswers(ctx context.Context, path string, runtime agent.Runtime, cfg config.ResolvedConfig, webhooks *webhook.Dispatcher) error {
+	if !cfg.Execution.AutoContinue || ctx.Err() != nil {
+		return nil
+	}
+	controller := newExecutionController(path, runtime, cfg, webhooks)
+	result, err := controller.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	return handleExecuteResult(ctx, controller, result)
 }
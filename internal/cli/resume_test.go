@@ -89,9 +89,9 @@ func TestRunResumePromptsWaitingQuestionWhenNoPendingParentFeedback(t *testing.T
 	}
 
 	runsDir := filepath.Join(tempDir, ".blackbird", "runs", "task")
-	entries, err := os.ReadDir(runsDir)
+	entries, err := filepath.Glob(filepath.Join(runsDir, "*.json"))
 	if err != nil {
-		t.Fatalf("read runs dir: %v", err)
+		t.Fatalf("list run records: %v", err)
 	}
 	if len(entries) != 2 {
 		t.Fatalf("expected 2 run records, got %d", len(entries))
@@ -217,9 +217,9 @@ func TestRunResumeUsesPendingParentFeedbackWithoutPromptingForAnswers(t *testing
 	}
 
 	runsDir := filepath.Join(tempDir, ".blackbird", "runs", "task")
-	entries, err := os.ReadDir(runsDir)
+	entries, err := filepath.Glob(filepath.Join(runsDir, "*.json"))
 	if err != nil {
-		t.Fatalf("read runs dir: %v", err)
+		t.Fatalf("list run records: %v", err)
 	}
 	if len(entries) != 3 {
 		t.Fatalf("expected 3 run records, got %d", len(entries))
@@ -6,6 +6,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
@@ -18,15 +20,20 @@ func runRuns(args []string) error {
 	fs.SetOutput(io.Discard)
 
 	verbose := fs.Bool("verbose", false, "show full stdout/stderr")
+	out := addOutputFlags(fs)
 
-	if err := fs.Parse(args); err != nil {
-		return UsageError{Message: err.Error()}
+	taskID, err := parseWithPositional(fs, args)
+	if err != nil {
+		return err
 	}
-	if fs.NArg() != 1 {
+	if taskID == "" {
 		return UsageError{Message: "runs requires exactly 1 argument: <taskID>"}
 	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
 
-	taskID := fs.Arg(0)
 	path := plan.PlanPath()
 	g, err := loadValidatedPlan(path)
 	if err != nil {
@@ -41,6 +48,20 @@ func runRuns(args []string) error {
 	if err != nil {
 		return err
 	}
+	if *verbose {
+		for i := range records {
+			if records[i], err = execution.LoadRunOutput(baseDir, records[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if asJSON {
+		runs := make([]runSummaryJSON, 0, len(records))
+		for _, record := range records {
+			runs = append(runs, newRunSummaryJSON(record, *verbose))
+		}
+		return writeJSON(os.Stdout, runsJSON{SchemaVersion: OutputSchemaVersion, TaskID: taskID, Runs: runs})
+	}
 	if len(records) == 0 {
 		fmt.Fprintf(os.Stdout, "no runs found for %s\n", taskID)
 		return nil
@@ -94,3 +115,120 @@ func formatRunDuration(record execution.RunRecord) string {
 	}
 	return duration.Truncate(time.Second).String()
 }
+
+type runsGCJSON struct {
+	SchemaVersion int                `json:"schemaVersion"`
+	DryRun        bool               `json:"dryRun"`
+	Result        execution.GCResult `json:"result"`
+}
+
+// runGC removes (or archives) old run records and, with --orphans, the run
+// directories of tasks no longer in the plan.
+func runGC(args []string) error {
+	fs := flag.NewFlagSet("gc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	keep := fs.Int("keep", 10, "number of newest runs to keep per task")
+	olderThan := fs.String("older-than", "", "only remove runs older than this (e.g. 30d, 12h)")
+	archive := fs.Bool("archive", false, "compress removed runs into .blackbird/archive before deleting")
+	dryRun := fs.Bool("dry-run", false, "report what would be removed without deleting")
+	orphans := fs.Bool("orphans", false, "also remove run directories of tasks not in the plan")
+	out := addOutputFlags(fs)
+
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "gc takes only flags (no positional args)"}
+	}
+	if *keep < 0 {
+		return UsageError{Message: "--keep must not be negative"}
+	}
+	var age time.Duration
+	if strings.TrimSpace(*olderThan) != "" {
+		d, err := parseAge(*olderThan)
+		if err != nil {
+			return UsageError{Message: err.Error()}
+		}
+		age = d
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	path := plan.PlanPath()
+	g, err := loadValidatedPlan(path)
+	if err != nil {
+		return err
+	}
+	baseDir := filepath.Dir(path)
+
+	var orphanIDs []string
+	if *orphans {
+		taskIDs, err := execution.RunDirTaskIDs(baseDir)
+		if err != nil {
+			return err
+		}
+		for _, id := range taskIDs {
+			if _, ok := g.Items[id]; !ok {
+				orphanIDs = append(orphanIDs, id)
+			}
+		}
+	}
+
+	result, err := execution.GCRuns(baseDir, execution.GCOptions{
+		Keep:      *keep,
+		OlderThan: age,
+		Archive:   *archive,
+		DryRun:    *dryRun,
+		Orphans:   orphanIDs,
+	})
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		return writeJSON(os.Stdout, runsGCJSON{SchemaVersion: OutputSchemaVersion, DryRun: *dryRun, Result: result})
+	}
+	verb := "removed"
+	if *dryRun {
+		verb = "would remove"
+	}
+	if len(result.Orphans) > 0 {
+		fmt.Fprintf(os.Stdout, "%s run history for %d deleted task(s) (%s): %s\n", verb, len(result.Orphans), formatBytes(result.OrphanBytes), strings.Join(result.Orphans, ", "))
+	}
+	fmt.Fprintf(os.Stdout, "%s %d run(s) (%s), kept %d\n", verb, len(result.Removed), formatBytes(result.Bytes), result.Kept)
+	if result.ArchivePath != "" {
+		fmt.Fprintf(os.Stdout, "archived to %s\n", result.ArchivePath)
+	}
+	return nil
+}
+
+// parseAge accepts Go durations plus a day suffix, e.g. 30d.
+func parseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid --older-than %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid --older-than %q", s)
+	}
+	return d, nil
+}
+
+func formatBytes(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(n)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
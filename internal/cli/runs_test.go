@@ -141,3 +141,95 @@ func TestRunRunsNoRuns(t *testing.T) {
 		t.Fatalf("expected no runs message, got %q", output)
 	}
 }
+
+func TestRunRunsListsTaskNamedGC(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+
+	now := time.Date(2026, 1, 28, 17, 0, 0, 0, time.UTC)
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"gc": newWorkItem("gc", now)}}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	if err := execution.SaveRun(tempDir, execution.RunRecord{
+		ID:        "run-gc",
+		TaskID:    "gc",
+		StartedAt: now,
+		Status:    execution.RunStatusSuccess,
+		Context:   execution.ContextPack{SchemaVersion: execution.ContextPackSchemaVersion, Task: execution.TaskContext{ID: "gc"}},
+	}); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return Run([]string{"runs", "gc"}) })
+	if err != nil {
+		t.Fatalf("runs gc: %v", err)
+	}
+	if !strings.Contains(output, "run-gc") {
+		t.Fatalf("expected the task's runs, got %q", output)
+	}
+}
+
+func TestRunGCRemovesOldAndOrphanedRuns(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	now := time.Now().UTC()
+	g := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items: map[string]plan.WorkItem{
+			"task-1": newWorkItem("task-1", now),
+		},
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	save := func(taskID, runID string, startedAt time.Time) {
+		t.Helper()
+		record := execution.RunRecord{
+			ID:        runID,
+			TaskID:    taskID,
+			StartedAt: startedAt,
+			Status:    execution.RunStatusSuccess,
+			Context: execution.ContextPack{
+				SchemaVersion: execution.ContextPackSchemaVersion,
+				Task:          execution.TaskContext{ID: taskID, Title: "Task"},
+			},
+		}
+		if err := execution.SaveRun(tempDir, record); err != nil {
+			t.Fatalf("SaveRun: %v", err)
+		}
+	}
+	save("task-1", "old", now.Add(-60*24*time.Hour))
+	save("task-1", "new", now.Add(-time.Hour))
+	save("gone", "run-1", now)
+
+	output, err := captureStdout(func() error {
+		return runGC([]string{"--keep", "1", "--older-than", "30d", "--orphans"})
+	})
+	if err != nil {
+		t.Fatalf("runGC: %v", err)
+	}
+	if !strings.Contains(output, "removed run history for 1 deleted task(s) (") || !strings.Contains(output, "): gone\n") || !strings.Contains(output, "removed 1 run(s)") {
+		t.Fatalf("unexpected output: %q", output)
+	}
+	records, err := execution.ListRuns(tempDir, "task-1")
+	if err != nil || len(records) != 1 || records[0].ID != "new" {
+		t.Fatalf("remaining runs = %+v, %v", records, err)
+	}
+	if ids, _ := execution.RunDirTaskIDs(tempDir); len(ids) != 1 || ids[0] != "task-1" {
+		t.Fatalf("run dirs = %v", ids)
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	if d, err := parseAge("30d"); err != nil || d != 30*24*time.Hour {
+		t.Fatalf("parseAge(30d) = %v, %v", d, err)
+	}
+	if d, err := parseAge("12h"); err != nil || d != 12*time.Hour {
+		t.Fatalf("parseAge(12h) = %v, %v", d, err)
+	}
+	if _, err := parseAge("soon"); err == nil {
+		t.Fatalf("expected error for invalid age")
+	}
+}
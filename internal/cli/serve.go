@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/config"
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/server"
+	"github.com/jbonatakis/blackbird/internal/webhook"
+)
+
+const defaultServeAddr = "127.0.0.1:8765"
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	addr := fs.String("addr", defaultServeAddr, "listen address")
+
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "serve takes only flags (no positional args)"}
+	}
+
+	path := plan.PlanPath()
+	if _, err := loadValidatedPlan(path); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadConfig(plan.ProjectRoot(path))
+	if err != nil {
+		cfg = config.DefaultResolvedConfig()
+	}
+
+	token, err := server.NewToken()
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	srv := server.New(server.Config{
+		PlanPath:            path,
+		Addr:                ln.Addr().String(),
+		Token:               token,
+		StopAfterEachTask:   cfg.Execution.StopAfterEachTask,
+		ParentReviewEnabled: cfg.Execution.ParentReviewEnabled,
+		AutoContinue:        cfg.Execution.AutoContinue,
+		Hooks:               execution.HooksFromConfig(cfg.Hooks),
+		Webhooks:            webhook.New(cfg.Webhooks, filepath.Dir(path)),
+	})
+
+	if !server.IsLoopback(*addr) {
+		fmt.Fprintln(os.Stdout, "warning: listening beyond loopback; anyone who can reach this address and holds the token can run agents")
+	}
+	fmt.Fprintf(os.Stdout, "serving %s on http://%s\n", path, ln.Addr())
+	fmt.Fprintf(os.Stdout, "token: %s (send as Authorization: Bearer <token>)\n", token)
+
+	httpServer := &http.Server{Handler: srv.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- httpServer.Serve(ln) }()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	// SSE connections never go idle, so close them rather than wait.
+	_ = httpServer.Close()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stdout, "server stopped")
+	return nil
+}
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+type templateListJSON struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Dirs          []string       `json:"dirs"`
+	Templates     []templateJSON `json:"templates"`
+}
+
+type templateJSON struct {
+	Name        string              `json:"name"`
+	Path        string              `json:"path"`
+	Description string              `json:"description,omitempty"`
+	Params      []templateParamJSON `json:"params"`
+	Items       int                 `json:"items"`
+}
+
+type templateParamJSON struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+// addFromTemplate instantiates a template for `add --template` and saves the plan.
+func addFromTemplate(path string, g plan.WorkGraph, name string, rawParams []string, parentID *string, index *int) error {
+	values := map[string]string{}
+	for _, raw := range rawParams {
+		k, v, ok := strings.Cut(raw, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return UsageError{Message: fmt.Sprintf("--param must be name=value, got %q", raw)}
+		}
+		values[strings.TrimSpace(k)] = v
+	}
+
+	tmpl, err := plan.FindTemplate(name, plan.TemplateDirs(path)...)
+	if err != nil {
+		return err
+	}
+	ids, err := plan.InstantiateTemplate(&g, tmpl, values, parentID, index, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if errs := plan.Validate(g); len(errs) != 0 {
+		printValidationErrors(os.Stdout, path, errs)
+		return errors.New("validation failed")
+	}
+	if err := plan.SaveWithHistory(path, g, "add --template "+tmpl.Name); err != nil {
+		return fmt.Errorf("write plan file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stdout, "added %d item(s) from template %s\n", len(ids), tmpl.Name)
+	for _, id := range ids {
+		fmt.Fprintf(os.Stdout, "  %s\n", id)
+	}
+	return nil
+}
+
+func runTemplates(args []string) error {
+	fs := flag.NewFlagSet("templates", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	out := addOutputFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return UsageError{Message: err.Error()}
+	}
+	if fs.NArg() != 0 {
+		return UsageError{Message: "templates takes only flags (no positional args)"}
+	}
+	asJSON, err := out.JSON()
+	if err != nil {
+		return err
+	}
+
+	dirs := plan.TemplateDirs(plan.PlanPath())
+	templates, err := plan.LoadTemplates(dirs...)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		doc := templateListJSON{SchemaVersion: OutputSchemaVersion, Dirs: dirs, Templates: []templateJSON{}}
+		for _, t := range templates {
+			entry := templateJSON{Name: t.Name, Path: t.Path, Description: t.Description, Params: []templateParamJSON{}, Items: countTemplateItems(t.Items)}
+			for _, p := range t.Params {
+				entry.Params = append(entry.Params, templateParamJSON{Name: p.Name, Description: p.Description, Default: p.Default})
+			}
+			doc.Templates = append(doc.Templates, entry)
+		}
+		return writeJSON(os.Stdout, doc)
+	}
+
+	if len(templates) == 0 {
+		fmt.Fprintf(os.Stdout, "no templates (looked in %s)\n", strings.Join(dirs, ", "))
+		return nil
+	}
+	for _, t := range templates {
+		line := t.Name
+		if t.Description != "" {
+			line += ": " + t.Description
+		}
+		fmt.Fprintln(os.Stdout, line)
+		for _, p := range t.Params {
+			param := "  --param " + p.Name + "="
+			if p.Default != nil {
+				param += fmt.Sprintf(" (default %q)", *p.Default)
+			} else {
+				param += " (required)"
+			}
+			if p.Description != "" {
+				param += " " + p.Description
+			}
+			fmt.Fprintln(os.Stdout, param)
+		}
+	}
+	return nil
+}
+
+func countTemplateItems(items []plan.TemplateItem) int {
+	n := len(items)
+	for _, it := range items {
+		n += countTemplateItems(it.Children)
+	}
+	return n
+}
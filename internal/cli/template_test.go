@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestRunAddFromTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	t.Setenv("HOME", t.TempDir())
+
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": newWorkItem("api", now)}}
+	if err := plan.SaveAtomic(plan.PlanPath(), g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	dir := filepath.Join(tempDir, filepath.FromSlash(plan.TemplatesDirName))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	template := `{
+  "description": "DB migration",
+  "params": [{"name": "table", "description": "table to change"}],
+  "items": [
+    {"id": "migrate-{{table}}", "title": "Migrate {{table}}", "children": [
+      {"id": "migrate-{{table}}-up", "title": "Write up migration"},
+      {"id": "migrate-{{table}}-backfill", "title": "Backfill {{table}}", "deps": ["migrate-{{table}}-up"]}
+    ]}
+  ]
+}`
+	if err := os.WriteFile(filepath.Join(dir, "migration.json"), []byte(template), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	output, err := captureStdout(func() error { return runTemplates(nil) })
+	if err != nil {
+		t.Fatalf("runTemplates: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{"migration: DB migration", "--param table= (required) table to change"})
+
+	output, err = captureStdout(func() error {
+		return runAdd([]string{"--template", "migration", "--param", "table=users", "--parent", "api"})
+	})
+	if err != nil {
+		t.Fatalf("runAdd --template: %v", err)
+	}
+	assertOutputContainsInOrder(t, output, []string{"added 3 item(s) from template migration", "migrate-users", "migrate-users-up", "migrate-users-backfill"})
+
+	saved, err := plan.Load(plan.PlanPath())
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if got := saved.Items["api"].ChildIDs; !reflect.DeepEqual(got, []string{"migrate-users"}) {
+		t.Fatalf("api children = %v", got)
+	}
+	if got := saved.Items["migrate-users-backfill"]; got.Title != "Backfill users" || !reflect.DeepEqual(got.Deps, []string{"migrate-users-up"}) {
+		t.Fatalf("backfill = %+v", got)
+	}
+
+	var usage UsageError
+	if err := runAdd([]string{"--template", "migration", "--title", "x"}); !errors.As(err, &usage) {
+		t.Fatalf("expected usage error for --title with --template, got %v", err)
+	}
+	if err := runAdd([]string{"--template", "migration"}); err == nil {
+		t.Fatalf("expected missing param error")
+	}
+}
+
+func TestRunAddFromTemplateRejectsUnknownExternalDep(t *testing.T) {
+	tempDir := t.TempDir()
+	chdirForTest(t, tempDir)
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(plan.PlanEnvVar, "")
+
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	backend := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": newWorkItem("api", now)}}
+	if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(tempDir, "backend"), plan.DefaultPlanFilename), backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+	if err := plan.SaveAtomic(plan.PlanPath(), plan.NewEmptyWorkGraph()); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	dir := filepath.Join(tempDir, filepath.FromSlash(plan.TemplatesDirName))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	template := `{"params": [{"name": "dep"}], "items": [{"id": "client", "title": "Client", "deps": ["plan:backend#{{dep}}"]}]}`
+	if err := os.WriteFile(filepath.Join(dir, "client.json"), []byte(template), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	_, err := captureStdout(func() error { return runAdd([]string{"--template", "client", "--param", "dep=missing"}) })
+	if err == nil || !strings.Contains(err.Error(), `unknown dep id "missing" in plan "backend"`) {
+		t.Fatalf("runAdd --template = %v, want the unknown external item rejected", err)
+	}
+	if output, err := captureStdout(func() error { return runAdd([]string{"--template", "client", "--param", "dep=api"}) }); err != nil {
+		t.Fatalf("runAdd --template: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/jbonatakis/blackbird/internal/config"
+	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
+)
+
+func runWebhooks(args []string) error {
+	if len(args) != 1 || args[0] != "test" {
+		return UsageError{Message: "usage: blackbird webhooks test"}
+	}
+
+	path := plan.PlanPath()
+	baseDir := filepath.Dir(path)
+	cfg, err := config.LoadConfig(plan.ProjectRoot(path))
+	if err != nil {
+		return err
+	}
+	dispatcher := webhook.New(cfg.Webhooks, baseDir)
+	if dispatcher == nil {
+		return errors.New("no webhook endpoints configured")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := dispatcher.Send(ctx, webhook.Event{Type: webhook.EventTest, PlanPath: path}); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stdout, "delivered test event to %d endpoint(s)\n", len(dispatcher.Endpoints))
+	return nil
+}
@@ -63,6 +63,36 @@ func OptionRegistry() []OptionMetadata {
 			defaults.Execution.ParentReviewEnabled,
 			"Run parent-review checks after successful child tasks",
 		),
+		newBoolOption(
+			"execution.autoContinue",
+			"Execution Auto-Continue",
+			defaults.Execution.AutoContinue,
+			"Keep executing past waiting tasks and after answers",
+		),
+		newIntOption(
+			"agent.executionTimeoutSeconds",
+			"Agent Execution Timeout (seconds)",
+			defaults.Agent.ExecutionTimeoutSeconds,
+			MinAgentTimeoutSeconds,
+			MaxAgentTimeoutSeconds,
+			"Time limit for each task run attempt",
+		),
+		newIntOption(
+			"agent.planTimeoutSeconds",
+			"Agent Plan Timeout (seconds)",
+			defaults.Agent.PlanTimeoutSeconds,
+			MinAgentTimeoutSeconds,
+			MaxAgentTimeoutSeconds,
+			"Time limit for each plan request attempt",
+		),
+		newIntOption(
+			"agent.maxRetries",
+			"Agent Max Retries",
+			defaults.Agent.MaxRetries,
+			MinAgentMaxRetries,
+			MaxAgentMaxRetries,
+			"Retries after transient agent failures",
+		),
 	}
 }
 
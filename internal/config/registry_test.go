@@ -5,8 +5,8 @@ import "testing"
 func TestOptionRegistryIncludesKnownOptions(t *testing.T) {
 	defaults := DefaultResolvedConfig()
 	options := OptionRegistry()
-	if len(options) != 5 {
-		t.Fatalf("options count = %d, want 5", len(options))
+	if len(options) != 9 {
+		t.Fatalf("options count = %d, want 9", len(options))
 	}
 
 	byKey := map[string]OptionMetadata{}
@@ -117,6 +117,23 @@ func TestOptionRegistryIncludesKnownOptions(t *testing.T) {
 			"Run parent-review checks after successful child tasks",
 		)
 	}
+
+	autoContinue := requireOption(t, byKey, "execution.autoContinue")
+	if autoContinue.Type != OptionTypeBool || autoContinue.DefaultBool != defaults.Execution.AutoContinue {
+		t.Fatalf("auto-continue option = %+v", autoContinue)
+	}
+
+	retries := requireOption(t, byKey, "agent.maxRetries")
+	if retries.Type != OptionTypeInt || retries.DefaultInt != defaults.Agent.MaxRetries || retries.Bounds == nil ||
+		retries.Bounds.Min != MinAgentMaxRetries || retries.Bounds.Max != MaxAgentMaxRetries {
+		t.Fatalf("max retries option = %+v", retries)
+	}
+	for _, key := range []string{"agent.executionTimeoutSeconds", "agent.planTimeoutSeconds"} {
+		timeout := requireOption(t, byKey, key)
+		if timeout.DefaultInt != DefaultAgentTimeoutSeconds || timeout.Bounds.Min != MinAgentTimeoutSeconds {
+			t.Fatalf("%s option = %+v", key, timeout)
+		}
+	}
 }
 
 func requireOption(t *testing.T, options map[string]OptionMetadata, key string) OptionMetadata {
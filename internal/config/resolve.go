@@ -1,5 +1,7 @@
 package config
 
+import "strings"
+
 // ResolveConfig merges project/global configs with built-in defaults.
 // Precedence per key: project > global > defaults, then clamp intervals to bounds.
 func ResolveConfig(project RawConfig, global RawConfig) ResolvedConfig {
@@ -30,6 +32,30 @@ func ResolveConfig(project RawConfig, global RawConfig) ResolvedConfig {
 		valueFromRawExecution(global, func(exec RawExecution) *bool { return exec.ParentReviewEnabled }),
 		defaults.Execution.ParentReviewEnabled,
 	)
+	autoContinue := resolveBool(
+		valueFromRawExecution(project, func(exec RawExecution) *bool { return exec.AutoContinue }),
+		valueFromRawExecution(global, func(exec RawExecution) *bool { return exec.AutoContinue }),
+		defaults.Execution.AutoContinue,
+	)
+	agentInt := func(pick func(RawAgent) *int, defaultVal, minVal, maxVal int) int {
+		value := defaultVal
+		if v := valueFromRawAgent(project, pick); v != nil {
+			value = *v
+		} else if v := valueFromRawAgent(global, pick); v != nil {
+			value = *v
+		}
+		return min(max(value, minVal), maxVal)
+	}
+	hook := func(pick func(RawHooks) *string) string {
+		return resolveString(valueFromRawHooks(project, pick), valueFromRawHooks(global, pick))
+	}
+	hookTimeout := resolveHookTimeout(
+		valueFromRawHooks(project, func(h RawHooks) *int { return h.TimeoutSeconds }),
+		valueFromRawHooks(global, func(h RawHooks) *int { return h.TimeoutSeconds }),
+		defaults.Hooks.TimeoutSeconds,
+	)
+
+	webhooks := resolveWebhooks(project.Webhooks, global.Webhooks, defaults.Webhooks)
 
 	return ResolvedConfig{
 		SchemaVersion: SchemaVersion,
@@ -39,11 +65,31 @@ func ResolveConfig(project RawConfig, global RawConfig) ResolvedConfig {
 		},
 		Planning: ResolvedPlanning{
 			MaxPlanAutoRefinePasses: maxPlanAutoRefinePasses,
+			Quality:                 resolvePlanQuality(project.Planning, global.Planning, defaults.Planning.Quality),
 		},
 		Execution: ResolvedExecution{
 			StopAfterEachTask:   stopAfterEachTask,
 			ParentReviewEnabled: parentReviewEnabled,
+			AutoContinue:        autoContinue,
 		},
+		Agent: ResolvedAgent{
+			ExecutionTimeoutSeconds: agentInt(func(a RawAgent) *int { return a.ExecutionTimeoutSeconds },
+				defaults.Agent.ExecutionTimeoutSeconds, MinAgentTimeoutSeconds, MaxAgentTimeoutSeconds),
+			PlanTimeoutSeconds: agentInt(func(a RawAgent) *int { return a.PlanTimeoutSeconds },
+				defaults.Agent.PlanTimeoutSeconds, MinAgentTimeoutSeconds, MaxAgentTimeoutSeconds),
+			MaxRetries: agentInt(func(a RawAgent) *int { return a.MaxRetries },
+				defaults.Agent.MaxRetries, MinAgentMaxRetries, MaxAgentMaxRetries),
+		},
+		Hooks: ResolvedHooks{
+			PreTask:              hook(func(h RawHooks) *string { return h.PreTask }),
+			PostTask:             hook(func(h RawHooks) *string { return h.PostTask }),
+			OnWaitingUser:        hook(func(h RawHooks) *string { return h.OnWaitingUser }),
+			OnFailed:             hook(func(h RawHooks) *string { return h.OnFailed }),
+			OnParentReviewFailed: hook(func(h RawHooks) *string { return h.OnParentReviewFailed }),
+			OnExecuteComplete:    hook(func(h RawHooks) *string { return h.OnExecuteComplete }),
+			TimeoutSeconds:       hookTimeout,
+		},
+		Webhooks: webhooks,
 	}
 }
 
@@ -61,6 +107,13 @@ func valueFromRawExecution(cfg RawConfig, pick func(RawExecution) *bool) *bool {
 	return pick(*cfg.Execution)
 }
 
+func valueFromRawAgent(cfg RawConfig, pick func(RawAgent) *int) *int {
+	if cfg.Agent == nil {
+		return nil
+	}
+	return pick(*cfg.Agent)
+}
+
 func valueFromRawPlanning(cfg RawConfig, pick func(RawPlanning) *int) *int {
 	if cfg.Planning == nil {
 		return nil
@@ -68,6 +121,107 @@ func valueFromRawPlanning(cfg RawConfig, pick func(RawPlanning) *int) *int {
 	return pick(*cfg.Planning)
 }
 
+func valueFromRawHooks[T any](cfg RawConfig, pick func(RawHooks) *T) *T {
+	if cfg.Hooks == nil {
+		return nil
+	}
+	return pick(*cfg.Hooks)
+}
+
+// resolveString returns the project value, else the global value. A project
+// value of "" disables a globally configured command.
+func resolveString(projectVal *string, globalVal *string) string {
+	if projectVal != nil {
+		return *projectVal
+	}
+	if globalVal != nil {
+		return *globalVal
+	}
+	return ""
+}
+
+func resolveHookTimeout(projectVal *int, globalVal *int, defaultVal int) int {
+	value := defaultVal
+	if projectVal != nil {
+		value = *projectVal
+	} else if globalVal != nil {
+		value = *globalVal
+	}
+	if value < MinHookTimeoutSeconds {
+		return MinHookTimeoutSeconds
+	}
+	if value > MaxHookTimeoutSeconds {
+		return MaxHookTimeoutSeconds
+	}
+	return value
+}
+
+// resolveWebhooks uses the project section if present, else the global one.
+// Endpoints without a URL are dropped.
+func resolveWebhooks(project *RawWebhooks, global *RawWebhooks, defaults ResolvedWebhooks) ResolvedWebhooks {
+	section := project
+	if section == nil {
+		section = global
+	}
+	if section == nil {
+		return defaults
+	}
+	resolved := ResolvedWebhooks{Endpoints: []RawWebhookEndpoint{}, MaxAttempts: defaults.MaxAttempts}
+	for _, endpoint := range section.Endpoints {
+		if strings.TrimSpace(endpoint.URL) == "" {
+			continue
+		}
+		resolved.Endpoints = append(resolved.Endpoints, endpoint)
+	}
+	if section.MaxAttempts != nil {
+		resolved.MaxAttempts = min(max(*section.MaxAttempts, MinWebhookMaxAttempts), MaxWebhookMaxAttempts)
+	}
+	return resolved
+}
+
+// resolvePlanQuality uses the project planning.quality section if present,
+// else the global one. Thresholds are clamped to their bounds.
+func resolvePlanQuality(project *RawPlanning, global *RawPlanning, defaults ResolvedPlanQuality) ResolvedPlanQuality {
+	var section *RawPlanQuality
+	if project != nil && project.Quality != nil {
+		section = project.Quality
+	} else if global != nil {
+		section = global.Quality
+	}
+	if section == nil {
+		return defaults
+	}
+	resolved := ResolvedPlanQuality{
+		Rules:                 map[string]string{},
+		DescriptionMinWords:   defaults.DescriptionMinWords,
+		MinAcceptanceCriteria: defaults.MinAcceptanceCriteria,
+		MaxDepth:              defaults.MaxDepth,
+		OrphanLeafMinLeaves:   defaults.OrphanLeafMinLeaves,
+		Phrases:               map[string][]string{},
+		Custom:                []RawCustomQualityRule{},
+	}
+	for code, severity := range section.Rules {
+		resolved.Rules[code] = severity
+	}
+	if section.DescriptionMinWords != nil {
+		resolved.DescriptionMinWords = min(max(*section.DescriptionMinWords, 0), MaxQualityDescriptionMinWords)
+	}
+	if section.MinAcceptanceCriteria != nil {
+		resolved.MinAcceptanceCriteria = min(max(*section.MinAcceptanceCriteria, 0), MaxQualityMinAcceptanceCriteria)
+	}
+	if section.MaxDepth != nil {
+		resolved.MaxDepth = min(max(*section.MaxDepth, 0), MaxQualityMaxDepth)
+	}
+	if section.OrphanLeafMinLeaves != nil {
+		resolved.OrphanLeafMinLeaves = min(max(*section.OrphanLeafMinLeaves, 0), MaxQualityOrphanLeafMinLeaves)
+	}
+	for name, phrases := range section.Phrases {
+		resolved.Phrases[name] = append([]string{}, phrases...)
+	}
+	resolved.Custom = append(resolved.Custom, section.Custom...)
+	return resolved
+}
+
 func resolveInterval(projectVal *int, globalVal *int, defaultVal int) int {
 	if projectVal != nil {
 		return clampInterval(*projectVal)
@@ -193,6 +193,128 @@ func TestResolveConfigClampGlobalWhenProjectMissing(t *testing.T) {
 	}
 }
 
+func TestResolveConfigHooks(t *testing.T) {
+	project := RawConfig{
+		Hooks: &RawHooks{
+			PreTask:        stringPtr(""),
+			PostTask:       stringPtr("make test"),
+			TimeoutSeconds: intPtr(99999),
+		},
+	}
+	global := RawConfig{
+		Hooks: &RawHooks{
+			PreTask:           stringPtr("git diff --quiet"),
+			OnExecuteComplete: stringPtr("notify-send done"),
+			TimeoutSeconds:    intPtr(30),
+		},
+	}
+
+	resolved := ResolveConfig(project, global)
+	if resolved.Hooks.PreTask != "" {
+		t.Fatalf("preTask = %q, want project override to disable it", resolved.Hooks.PreTask)
+	}
+	if resolved.Hooks.PostTask != "make test" {
+		t.Fatalf("postTask = %q, want make test", resolved.Hooks.PostTask)
+	}
+	if resolved.Hooks.OnExecuteComplete != "notify-send done" {
+		t.Fatalf("onExecuteComplete = %q, want global value", resolved.Hooks.OnExecuteComplete)
+	}
+	if resolved.Hooks.TimeoutSeconds != MaxHookTimeoutSeconds {
+		t.Fatalf("timeoutSeconds = %d, want %d", resolved.Hooks.TimeoutSeconds, MaxHookTimeoutSeconds)
+	}
+
+	if got := ResolveConfig(RawConfig{}, RawConfig{}).Hooks.TimeoutSeconds; got != DefaultHookTimeoutSeconds {
+		t.Fatalf("default timeoutSeconds = %d, want %d", got, DefaultHookTimeoutSeconds)
+	}
+}
+
+func TestResolveConfigWebhooks(t *testing.T) {
+	global := RawConfig{
+		Webhooks: &RawWebhooks{
+			Endpoints:   []RawWebhookEndpoint{{URL: "https://example.com/hook"}, {URL: " "}},
+			MaxAttempts: intPtr(50),
+		},
+	}
+
+	resolved := ResolveConfig(RawConfig{}, global)
+	if len(resolved.Webhooks.Endpoints) != 1 || resolved.Webhooks.Endpoints[0].URL != "https://example.com/hook" {
+		t.Fatalf("endpoints = %+v, want the one with a url", resolved.Webhooks.Endpoints)
+	}
+	if resolved.Webhooks.MaxAttempts != MaxWebhookMaxAttempts {
+		t.Fatalf("maxAttempts = %d, want %d", resolved.Webhooks.MaxAttempts, MaxWebhookMaxAttempts)
+	}
+
+	project := RawConfig{Webhooks: &RawWebhooks{Endpoints: []RawWebhookEndpoint{}}}
+	resolved = ResolveConfig(project, global)
+	if len(resolved.Webhooks.Endpoints) != 0 {
+		t.Fatalf("endpoints = %+v, want project section to replace global", resolved.Webhooks.Endpoints)
+	}
+	if resolved.Webhooks.MaxAttempts != DefaultWebhookMaxAttempts {
+		t.Fatalf("maxAttempts = %d, want %d", resolved.Webhooks.MaxAttempts, DefaultWebhookMaxAttempts)
+	}
+}
+
+func TestResolveConfigAgent(t *testing.T) {
+	project := RawConfig{Agent: &RawAgent{ExecutionTimeoutSeconds: intPtr(5)}}
+	global := RawConfig{Agent: &RawAgent{
+		ExecutionTimeoutSeconds: intPtr(600),
+		PlanTimeoutSeconds:      intPtr(900),
+		MaxRetries:              intPtr(9),
+	}}
+
+	resolved := ResolveConfig(project, global)
+	if resolved.Agent.ExecutionTimeoutSeconds != MinAgentTimeoutSeconds {
+		t.Fatalf("executionTimeoutSeconds = %d, want clamped project value %d", resolved.Agent.ExecutionTimeoutSeconds, MinAgentTimeoutSeconds)
+	}
+	if resolved.Agent.PlanTimeoutSeconds != 900 {
+		t.Fatalf("planTimeoutSeconds = %d, want global value 900", resolved.Agent.PlanTimeoutSeconds)
+	}
+	if resolved.Agent.MaxRetries != MaxAgentMaxRetries {
+		t.Fatalf("maxRetries = %d, want %d", resolved.Agent.MaxRetries, MaxAgentMaxRetries)
+	}
+
+	defaults := ResolveConfig(RawConfig{}, RawConfig{}).Agent
+	if defaults.ExecutionTimeoutSeconds != DefaultAgentTimeoutSeconds || defaults.MaxRetries != DefaultAgentMaxRetries {
+		t.Fatalf("defaults = %+v", defaults)
+	}
+}
+
+func TestResolveConfigPlanQuality(t *testing.T) {
+	global := RawConfig{Planning: &RawPlanning{Quality: &RawPlanQuality{
+		Rules:               map[string]string{"vague_language_detected": "off"},
+		DescriptionMinWords: intPtr(500),
+		MaxDepth:            intPtr(-1),
+		Phrases:             map[string][]string{"vagueLanguage": {"somehow"}},
+		Custom:              []RawCustomQualityRule{{Code: "needs_ticket", Field: "description", Require: "JIRA-", Message: "m"}},
+	}}}
+
+	resolved := ResolveConfig(RawConfig{}, global).Planning.Quality
+	if resolved.Rules["vague_language_detected"] != "off" {
+		t.Fatalf("rules = %+v", resolved.Rules)
+	}
+	if resolved.DescriptionMinWords != MaxQualityDescriptionMinWords {
+		t.Fatalf("descriptionMinWords = %d, want %d", resolved.DescriptionMinWords, MaxQualityDescriptionMinWords)
+	}
+	if resolved.MinAcceptanceCriteria != DefaultQualityMinAcceptanceCriteria {
+		t.Fatalf("minAcceptanceCriteria = %d, want default", resolved.MinAcceptanceCriteria)
+	}
+	if resolved.MaxDepth != 0 || resolved.OrphanLeafMinLeaves != DefaultQualityOrphanLeafMinLeaves {
+		t.Fatalf("maxDepth = %d orphanLeafMinLeaves = %d", resolved.MaxDepth, resolved.OrphanLeafMinLeaves)
+	}
+	if len(resolved.Phrases["vagueLanguage"]) != 1 || len(resolved.Custom) != 1 {
+		t.Fatalf("phrases = %+v custom = %+v", resolved.Phrases, resolved.Custom)
+	}
+
+	project := RawConfig{Planning: &RawPlanning{Quality: &RawPlanQuality{MinAcceptanceCriteria: intPtr(1)}}}
+	resolved = ResolveConfig(project, global).Planning.Quality
+	if len(resolved.Rules) != 0 || len(resolved.Custom) != 0 {
+		t.Fatalf("resolved = %+v, want project section to replace global", resolved)
+	}
+	if resolved.MinAcceptanceCriteria != 1 || resolved.DescriptionMinWords != DefaultQualityDescriptionMinWords {
+		t.Fatalf("thresholds = %d/%d", resolved.MinAcceptanceCriteria, resolved.DescriptionMinWords)
+	}
+}
+
 func intPtr(value int) *int {
 	return &value
 }
@@ -200,3 +322,7 @@ func intPtr(value int) *int {
 func boolPtr(value bool) *bool {
 	return &value
 }
+
+func stringPtr(value string) *string {
+	return &value
+}
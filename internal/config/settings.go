@@ -14,6 +14,10 @@ const (
 	keyPlanningMaxPlanAutoRefinePasses   = "planning.maxPlanAutoRefinePasses"
 	keyExecutionStopAfterEachTask        = "execution.stopAfterEachTask"
 	keyExecutionParentReviewEnabled      = "execution.parentReviewEnabled"
+	keyExecutionAutoContinue             = "execution.autoContinue"
+	keyAgentExecutionTimeoutSeconds      = "agent.executionTimeoutSeconds"
+	keyAgentPlanTimeoutSeconds           = "agent.planTimeoutSeconds"
+	keyAgentMaxRetries                   = "agent.maxRetries"
 )
 
 type RawOptionValue struct {
@@ -86,6 +90,29 @@ func RawOptionValues(cfg RawConfig) map[string]RawOptionValue {
 				Bool: copyBool(*cfg.Execution.ParentReviewEnabled),
 			}
 		}
+		if cfg.Execution.AutoContinue != nil {
+			values[keyExecutionAutoContinue] = RawOptionValue{
+				Bool: copyBool(*cfg.Execution.AutoContinue),
+			}
+		}
+	}
+
+	if cfg.Agent != nil {
+		if cfg.Agent.ExecutionTimeoutSeconds != nil {
+			values[keyAgentExecutionTimeoutSeconds] = RawOptionValue{
+				Int: copyInt(*cfg.Agent.ExecutionTimeoutSeconds),
+			}
+		}
+		if cfg.Agent.PlanTimeoutSeconds != nil {
+			values[keyAgentPlanTimeoutSeconds] = RawOptionValue{
+				Int: copyInt(*cfg.Agent.PlanTimeoutSeconds),
+			}
+		}
+		if cfg.Agent.MaxRetries != nil {
+			values[keyAgentMaxRetries] = RawOptionValue{
+				Int: copyInt(*cfg.Agent.MaxRetries),
+			}
+		}
 	}
 
 	return values
@@ -102,6 +129,28 @@ func SaveConfigValues(path string, values map[string]RawOptionValue) error {
 	if err != nil {
 		return err
 	}
+	// Hooks, webhooks and planning.quality are not settings options; keep
+	// whatever the file already has.
+	existing, present, _ := loadConfigFile(path)
+	var existingQuality *RawPlanQuality
+	if present && existing.Planning != nil {
+		existingQuality = existing.Planning.Quality
+	}
+	if existingQuality != nil {
+		if cfg.Planning == nil {
+			cfg.Planning = &RawPlanning{}
+		}
+		cfg.Planning.Quality = existingQuality
+	}
+	if present && (existing.Hooks != nil || existing.Webhooks != nil || existingQuality != nil) {
+		cfg.Hooks = existing.Hooks
+		cfg.Webhooks = existing.Webhooks
+		if !hasValues {
+			version := SchemaVersion
+			cfg.SchemaVersion = &version
+			hasValues = true
+		}
+	}
 	if !hasValues {
 		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return fmt.Errorf("remove config %s: %w", path, err)
@@ -130,9 +179,11 @@ func buildRawConfig(values map[string]RawOptionValue) (RawConfig, bool, error) {
 	var tui RawTUI
 	var planning RawPlanning
 	var exec RawExecution
+	var agentCfg RawAgent
 	var hasTUI bool
 	var hasPlanning bool
 	var hasExec bool
+	var hasAgent bool
 
 	for key, value := range values {
 		if value.Int != nil && value.Bool != nil {
@@ -178,12 +229,33 @@ func buildRawConfig(values map[string]RawOptionValue) (RawConfig, bool, error) {
 			v := *value.Bool
 			exec.ParentReviewEnabled = &v
 			hasExec = true
+		case keyExecutionAutoContinue:
+			if value.Bool == nil {
+				return RawConfig{}, false, fmt.Errorf("config key %q expects bool value", key)
+			}
+			v := *value.Bool
+			exec.AutoContinue = &v
+			hasExec = true
+		case keyAgentExecutionTimeoutSeconds, keyAgentPlanTimeoutSeconds, keyAgentMaxRetries:
+			if value.Int == nil {
+				return RawConfig{}, false, fmt.Errorf("config key %q expects int value", key)
+			}
+			v := *value.Int
+			switch key {
+			case keyAgentExecutionTimeoutSeconds:
+				agentCfg.ExecutionTimeoutSeconds = &v
+			case keyAgentPlanTimeoutSeconds:
+				agentCfg.PlanTimeoutSeconds = &v
+			default:
+				agentCfg.MaxRetries = &v
+			}
+			hasAgent = true
 		default:
 			return RawConfig{}, false, fmt.Errorf("unknown config key %q", key)
 		}
 	}
 
-	if !hasTUI && !hasPlanning && !hasExec {
+	if !hasTUI && !hasPlanning && !hasExec && !hasAgent {
 		return RawConfig{}, false, nil
 	}
 
@@ -196,6 +268,9 @@ func buildRawConfig(values map[string]RawOptionValue) (RawConfig, bool, error) {
 	if hasExec {
 		cfg.Execution = &exec
 	}
+	if hasAgent {
+		cfg.Agent = &agentCfg
+	}
 	version := SchemaVersion
 	cfg.SchemaVersion = &version
 
@@ -162,6 +162,18 @@ func ResolvedOptionValues(cfg ResolvedConfig) map[string]RawOptionValue {
 		keyExecutionParentReviewEnabled: {
 			Bool: copyBool(cfg.Execution.ParentReviewEnabled),
 		},
+		keyExecutionAutoContinue: {
+			Bool: copyBool(cfg.Execution.AutoContinue),
+		},
+		keyAgentExecutionTimeoutSeconds: {
+			Int: copyInt(cfg.Agent.ExecutionTimeoutSeconds),
+		},
+		keyAgentPlanTimeoutSeconds: {
+			Int: copyInt(cfg.Agent.PlanTimeoutSeconds),
+		},
+		keyAgentMaxRetries: {
+			Int: copyInt(cfg.Agent.MaxRetries),
+		},
 	}
 }
 
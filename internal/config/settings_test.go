@@ -290,6 +290,60 @@ func TestSaveConfigValuesRoundTripPreservesExistingKeys(t *testing.T) {
 	}
 }
 
+func TestSaveConfigValuesPreservesHooks(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, ".blackbird", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"schemaVersion":1,"hooks":{"preTask":"git diff --quiet"}}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	stop := true
+	if err := SaveConfigValues(path, map[string]RawOptionValue{keyExecutionStopAfterEachTask: {Bool: &stop}}); err != nil {
+		t.Fatalf("save config values: %v", err)
+	}
+	if err := SaveConfigValues(path, map[string]RawOptionValue{}); err != nil {
+		t.Fatalf("save empty config values: %v", err)
+	}
+
+	cfg, present, err := loadConfigFile(path)
+	if err != nil || !present {
+		t.Fatalf("load config: present=%v err=%v", present, err)
+	}
+	if cfg.Hooks == nil || cfg.Hooks.PreTask == nil || *cfg.Hooks.PreTask != "git diff --quiet" {
+		t.Fatalf("hooks = %#v, want preTask preserved", cfg.Hooks)
+	}
+}
+
+func TestSaveConfigValuesPreservesPlanQuality(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, ".blackbird", "config.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"schemaVersion":1,"planning":{"maxPlanAutoRefinePasses":2,"quality":{"rules":{"leaf_prompt_missing":"warning"}}}}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	passes := 0
+	if err := SaveConfigValues(path, map[string]RawOptionValue{keyPlanningMaxPlanAutoRefinePasses: {Int: &passes}}); err != nil {
+		t.Fatalf("save config values: %v", err)
+	}
+
+	cfg, present, err := loadConfigFile(path)
+	if err != nil || !present {
+		t.Fatalf("load config: present=%v err=%v", present, err)
+	}
+	if cfg.Planning == nil || cfg.Planning.MaxPlanAutoRefinePasses == nil || *cfg.Planning.MaxPlanAutoRefinePasses != 0 {
+		t.Fatalf("planning = %#v, want saved maxPlanAutoRefinePasses", cfg.Planning)
+	}
+	if cfg.Planning.Quality == nil || cfg.Planning.Quality.Rules["leaf_prompt_missing"] != "warning" {
+		t.Fatalf("quality = %#v, want preserved", cfg.Planning.Quality)
+	}
+}
+
 func TestSaveConfigValuesRemovesFileWhenEmpty(t *testing.T) {
 	tempDir := t.TempDir()
 	path := filepath.Join(tempDir, ".blackbird", "config.json")
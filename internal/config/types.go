@@ -8,11 +8,32 @@ const (
 	DefaultMaxPlanAutoRefinePasses        = 1
 	DefaultStopAfterEachTask              = false
 	DefaultParentReviewEnabled            = false
+	DefaultAutoContinue                   = false
+	DefaultHookTimeoutSeconds             = 300
+	DefaultWebhookMaxAttempts             = 4
+	DefaultAgentTimeoutSeconds            = 3600
+	DefaultAgentMaxRetries                = 1
+	DefaultQualityDescriptionMinWords     = 8
+	DefaultQualityMinAcceptanceCriteria   = 2
+	DefaultQualityMaxDepth                = 4
+	DefaultQualityOrphanLeafMinLeaves     = 10
 
-	MinRefreshIntervalSeconds = 1
-	MaxRefreshIntervalSeconds = 300
-	MinPlanAutoRefinePasses   = 0
-	MaxPlanAutoRefinePasses   = 3
+	MinRefreshIntervalSeconds       = 1
+	MaxRefreshIntervalSeconds       = 300
+	MinPlanAutoRefinePasses         = 0
+	MaxPlanAutoRefinePasses         = 3
+	MinHookTimeoutSeconds           = 1
+	MaxHookTimeoutSeconds           = 3600
+	MinWebhookMaxAttempts           = 1
+	MaxWebhookMaxAttempts           = 10
+	MinAgentTimeoutSeconds          = 60
+	MaxAgentTimeoutSeconds          = 86400
+	MinAgentMaxRetries              = 0
+	MaxAgentMaxRetries              = 5
+	MaxQualityDescriptionMinWords   = 200
+	MaxQualityMinAcceptanceCriteria = 20
+	MaxQualityMaxDepth              = 20
+	MaxQualityOrphanLeafMinLeaves   = 1000
 )
 
 type RawConfig struct {
@@ -20,6 +41,9 @@ type RawConfig struct {
 	TUI           *RawTUI       `json:"tui,omitempty"`
 	Planning      *RawPlanning  `json:"planning,omitempty"`
 	Execution     *RawExecution `json:"execution,omitempty"`
+	Agent         *RawAgent     `json:"agent,omitempty"`
+	Hooks         *RawHooks     `json:"hooks,omitempty"`
+	Webhooks      *RawWebhooks  `json:"webhooks,omitempty"`
 }
 
 type RawTUI struct {
@@ -30,10 +54,72 @@ type RawTUI struct {
 type RawExecution struct {
 	StopAfterEachTask   *bool `json:"stopAfterEachTask,omitempty"`
 	ParentReviewEnabled *bool `json:"parentReviewEnabled,omitempty"`
+	AutoContinue        *bool `json:"autoContinue,omitempty"`
+}
+
+// RawAgent limits agent commands. Timeouts apply per attempt.
+type RawAgent struct {
+	ExecutionTimeoutSeconds *int `json:"executionTimeoutSeconds,omitempty"`
+	PlanTimeoutSeconds      *int `json:"planTimeoutSeconds,omitempty"`
+	MaxRetries              *int `json:"maxRetries,omitempty"`
+}
+
+// RawHooks holds lifecycle hook commands (run via `sh -c`).
+type RawHooks struct {
+	PreTask              *string `json:"preTask,omitempty"`
+	PostTask             *string `json:"postTask,omitempty"`
+	OnWaitingUser        *string `json:"onWaitingUser,omitempty"`
+	OnFailed             *string `json:"onFailed,omitempty"`
+	OnParentReviewFailed *string `json:"onParentReviewFailed,omitempty"`
+	OnExecuteComplete    *string `json:"onExecuteComplete,omitempty"`
+	TimeoutSeconds       *int    `json:"timeoutSeconds,omitempty"`
+}
+
+// RawWebhooks configures outgoing execution event notifications. A project
+// section replaces the global one as a whole.
+type RawWebhooks struct {
+	Endpoints   []RawWebhookEndpoint `json:"endpoints,omitempty"`
+	MaxAttempts *int                 `json:"maxAttempts,omitempty"`
+}
+
+type RawWebhookEndpoint struct {
+	URL string `json:"url"`
+	// Secret (or the env var named by SecretEnv) signs payloads with HMAC-SHA256.
+	Secret    string `json:"secret,omitempty"`
+	SecretEnv string `json:"secretEnv,omitempty"`
+	// Events limits deliveries to the listed event names; empty means all.
+	Events []string `json:"events,omitempty"`
 }
 
 type RawPlanning struct {
-	MaxPlanAutoRefinePasses *int `json:"maxPlanAutoRefinePasses,omitempty"`
+	MaxPlanAutoRefinePasses *int            `json:"maxPlanAutoRefinePasses,omitempty"`
+	Quality                 *RawPlanQuality `json:"quality,omitempty"`
+}
+
+// RawPlanQuality tunes the plan-quality lint. A project section replaces the
+// global one as a whole.
+type RawPlanQuality struct {
+	// Rules overrides rule severities by code: blocking, warning or off.
+	Rules                 map[string]string `json:"rules,omitempty"`
+	DescriptionMinWords   *int              `json:"descriptionMinWords,omitempty"`
+	MinAcceptanceCriteria *int              `json:"minAcceptanceCriteria,omitempty"`
+	MaxDepth              *int              `json:"maxDepth,omitempty"`
+	OrphanLeafMinLeaves   *int              `json:"orphanLeafMinLeaves,omitempty"`
+	// Phrases extends the built-in phrase lists by list name.
+	Phrases map[string][]string    `json:"phrases,omitempty"`
+	Custom  []RawCustomQualityRule `json:"custom,omitempty"`
+}
+
+// RawCustomQualityRule reports a finding when Field matches the Match regexp
+// or does not match the Require regexp.
+type RawCustomQualityRule struct {
+	Code       string `json:"code"`
+	Field      string `json:"field"`
+	Match      string `json:"match,omitempty"`
+	Require    string `json:"require,omitempty"`
+	Severity   string `json:"severity,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
 }
 
 type ResolvedConfig struct {
@@ -41,6 +127,9 @@ type ResolvedConfig struct {
 	TUI           ResolvedTUI       `json:"tui"`
 	Planning      ResolvedPlanning  `json:"planning"`
 	Execution     ResolvedExecution `json:"execution"`
+	Agent         ResolvedAgent     `json:"agent"`
+	Hooks         ResolvedHooks     `json:"hooks"`
+	Webhooks      ResolvedWebhooks  `json:"webhooks"`
 }
 
 type ResolvedTUI struct {
@@ -51,10 +140,48 @@ type ResolvedTUI struct {
 type ResolvedExecution struct {
 	StopAfterEachTask   bool `json:"stopAfterEachTask"`
 	ParentReviewEnabled bool `json:"parentReviewEnabled"`
+	// AutoContinue keeps execute running past waiting_user tasks and resumes
+	// execution after questions are answered.
+	AutoContinue bool `json:"autoContinue"`
+}
+
+type ResolvedAgent struct {
+	ExecutionTimeoutSeconds int `json:"executionTimeoutSeconds"`
+	PlanTimeoutSeconds      int `json:"planTimeoutSeconds"`
+	MaxRetries              int `json:"maxRetries"`
+}
+
+// ResolvedHooks holds hook commands; an empty command disables the hook.
+type ResolvedHooks struct {
+	PreTask              string `json:"preTask"`
+	PostTask             string `json:"postTask"`
+	OnWaitingUser        string `json:"onWaitingUser"`
+	OnFailed             string `json:"onFailed"`
+	OnParentReviewFailed string `json:"onParentReviewFailed"`
+	OnExecuteComplete    string `json:"onExecuteComplete"`
+	TimeoutSeconds       int    `json:"timeoutSeconds"`
+}
+
+type ResolvedWebhooks struct {
+	Endpoints   []RawWebhookEndpoint `json:"endpoints"`
+	MaxAttempts int                  `json:"maxAttempts"`
 }
 
 type ResolvedPlanning struct {
-	MaxPlanAutoRefinePasses int `json:"maxPlanAutoRefinePasses"`
+	MaxPlanAutoRefinePasses int                 `json:"maxPlanAutoRefinePasses"`
+	Quality                 ResolvedPlanQuality `json:"quality"`
+}
+
+// ResolvedPlanQuality holds plan-quality settings as configured; rule
+// severities, phrase list names and regexps are checked by plangen.
+type ResolvedPlanQuality struct {
+	Rules                 map[string]string      `json:"rules"`
+	DescriptionMinWords   int                    `json:"descriptionMinWords"`
+	MinAcceptanceCriteria int                    `json:"minAcceptanceCriteria"`
+	MaxDepth              int                    `json:"maxDepth"`
+	OrphanLeafMinLeaves   int                    `json:"orphanLeafMinLeaves"`
+	Phrases               map[string][]string    `json:"phrases"`
+	Custom                []RawCustomQualityRule `json:"custom"`
 }
 
 func DefaultResolvedConfig() ResolvedConfig {
@@ -66,10 +193,32 @@ func DefaultResolvedConfig() ResolvedConfig {
 		},
 		Planning: ResolvedPlanning{
 			MaxPlanAutoRefinePasses: DefaultMaxPlanAutoRefinePasses,
+			Quality: ResolvedPlanQuality{
+				Rules:                 map[string]string{},
+				DescriptionMinWords:   DefaultQualityDescriptionMinWords,
+				MinAcceptanceCriteria: DefaultQualityMinAcceptanceCriteria,
+				MaxDepth:              DefaultQualityMaxDepth,
+				OrphanLeafMinLeaves:   DefaultQualityOrphanLeafMinLeaves,
+				Phrases:               map[string][]string{},
+				Custom:                []RawCustomQualityRule{},
+			},
 		},
 		Execution: ResolvedExecution{
 			StopAfterEachTask:   DefaultStopAfterEachTask,
 			ParentReviewEnabled: DefaultParentReviewEnabled,
+			AutoContinue:        DefaultAutoContinue,
+		},
+		Agent: ResolvedAgent{
+			ExecutionTimeoutSeconds: DefaultAgentTimeoutSeconds,
+			PlanTimeoutSeconds:      DefaultAgentTimeoutSeconds,
+			MaxRetries:              DefaultAgentMaxRetries,
+		},
+		Hooks: ResolvedHooks{
+			TimeoutSeconds: DefaultHookTimeoutSeconds,
+		},
+		Webhooks: ResolvedWebhooks{
+			Endpoints:   []RawWebhookEndpoint{},
+			MaxAttempts: DefaultWebhookMaxAttempts,
 		},
 	}
 }
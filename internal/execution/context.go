@@ -21,6 +21,14 @@ func BuildContext(g plan.WorkGraph, taskID string) (ContextPack, error) {
 
 	deps := make([]DependencyContext, 0, len(it.Deps))
 	for _, depID := range it.Deps {
+		if plan.IsExternalDep(depID) {
+			_, dep, err := plan.ResolveExternalDep(g, depID)
+			if err != nil {
+				return ContextPack{}, fmt.Errorf("dependency %q: %w", depID, err)
+			}
+			deps = append(deps, DependencyContext{ID: depID, Title: dep.Title, Status: string(dep.Status)})
+			continue
+		}
 		dep, ok := g.Items[depID]
 		if !ok {
 			return ContextPack{}, fmt.Errorf("unknown dependency id %q", depID)
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jbonatakis/blackbird/internal/agent"
 	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
 )
 
 // ExecutionController centralizes execution and decision checkpoint flows for CLI/TUI.
@@ -21,10 +22,14 @@ type ExecutionController struct {
 	StreamStderr        io.Writer
 	StopAfterEachTask   bool
 	ParentReviewEnabled bool
-	OnStateChange       func(ExecutionStageState)
-	OnParentReview      func(RunRecord)
-	OnTaskStart         func(taskID string)
-	OnTaskFinish        func(taskID string, record RunRecord, execErr error)
+	// ContinueOnWaitingUser mirrors ExecuteConfig.ContinueOnWaitingUser.
+	ContinueOnWaitingUser bool
+	Hooks                 Hooks
+	Webhooks              *webhook.Dispatcher
+	OnStateChange         func(ExecutionStageState)
+	OnParentReview        func(RunRecord)
+	OnTaskStart           func(taskID string)
+	OnTaskFinish          func(taskID string, record RunRecord, execErr error)
 }
 
 // DecisionRequest captures a user decision for a run checkpoint.
@@ -45,17 +50,20 @@ type DecisionResult struct {
 
 func (c ExecutionController) Execute(ctx context.Context) (ExecuteResult, error) {
 	return RunExecute(ctx, ExecuteConfig{
-		PlanPath:            c.PlanPath,
-		Graph:               c.Graph,
-		Runtime:             c.Runtime,
-		StopAfterEachTask:   c.StopAfterEachTask,
-		ParentReviewEnabled: c.ParentReviewEnabled,
-		StreamStdout:        c.StreamStdout,
-		StreamStderr:        c.StreamStderr,
-		OnStateChange:       c.OnStateChange,
-		OnParentReview:      c.OnParentReview,
-		OnTaskStart:         c.OnTaskStart,
-		OnTaskFinish:        c.OnTaskFinish,
+		PlanPath:              c.PlanPath,
+		Graph:                 c.Graph,
+		Runtime:               c.Runtime,
+		StopAfterEachTask:     c.StopAfterEachTask,
+		ParentReviewEnabled:   c.ParentReviewEnabled,
+		ContinueOnWaitingUser: c.ContinueOnWaitingUser,
+		Hooks:                 c.Hooks,
+		Webhooks:              c.Webhooks,
+		StreamStdout:          c.StreamStdout,
+		StreamStderr:          c.StreamStderr,
+		OnStateChange:         c.OnStateChange,
+		OnParentReview:        c.OnParentReview,
+		OnTaskStart:           c.OnTaskStart,
+		OnTaskFinish:          c.OnTaskFinish,
 	})
 }
 
@@ -116,6 +124,8 @@ func (c ExecutionController) ResolveDecision(ctx context.Context, req DecisionRe
 				Graph:               c.Graph,
 				Runtime:             c.Runtime,
 				ParentReviewEnabled: c.ParentReviewEnabled,
+				Hooks:               c.Hooks,
+				Webhooks:            c.Webhooks,
 				StreamStdout:        c.StreamStdout,
 				StreamStderr:        c.StreamStderr,
 				OnStateChange:       c.OnStateChange,
@@ -162,6 +172,8 @@ func (c ExecutionController) ResolveDecision(ctx context.Context, req DecisionRe
 			TaskID:       req.TaskID,
 			Feedback:     record.DecisionFeedback,
 			Runtime:      c.Runtime,
+			Hooks:        c.Hooks,
+			Webhooks:     c.Webhooks,
 			StreamStdout: c.StreamStdout,
 			StreamStderr: c.StreamStderr,
 			OnTaskStart:  c.OnTaskStart,
@@ -179,6 +191,7 @@ func (c ExecutionController) ResolveDecision(ctx context.Context, req DecisionRe
 			if err := SaveRun(baseDir, resumeRecord); err != nil {
 				return result, err
 			}
+			c.Webhooks.Notify(runEvent(webhook.EventDecisionRequired, c.PlanPath, resumeRecord))
 			next.Reason = ExecuteReasonDecisionRequired
 		default:
 			return result, fmt.Errorf("unexpected run status %q", resumeRecord.Status)
@@ -0,0 +1,294 @@
+package execution
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const runArchiveDirName = ".blackbird/archive"
+
+// GCOptions selects which run records GCRuns removes.
+type GCOptions struct {
+	// Keep is the number of newest runs kept per task regardless of age.
+	Keep int
+	// OlderThan limits removal to runs started before Now-OlderThan. Zero
+	// means age is not considered.
+	OlderThan time.Duration
+	// Archive writes the removed runs to a tar.gz under .blackbird/archive
+	// before deleting them.
+	Archive bool
+	// DryRun reports what would be removed without touching any files.
+	DryRun bool
+	// Orphans lists tasks (no longer in the plan) whose whole run directory
+	// is removed, regardless of Keep, OlderThan and run status.
+	Orphans []string
+	Now     time.Time
+}
+
+// GCResult reports the outcome of GCRuns.
+type GCResult struct {
+	Removed     []RunRef `json:"removed"`
+	Kept        int      `json:"kept"`
+	Bytes       int64    `json:"bytes"`
+	ArchivePath string   `json:"archivePath,omitempty"`
+	// Orphans are the tasks from GCOptions.Orphans that had a run directory;
+	// OrphanBytes is the size of those directories.
+	Orphans     []string `json:"orphans"`
+	OrphanBytes int64    `json:"orphanBytes"`
+}
+
+// RunRef identifies one run record.
+type RunRef struct {
+	TaskID string `json:"taskId"`
+	RunID  string `json:"runId"`
+}
+
+type gcCandidate struct {
+	ref RunRef
+	// paths holds the run record followed by its log files.
+	paths []string
+	size  int64
+}
+
+// GCRuns removes old run records under baseDir. For every task the newest
+// opts.Keep runs are kept; older runs are removed when they also match
+// opts.OlderThan. Runs that are still running or waiting for user input are
+// never removed since execution and resume depend on them. The run
+// directories of opts.Orphans are removed (and archived) as a whole.
+func GCRuns(baseDir string, opts GCOptions) (GCResult, error) {
+	if baseDir == "" {
+		return GCResult{}, fmt.Errorf("baseDir required")
+	}
+	if opts.Keep < 0 {
+		return GCResult{}, fmt.Errorf("keep must not be negative")
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	taskIDs, err := RunDirTaskIDs(baseDir)
+	if err != nil {
+		return GCResult{}, err
+	}
+
+	orphans := map[string]bool{}
+	for _, id := range opts.Orphans {
+		orphans[id] = true
+	}
+
+	result := GCResult{Removed: []RunRef{}, Orphans: []string{}}
+	var candidates, orphaned []gcCandidate
+	for _, taskID := range taskIDs {
+		if orphans[taskID] {
+			c, err := orphanCandidate(baseDir, taskID)
+			if err != nil {
+				return GCResult{}, err
+			}
+			orphaned = append(orphaned, c)
+			result.Orphans = append(result.Orphans, taskID)
+			result.OrphanBytes += c.size
+			continue
+		}
+		records, err := ListRuns(baseDir, taskID)
+		if err != nil {
+			return GCResult{}, fmt.Errorf("%s: %w", taskID, err)
+		}
+		// ListRuns is oldest first; everything before cutoff is past the
+		// keep window.
+		cutoff := max(len(records)-opts.Keep, 0)
+		for i, record := range records {
+			if i >= cutoff || !gcEligible(record, opts) {
+				result.Kept++
+				continue
+			}
+			c := gcCandidate{ref: RunRef{TaskID: record.TaskID, RunID: record.ID}}
+			for _, name := range []string{record.ID + ".json", record.StdoutLog, record.StderrLog} {
+				if name == "" {
+					continue
+				}
+				path := filepath.Join(baseDir, runsDirName, record.TaskID, filepath.Base(name))
+				info, err := os.Stat(path)
+				if err != nil {
+					if os.IsNotExist(err) {
+						continue
+					}
+					return GCResult{}, fmt.Errorf("stat run record: %w", err)
+				}
+				c.paths = append(c.paths, path)
+				c.size += info.Size()
+			}
+			candidates = append(candidates, c)
+		}
+	}
+
+	for _, c := range candidates {
+		result.Removed = append(result.Removed, c.ref)
+		result.Bytes += c.size
+	}
+	if opts.DryRun || len(candidates)+len(orphaned) == 0 {
+		return result, nil
+	}
+
+	if opts.Archive {
+		archivePath, err := writeRunArchive(baseDir, append(candidates, orphaned...), opts.Now)
+		if err != nil {
+			return GCResult{}, err
+		}
+		result.ArchivePath = archivePath
+	}
+	for _, c := range candidates {
+		for _, path := range c.paths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return result, fmt.Errorf("remove run record: %w", err)
+			}
+		}
+	}
+	for _, taskID := range result.Orphans {
+		if err := os.RemoveAll(filepath.Join(baseDir, runsDirName, taskID)); err != nil {
+			return result, fmt.Errorf("remove run directory: %w", err)
+		}
+	}
+	for _, taskID := range taskIDs {
+		// Drop task directories that GC emptied; Remove fails on the rest.
+		_ = os.Remove(filepath.Join(baseDir, runsDirName, taskID))
+	}
+	return result, nil
+}
+
+// orphanCandidate collects every file in the run directory of taskID.
+func orphanCandidate(baseDir, taskID string) (gcCandidate, error) {
+	c := gcCandidate{ref: RunRef{TaskID: taskID}}
+	dir := filepath.Join(baseDir, runsDirName, taskID)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return gcCandidate{}, fmt.Errorf("read run directory: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return gcCandidate{}, fmt.Errorf("stat run record: %w", err)
+		}
+		c.paths = append(c.paths, filepath.Join(dir, entry.Name()))
+		c.size += info.Size()
+	}
+	return c, nil
+}
+
+func gcEligible(record RunRecord, opts GCOptions) bool {
+	if record.Status == RunStatusRunning || record.Status == RunStatusWaitingUser {
+		return false
+	}
+	if opts.OlderThan > 0 && record.StartedAt.After(opts.Now.Add(-opts.OlderThan)) {
+		return false
+	}
+	return true
+}
+
+// writeRunArchive stores the candidate records and their logs as
+// <taskID>/<file> entries in a new tar.gz and returns its path.
+func writeRunArchive(baseDir string, candidates []gcCandidate, now time.Time) (string, error) {
+	dir := filepath.Join(baseDir, runArchiveDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create archive directory: %w", err)
+	}
+	path := filepath.Join(dir, "runs-"+now.UTC().Format("20060102T150405Z")+".tar.gz")
+
+	tmp, err := os.CreateTemp(dir, tmpPattern(filepath.Base(path)))
+	if err != nil {
+		return "", fmt.Errorf("create archive: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+	}()
+
+	gz := gzip.NewWriter(tmp)
+	tw := tar.NewWriter(gz)
+	for _, c := range candidates {
+		for _, path := range c.paths {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", fmt.Errorf("read run record: %w", err)
+			}
+			hdr := &tar.Header{
+				Name:    c.ref.TaskID + "/" + filepath.Base(path),
+				Mode:    0o644,
+				Size:    int64(len(data)),
+				ModTime: now,
+			}
+			if err := tw.WriteHeader(hdr); err != nil {
+				return "", fmt.Errorf("write archive: %w", err)
+			}
+			if _, err := tw.Write(data); err != nil {
+				return "", fmt.Errorf("write archive: %w", err)
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return "", fmt.Errorf("write archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return "", fmt.Errorf("write archive: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return "", fmt.Errorf("fsync archive: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("close archive: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return "", fmt.Errorf("rename archive into place: %w", err)
+	}
+	return path, nil
+}
+
+// RunDirTaskIDs returns the task IDs that have a run directory, sorted.
+func RunDirTaskIDs(baseDir string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(baseDir, runsDirName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, fmt.Errorf("read runs directory: %w", err)
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			ids = append(ids, entry.Name())
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// RemoveTaskRuns deletes the run directories of the given tasks and returns
+// the IDs that had one.
+func RemoveTaskRuns(baseDir string, taskIDs []string) ([]string, error) {
+	removed := []string{}
+	for _, id := range taskIDs {
+		if id == "" || filepath.Base(id) != id {
+			continue
+		}
+		dir := filepath.Join(baseDir, runsDirName, id)
+		if _, err := os.Stat(dir); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return removed, fmt.Errorf("stat run directory: %w", err)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return removed, fmt.Errorf("remove run directory: %w", err)
+		}
+		removed = append(removed, id)
+	}
+	return removed, nil
+}
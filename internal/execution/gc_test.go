@@ -0,0 +1,173 @@
+package execution
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+func saveGCRun(t *testing.T, baseDir, taskID, runID string, startedAt time.Time, status RunStatus) {
+	t.Helper()
+	record := RunRecord{
+		ID:        runID,
+		TaskID:    taskID,
+		StartedAt: startedAt,
+		Status:    status,
+		Stdout:    "output of " + runID,
+		Context: ContextPack{
+			SchemaVersion: ContextPackSchemaVersion,
+			Task:          TaskContext{ID: taskID, Title: "Task"},
+		},
+	}
+	if err := SaveRun(baseDir, record); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+}
+
+func TestGCRunsKeepsNewestAndActiveRuns(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	saveGCRun(t, baseDir, "a", "a1", now.Add(-40*day), RunStatusFailed)
+	saveGCRun(t, baseDir, "a", "a2", now.Add(-35*day), RunStatusWaitingUser)
+	saveGCRun(t, baseDir, "a", "a3", now.Add(-10*day), RunStatusSuccess)
+	saveGCRun(t, baseDir, "a", "a4", now.Add(-1*day), RunStatusSuccess)
+	saveGCRun(t, baseDir, "b", "b1", now.Add(-50*day), RunStatusSuccess)
+
+	result, err := GCRuns(baseDir, GCOptions{Keep: 1, OlderThan: 30 * day, Now: now})
+	if err != nil {
+		t.Fatalf("GCRuns: %v", err)
+	}
+	// a1 is old; a2 is waiting; a3 is too recent; a4 and b1 are the newest.
+	if len(result.Removed) != 1 || result.Removed[0] != (RunRef{TaskID: "a", RunID: "a1"}) {
+		t.Fatalf("removed = %+v", result.Removed)
+	}
+	if result.Kept != 4 || result.Bytes == 0 || result.ArchivePath != "" {
+		t.Fatalf("result = %+v", result)
+	}
+	runs, err := ListRuns(baseDir, "a")
+	if err != nil || len(runs) != 3 || runs[0].ID != "a2" {
+		t.Fatalf("remaining runs = %+v, %v", runs, err)
+	}
+}
+
+func TestGCRunsDryRunAndArchive(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	saveGCRun(t, baseDir, "a", "a1", now.Add(-3*time.Hour), RunStatusSuccess)
+	saveGCRun(t, baseDir, "a", "a2", now.Add(-2*time.Hour), RunStatusFailed)
+	saveGCRun(t, baseDir, "b", "b1", now.Add(-1*time.Hour), RunStatusSuccess)
+
+	dry, err := GCRuns(baseDir, GCOptions{Keep: 0, DryRun: true, Now: now})
+	if err != nil || len(dry.Removed) != 3 {
+		t.Fatalf("dry run = %+v, %v", dry, err)
+	}
+	if runs, _ := ListRuns(baseDir, "a"); len(runs) != 2 {
+		t.Fatalf("dry run removed files: %+v", runs)
+	}
+
+	result, err := GCRuns(baseDir, GCOptions{Keep: 0, Archive: true, Now: now})
+	if err != nil {
+		t.Fatalf("GCRuns: %v", err)
+	}
+	if result.ArchivePath == "" || len(result.Removed) != 3 {
+		t.Fatalf("result = %+v", result)
+	}
+	if ids, _ := RunDirTaskIDs(baseDir); len(ids) != 0 {
+		t.Fatalf("expected emptied task directories to be removed, got %v", ids)
+	}
+
+	f, err := os.Open(result.ArchivePath)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, hdr.Name)
+	}
+	sort.Strings(names)
+	want := []string{"a/a1.json", "a/a2.json", "b/b1.json"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] || names[2] != want[2] {
+		t.Fatalf("archive entries = %v", names)
+	}
+}
+
+func TestRemoveTaskRuns(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Now()
+	saveGCRun(t, baseDir, "a", "a1", now, RunStatusSuccess)
+	saveGCRun(t, baseDir, "b", "b1", now, RunStatusSuccess)
+
+	removed, err := RemoveTaskRuns(baseDir, []string{"a", "missing", "../b"})
+	if err != nil || len(removed) != 1 || removed[0] != "a" {
+		t.Fatalf("removed = %v, %v", removed, err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, runsDirName, "b")); err != nil {
+		t.Fatalf("expected b runs to remain: %v", err)
+	}
+}
+
+func TestGCRunsArchivesOrphans(t *testing.T) {
+	baseDir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	saveGCRun(t, baseDir, "a", "a1", now, RunStatusSuccess)
+	saveGCRun(t, baseDir, "gone", "g1", now, RunStatusSuccess)
+	saveGCRun(t, baseDir, "gone", "g2", now, RunStatusRunning)
+	opts := GCOptions{Keep: 10, Orphans: []string{"gone", "missing"}, Now: now}
+
+	dryOpts := opts
+	dryOpts.DryRun = true
+	dry, err := GCRuns(baseDir, dryOpts)
+	if err != nil || len(dry.Orphans) != 1 || dry.Orphans[0] != "gone" || dry.OrphanBytes == 0 {
+		t.Fatalf("dry run = %+v, %v", dry, err)
+	}
+
+	opts.Archive = true
+	result, err := GCRuns(baseDir, opts)
+	if err != nil {
+		t.Fatalf("GCRuns: %v", err)
+	}
+	if result.ArchivePath == "" || len(result.Removed) != 0 || result.OrphanBytes != dry.OrphanBytes {
+		t.Fatalf("result = %+v", result)
+	}
+	if ids, _ := RunDirTaskIDs(baseDir); len(ids) != 1 || ids[0] != "a" {
+		t.Fatalf("run dirs = %v", ids)
+	}
+
+	f, err := os.Open(result.ArchivePath)
+	if err != nil {
+		t.Fatalf("open archive: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, hdr.Name)
+	}
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "gone/g1.json" || names[1] != "gone/g2.json" {
+		t.Fatalf("archive entries = %v", names)
+	}
+}
@@ -0,0 +1,300 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/config"
+	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
+)
+
+// HookEvent names a point in the execution lifecycle where a user hook runs.
+type HookEvent string
+
+const (
+	HookPreTask              HookEvent = "pre_task"
+	HookPostTask             HookEvent = "post_task"
+	HookOnWaitingUser        HookEvent = "on_waiting_user"
+	HookOnFailed             HookEvent = "on_failed"
+	HookOnParentReviewFailed HookEvent = "on_parent_review_failed"
+	HookOnExecuteComplete    HookEvent = "on_execute_complete"
+)
+
+// Hooks holds the shell command for each lifecycle event. Empty commands are skipped.
+type Hooks struct {
+	PreTask              string
+	PostTask             string
+	OnWaitingUser        string
+	OnFailed             string
+	OnParentReviewFailed string
+	OnExecuteComplete    string
+	Timeout              time.Duration
+}
+
+// HooksFromConfig converts resolved hook config into execution hooks.
+func HooksFromConfig(cfg config.ResolvedHooks) Hooks {
+	return Hooks{
+		PreTask:              cfg.PreTask,
+		PostTask:             cfg.PostTask,
+		OnWaitingUser:        cfg.OnWaitingUser,
+		OnFailed:             cfg.OnFailed,
+		OnParentReviewFailed: cfg.OnParentReviewFailed,
+		OnExecuteComplete:    cfg.OnExecuteComplete,
+		Timeout:              time.Duration(cfg.TimeoutSeconds) * time.Second,
+	}
+}
+
+func (h Hooks) command(event HookEvent) string {
+	switch event {
+	case HookPreTask:
+		return h.PreTask
+	case HookPostTask:
+		return h.PostTask
+	case HookOnWaitingUser:
+		return h.OnWaitingUser
+	case HookOnFailed:
+		return h.OnFailed
+	case HookOnParentReviewFailed:
+		return h.OnParentReviewFailed
+	case HookOnExecuteComplete:
+		return h.OnExecuteComplete
+	default:
+		return ""
+	}
+}
+
+// HookResult captures one hook invocation.
+type HookResult struct {
+	Event      HookEvent `json:"event"`
+	Command    string    `json:"command"`
+	StartedAt  time.Time `json:"startedAt"`
+	DurationMs int64     `json:"durationMs"`
+	ExitCode   *int      `json:"exitCode,omitempty"`
+	Stdout     string    `json:"stdout,omitempty"`
+	Stderr     string    `json:"stderr,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Failed reports whether the hook exited non-zero or could not run.
+func (r HookResult) Failed() bool {
+	return r.Error != ""
+}
+
+// hookPayload is written to the hook's stdin as JSON.
+type hookPayload struct {
+	Event    HookEvent         `json:"event"`
+	PlanPath string            `json:"planPath"`
+	TaskID   string            `json:"taskId,omitempty"`
+	Task     *TaskContext      `json:"task,omitempty"`
+	Run      *RunRecord        `json:"run,omitempty"`
+	Reason   ExecuteStopReason `json:"reason,omitempty"`
+}
+
+// runHook runs the command configured for event. It reports false when no
+// command is configured.
+func runHook(ctx context.Context, hooks Hooks, event HookEvent, payload hookPayload) (HookResult, bool) {
+	command := strings.TrimSpace(hooks.command(event))
+	if command == "" {
+		return HookResult{}, false
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	timeout := hooks.Timeout
+	if timeout <= 0 {
+		timeout = time.Duration(config.DefaultHookTimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	payload.Event = event
+	result := HookResult{Event: event, Command: command, StartedAt: time.Now().UTC()}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		result.Error = fmt.Sprintf("encode hook payload: %v", err)
+		return result, true
+	}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = plan.ProjectRoot(payload.PlanPath)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(), hookEnv(payload)...)
+	// Don't wait on children that outlive a killed shell and hold its pipes open.
+	cmd.WaitDelay = time.Second
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+	result.DurationMs = time.Since(result.StartedAt).Milliseconds()
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	result.ExitCode = extractExitCode(runErr)
+	if runErr != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			result.Error = fmt.Sprintf("%s hook timed out after %s", event, timeout)
+		} else {
+			result.Error = fmt.Sprintf("%s hook failed: %v", event, runErr)
+		}
+	}
+	return result, true
+}
+
+func hookEnv(payload hookPayload) []string {
+	env := []string{
+		"BLACKBIRD_HOOK=" + string(payload.Event),
+		"BLACKBIRD_PLAN_PATH=" + payload.PlanPath,
+		// Overrides an inherited BLACKBIRD_PLAN, which names the plan the
+		// process started with rather than the one this run belongs to.
+		plan.PlanEnvVar + "=" + payload.PlanPath,
+	}
+	if payload.TaskID != "" {
+		env = append(env, "BLACKBIRD_TASK_ID="+payload.TaskID)
+	}
+	if payload.Run != nil {
+		env = append(env,
+			"BLACKBIRD_RUN_ID="+payload.Run.ID,
+			"BLACKBIRD_RUN_STATUS="+string(payload.Run.Status),
+		)
+	}
+	if payload.Reason != "" {
+		env = append(env, "BLACKBIRD_EXECUTE_REASON="+string(payload.Reason))
+	}
+	return env
+}
+
+// launchWithHooks wraps an agent launch with the pre_task, post_task and
+// status hooks, recording their results on the run, and sends the run
+// webhooks. A failing pre_task hook skips the launch and yields a failed run.
+func launchWithHooks(
+	ctx context.Context,
+	hooks Hooks,
+	webhooks *webhook.Dispatcher,
+	planPath string,
+	provider string,
+	ctxPack ContextPack,
+	launch func() (RunRecord, error),
+) (RunRecord, error) {
+	taskID := ctxPack.Task.ID
+	task := ctxPack.Task
+	var results []HookResult
+
+	if pre, ok := runHook(ctx, hooks, HookPreTask, hookPayload{PlanPath: planPath, TaskID: taskID, Task: &task}); ok {
+		results = append(results, pre)
+		if pre.Failed() {
+			now := time.Now().UTC()
+			record := RunRecord{
+				ID:          newRunID(),
+				TaskID:      taskID,
+				Provider:    provider,
+				StartedAt:   pre.StartedAt,
+				CompletedAt: &now,
+				Status:      RunStatusFailed,
+				Context:     ctxPack,
+				Error:       pre.Error,
+			}
+			record.Hooks = append(results, runStatusHooks(ctx, hooks, planPath, record, false)...)
+			notifyRunFinished(webhooks, planPath, record)
+			return record, errors.New(pre.Error)
+		}
+	}
+
+	webhooks.Notify(webhook.Event{
+		Type:      webhook.EventRunStarted,
+		PlanPath:  planPath,
+		TaskID:    taskID,
+		TaskTitle: task.Title,
+	})
+	record, execErr := launch()
+	if record.ID == "" {
+		return record, execErr
+	}
+	results = append(results, runStatusHooks(ctx, hooks, planPath, record, true)...)
+	record.Hooks = append(record.Hooks, results...)
+	notifyRunFinished(webhooks, planPath, record)
+	return record, execErr
+}
+
+// runStatusHooks runs post_task (when the agent actually ran) and the hook
+// matching the run's outcome.
+func runStatusHooks(ctx context.Context, hooks Hooks, planPath string, record RunRecord, postTask bool) []HookResult {
+	var results []HookResult
+	payload := hookPayload{PlanPath: planPath, TaskID: record.TaskID, Task: &record.Context.Task, Run: &record}
+	if postTask {
+		if res, ok := runHook(ctx, hooks, HookPostTask, payload); ok {
+			results = append(results, res)
+		}
+	}
+	var event HookEvent
+	switch record.Status {
+	case RunStatusWaitingUser:
+		event = HookOnWaitingUser
+	case RunStatusFailed:
+		event = HookOnFailed
+	default:
+		return results
+	}
+	if res, ok := runHook(ctx, hooks, event, payload); ok {
+		results = append(results, res)
+	}
+	return results
+}
+
+// runParentReviewFailedHook sends the parent_review_failed webhook and runs
+// on_parent_review_failed for a review run that requested changes, saving the
+// hook result on the review run.
+func runParentReviewFailedHook(ctx context.Context, hooks Hooks, webhooks *webhook.Dispatcher, planPath string, review RunRecord) RunRecord {
+	webhooks.Notify(runEvent(webhook.EventParentReviewFailed, planPath, review))
+	res, ok := runHook(ctx, hooks, HookOnParentReviewFailed, hookPayload{
+		PlanPath: planPath,
+		TaskID:   review.TaskID,
+		Run:      &review,
+	})
+	if !ok {
+		return review
+	}
+	review.Hooks = append(review.Hooks, res)
+	// The review run is already persisted; a failed re-save only loses the hook output.
+	_ = SaveRun(filepath.Dir(planPath), review)
+	return review
+}
+
+// notifyRunFinished sends run_finished, plus waiting_user when the agent asked
+// questions.
+func notifyRunFinished(webhooks *webhook.Dispatcher, planPath string, record RunRecord) {
+	webhooks.Notify(runEvent(webhook.EventRunFinished, planPath, record))
+	if record.Status == RunStatusWaitingUser {
+		webhooks.Notify(runEvent(webhook.EventWaitingUser, planPath, record))
+	}
+}
+
+func runEvent(eventType webhook.EventType, planPath string, record RunRecord) webhook.Event {
+	ev := webhook.Event{
+		Type:      eventType,
+		PlanPath:  planPath,
+		TaskID:    record.TaskID,
+		TaskTitle: record.Context.Task.Title,
+		RunID:     record.ID,
+		Status:    string(record.Status),
+		Message:   record.Error,
+	}
+	switch eventType {
+	case webhook.EventWaitingUser:
+		if questions, err := ParseQuestions(record.Stdout); err == nil && len(questions) > 0 {
+			ev.Message = questions[0].Prompt
+		}
+	case webhook.EventParentReviewFailed:
+		ev.TaskIDs = ParentReviewFailedTaskIDs(record)
+		ev.Message = record.ParentReviewFeedback
+	}
+	return ev
+}
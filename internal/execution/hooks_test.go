@@ -0,0 +1,193 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/config"
+	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
+)
+
+func saveHookTestPlan(t *testing.T, items map[string]plan.WorkItem) string {
+	t.Helper()
+	planPath := filepath.Join(t.TempDir(), "blackbird.plan.json")
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: items}
+	if err := plan.SaveAtomic(planPath, g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	return planPath
+}
+
+func TestRunExecuteFailingPreTaskHookAbortsTask(t *testing.T) {
+	planPath := saveHookTestPlan(t, map[string]plan.WorkItem{
+		"a": makeItem("a", plan.StatusTodo),
+	})
+	baseDir := filepath.Dir(planPath)
+
+	result, err := RunExecute(context.Background(), ExecuteConfig{
+		PlanPath: planPath,
+		Runtime: agent.Runtime{
+			Command:  "touch agent-ran",
+			UseShell: true,
+			Timeout:  2 * time.Second,
+		},
+		Hooks: Hooks{
+			PreTask:  "echo dirty tree >&2; exit 3",
+			OnFailed: "echo failed $BLACKBIRD_TASK_ID",
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunExecute: %v", err)
+	}
+	if result.Reason != ExecuteReasonCompleted {
+		t.Fatalf("expected completed, got %s", result.Reason)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, "agent-ran")); !os.IsNotExist(err) {
+		t.Fatalf("agent ran despite failing pre_task hook")
+	}
+
+	g, err := plan.Load(planPath)
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if g.Items["a"].Status != plan.StatusFailed {
+		t.Fatalf("expected a failed, got %s", g.Items["a"].Status)
+	}
+
+	runs, err := ListRuns(baseDir, "a")
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("runs = %+v, %v", runs, err)
+	}
+	hooks := runs[0].Hooks
+	if len(hooks) != 2 || hooks[0].Event != HookPreTask || hooks[1].Event != HookOnFailed {
+		t.Fatalf("hooks = %+v", hooks)
+	}
+	if hooks[0].ExitCode == nil || *hooks[0].ExitCode != 3 || hooks[0].Stderr != "dirty tree\n" {
+		t.Fatalf("pre_task result = %+v", hooks[0])
+	}
+	if hooks[1].Stdout != "failed a\n" {
+		t.Fatalf("on_failed stdout = %q", hooks[1].Stdout)
+	}
+	if !strings.Contains(runs[0].Error, "pre_task hook failed") {
+		t.Fatalf("run error = %q", runs[0].Error)
+	}
+}
+
+func TestRunExecuteHooksReceivePayloadAndEnv(t *testing.T) {
+	planPath := saveHookTestPlan(t, map[string]plan.WorkItem{
+		"a": makeItem("a", plan.StatusTodo),
+	})
+	baseDir := filepath.Dir(planPath)
+	t.Setenv(plan.PlanEnvVar, "other")
+
+	result, err := RunExecute(context.Background(), ExecuteConfig{
+		PlanPath: planPath,
+		Runtime:  agent.Runtime{Provider: "test", Command: "cat", Timeout: 2 * time.Second},
+		Hooks: Hooks{
+			PreTask:           "true",
+			PostTask:          `cat > post.json; echo "$BLACKBIRD_HOOK $BLACKBIRD_TASK_ID $BLACKBIRD_RUN_STATUS"`,
+			OnExecuteComplete: `echo "done $BLACKBIRD_EXECUTE_REASON $BLACKBIRD_PLAN"`,
+		},
+	})
+	if err != nil {
+		t.Fatalf("RunExecute: %v", err)
+	}
+	if len(result.Hooks) != 1 || result.Hooks[0].Stdout != "done completed "+planPath+"\n" {
+		t.Fatalf("execute hooks = %+v", result.Hooks)
+	}
+
+	runs, err := ListRuns(baseDir, "a")
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("runs = %+v, %v", runs, err)
+	}
+	hooks := runs[0].Hooks
+	if len(hooks) != 2 || hooks[1].Event != HookPostTask || hooks[1].Stdout != "post_task a success\n" {
+		t.Fatalf("hooks = %+v", hooks)
+	}
+
+	payload, err := os.ReadFile(filepath.Join(baseDir, "post.json"))
+	if err != nil {
+		t.Fatalf("read payload: %v", err)
+	}
+	for _, want := range []string{`"event":"post_task"`, `"taskId":"a"`, `"status":"success"`} {
+		if !strings.Contains(string(payload), want) {
+			t.Fatalf("payload missing %s: %s", want, payload)
+		}
+	}
+}
+
+func TestRunHookTimeout(t *testing.T) {
+	res, ok := runHook(context.Background(), Hooks{PostTask: "sleep 5", Timeout: 50 * time.Millisecond}, HookPostTask, hookPayload{
+		PlanPath: filepath.Join(t.TempDir(), "blackbird.plan.json"),
+	})
+	if !ok || !res.Failed() || !strings.Contains(res.Error, "timed out") {
+		t.Fatalf("result = %+v", res)
+	}
+	if _, ok := runHook(context.Background(), Hooks{}, HookPostTask, hookPayload{}); ok {
+		t.Fatalf("expected unconfigured hook to be skipped")
+	}
+}
+
+func TestRunExecuteSendsWebhooks(t *testing.T) {
+	var mu sync.Mutex
+	var events []webhook.Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev webhook.Event
+		_ = json.NewDecoder(r.Body).Decode(&ev)
+		mu.Lock()
+		events = append(events, ev)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	planPath := saveHookTestPlan(t, map[string]plan.WorkItem{
+		"a": makeItem("a", plan.StatusTodo),
+	})
+	dispatcher := webhook.New(config.ResolvedWebhooks{
+		Endpoints:   []config.RawWebhookEndpoint{{URL: srv.URL}},
+		MaxAttempts: 1,
+	}, filepath.Dir(planPath))
+
+	result, err := RunExecute(context.Background(), ExecuteConfig{
+		PlanPath: planPath,
+		Runtime: agent.Runtime{
+			Command:  `printf '{"tool":"AskUserQuestion","id":"q1","prompt":"Name?"}'`,
+			UseShell: true,
+			Timeout:  2 * time.Second,
+		},
+		Webhooks: dispatcher,
+	})
+	if err != nil || result.Reason != ExecuteReasonWaitingUser {
+		t.Fatalf("RunExecute = %+v, %v", result, err)
+	}
+	dispatcher.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	got := map[webhook.EventType]webhook.Event{}
+	for _, ev := range events {
+		got[ev.Type] = ev
+	}
+	if len(events) != 3 {
+		t.Fatalf("events = %+v", events)
+	}
+	if ev, ok := got[webhook.EventRunStarted]; !ok || ev.TaskID != "a" {
+		t.Fatalf("run_started = %+v", ev)
+	}
+	if ev := got[webhook.EventRunFinished]; ev.Status != string(RunStatusWaitingUser) || ev.RunID == "" {
+		t.Fatalf("run_finished = %+v", ev)
+	}
+	if ev := got[webhook.EventWaitingUser]; ev.Message != "Name?" {
+		t.Fatalf("waiting_user = %+v", ev)
+	}
+}
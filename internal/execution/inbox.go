@@ -0,0 +1,105 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// PendingQuestions holds the unanswered questions of one waiting_user task.
+type PendingQuestions struct {
+	TaskID    string           `json:"taskId"`
+	TaskTitle string           `json:"taskTitle"`
+	RunID     string           `json:"runId"`
+	AskedAt   time.Time        `json:"askedAt"`
+	Questions []agent.Question `json:"questions"`
+}
+
+// ListPendingQuestions collects the questions from the latest waiting run of
+// every waiting_user task, oldest first. Tasks whose waiting run has no
+// parsable questions are skipped.
+func ListPendingQuestions(planPath string, g plan.WorkGraph) ([]PendingQuestions, error) {
+	baseDir := filepath.Dir(planPath)
+	out := []PendingQuestions{}
+	for id, it := range g.Items {
+		if it.Status != plan.StatusWaitingUser {
+			continue
+		}
+		waiting, err := latestWaitingRun(baseDir, id)
+		if err != nil {
+			if IsWaitingRunNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		questions, err := ParseQuestions(waiting.Stdout)
+		if err != nil || len(questions) == 0 {
+			continue
+		}
+		askedAt := waiting.StartedAt
+		if waiting.CompletedAt != nil {
+			askedAt = *waiting.CompletedAt
+		}
+		out = append(out, PendingQuestions{
+			TaskID:    id,
+			TaskTitle: it.Title,
+			RunID:     waiting.ID,
+			AskedAt:   askedAt,
+			Questions: questions,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].AskedAt.Equal(out[j].AskedAt) {
+			return out[i].AskedAt.Before(out[j].AskedAt)
+		}
+		return out[i].TaskID < out[j].TaskID
+	})
+	return out, nil
+}
+
+// AnswerSet holds the answers for one task's pending questions.
+type AnswerSet struct {
+	TaskID  string
+	Answers []agent.Answer
+}
+
+// ResumeAnswered checks every answer set against its waiting run, then
+// resumes the tasks one after another using cfg (TaskID, Answers and Context
+// are set per task). No task is resumed if any answer set is invalid. onResult
+// is called after each resume; a failed resume does not stop the others, but
+// a canceled ctx does.
+func ResumeAnswered(ctx context.Context, cfg ResumeConfig, sets []AnswerSet, onResult func(taskID string, record RunRecord, err error)) error {
+	baseDir := filepath.Dir(cfg.PlanPath)
+	contexts := make([]ContextPack, len(sets))
+	for i, set := range sets {
+		waiting, err := latestWaitingRun(baseDir, set.TaskID)
+		if err != nil {
+			return err
+		}
+		ctxPack, err := ResumeWithAnswer(*waiting, set.Answers)
+		if err != nil {
+			return fmt.Errorf("%s: %w", set.TaskID, err)
+		}
+		contexts[i] = ctxPack
+	}
+
+	for i, set := range sets {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		taskCfg := cfg
+		taskCfg.TaskID = set.TaskID
+		taskCfg.Answers = set.Answers
+		taskCfg.Context = &contexts[i]
+		record, err := RunResume(ctx, taskCfg)
+		if onResult != nil {
+			onResult(set.TaskID, record, err)
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,111 @@
+package execution
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func saveInboxFixture(t *testing.T) string {
+	t.Helper()
+	planPath := saveHookTestPlan(t, map[string]plan.WorkItem{
+		"a":    makeItem("a", plan.StatusWaitingUser),
+		"b":    makeItem("b", plan.StatusWaitingUser),
+		"c":    makeItem("c", plan.StatusTodo),
+		"none": makeItem("none", plan.StatusWaitingUser),
+	})
+	baseDir := filepath.Dir(planPath)
+	waiting := func(taskID string, startedAt time.Time, stdout string) {
+		t.Helper()
+		run := RunRecord{
+			ID:        "run-" + taskID,
+			TaskID:    taskID,
+			StartedAt: startedAt,
+			Status:    RunStatusWaitingUser,
+			Stdout:    stdout,
+			Context: ContextPack{
+				SchemaVersion: ContextPackSchemaVersion,
+				Task:          TaskContext{ID: taskID, Title: "Task " + taskID},
+			},
+		}
+		if err := SaveRun(baseDir, run); err != nil {
+			t.Fatalf("SaveRun: %v", err)
+		}
+	}
+	waiting("a", time.Date(2026, 1, 30, 14, 0, 0, 0, time.UTC), `{"tool":"AskUserQuestion","id":"q1","prompt":"Name?"}`)
+	waiting("b", time.Date(2026, 1, 30, 13, 0, 0, 0, time.UTC), `{"tool":"AskUserQuestion","id":"db","prompt":"Which DB?","options":["pg","sqlite"]}`)
+	waiting("c", time.Date(2026, 1, 30, 12, 0, 0, 0, time.UTC), `{"tool":"AskUserQuestion","id":"q1","prompt":"Stale?"}`)
+	return planPath
+}
+
+func TestListPendingQuestions(t *testing.T) {
+	planPath := saveInboxFixture(t)
+	g, err := plan.Load(planPath)
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+
+	pending, err := ListPendingQuestions(planPath, g)
+	if err != nil {
+		t.Fatalf("ListPendingQuestions: %v", err)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("pending = %+v, want a and b (c is not waiting, none has no run)", pending)
+	}
+	if pending[0].TaskID != "b" || pending[1].TaskID != "a" {
+		t.Fatalf("order = %s, %s; want oldest first", pending[0].TaskID, pending[1].TaskID)
+	}
+	if pending[0].RunID != "run-b" || pending[0].TaskTitle != "Task b" || len(pending[0].Questions[0].Options) != 2 {
+		t.Fatalf("pending[0] = %+v", pending[0])
+	}
+}
+
+func TestResumeAnsweredValidatesBeforeResuming(t *testing.T) {
+	planPath := saveInboxFixture(t)
+	cfg := ResumeConfig{
+		PlanPath: planPath,
+		Runtime:  agent.Runtime{Provider: "test", Command: "cat", Timeout: 2 * time.Second},
+	}
+
+	var resumed []string
+	onResult := func(taskID string, record RunRecord, err error) {
+		if err != nil {
+			t.Fatalf("resume %s: %v", taskID, err)
+		}
+		resumed = append(resumed, taskID+":"+string(record.Status))
+	}
+
+	err := ResumeAnswered(context.Background(), cfg, []AnswerSet{
+		{TaskID: "a", Answers: []agent.Answer{{ID: "q1", Value: "Ada"}}},
+		{TaskID: "b", Answers: []agent.Answer{{ID: "db", Value: "mysql"}}},
+	}, onResult)
+	if err == nil || !strings.Contains(err.Error(), "not in options") {
+		t.Fatalf("expected option validation error, got %v", err)
+	}
+	if len(resumed) != 0 {
+		t.Fatalf("resumed %v despite invalid answers", resumed)
+	}
+
+	err = ResumeAnswered(context.Background(), cfg, []AnswerSet{
+		{TaskID: "b", Answers: []agent.Answer{{ID: "db", Value: "pg"}}},
+		{TaskID: "a", Answers: []agent.Answer{{ID: "q1", Value: "Ada"}}},
+	}, onResult)
+	if err != nil {
+		t.Fatalf("ResumeAnswered: %v", err)
+	}
+	if strings.Join(resumed, ",") != "b:success,a:success" {
+		t.Fatalf("resumed = %v", resumed)
+	}
+	g, err := plan.Load(planPath)
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if g.Items["a"].Status != plan.StatusDone || g.Items["b"].Status != plan.StatusDone {
+		t.Fatalf("statuses a=%s b=%s", g.Items["a"].Status, g.Items["b"].Status)
+	}
+}
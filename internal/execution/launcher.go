@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
 )
 
 // LaunchAgent executes the agent command with the provided context pack.
@@ -23,13 +24,13 @@ func LaunchAgent(ctx context.Context, runtime agent.Runtime, contextPack Context
 }
 
 // LaunchAgentWithStream executes the agent command with optional live output streaming.
+// Transient failures (non-zero exit with a rate-limit or network marker in
+// stderr) are retried up to runtime.MaxRetries times with exponential backoff;
+// every attempt of a retried run is recorded in RunRecord.Attempts.
 func LaunchAgentWithStream(ctx context.Context, runtime agent.Runtime, contextPack ContextPack, stream StreamConfig) (RunRecord, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	if runtime.Timeout == 0 {
-		runtime.Timeout = agent.DefaultTimeout
-	}
 	runtime = applySelectedProvider(runtime)
 	if runtime.Command == "" {
 		return RunRecord{}, fmt.Errorf("agent command required")
@@ -43,51 +44,19 @@ func LaunchAgentWithStream(ctx context.Context, runtime agent.Runtime, contextPa
 		return RunRecord{}, fmt.Errorf("encode context pack: %w", err)
 	}
 
-	start := time.Now().UTC()
 	record := RunRecord{
 		ID:        newRunID(),
 		TaskID:    contextPack.Task.ID,
 		Provider:  runtime.Provider,
-		StartedAt: start,
+		StartedAt: time.Now().UTC(),
 		Status:    RunStatusRunning,
 		Context:   contextPack,
 	}
-	sessionRef := ""
-	if supportsResumeProvider(record.Provider) {
-		switch normalizeProvider(record.Provider) {
-		case "claude":
-			if !runtime.UseShell {
-				sessionRef = newSessionID()
-				record.ProviderSessionRef = sessionRef
-			}
-		case "codex":
-			record.ProviderSessionRef = record.ID
-		}
-	}
-
-	ctx, cancel := context.WithTimeout(ctx, runtime.Timeout)
-	defer cancel()
-
-	var cmd *exec.Cmd
-	if runtime.UseShell {
-		cmd = exec.CommandContext(ctx, "sh", "-c", runtime.Command)
-	} else {
-		args := append([]string{}, runtime.Args...)
-		args = buildLaunchArgs(runtime.Provider, args, sessionRef)
-		cmd = exec.CommandContext(ctx, runtime.Command, args...)
-	}
-	cmd.Stdin = bytes.NewReader(payload)
 
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = streamWriter(&stdout, stream.Stdout, os.Stdout)
-	cmd.Stderr = streamWriter(&stderr, stream.Stderr, os.Stderr)
-
-	execErr := cmd.Run()
-	completed := time.Now().UTC()
-	record.CompletedAt = &completed
-	record.Stdout = stdout.String()
-	record.Stderr = stderr.String()
+	execErr := runWithRetries(ctx, runtime, &record, func() (bool, error) {
+		sessionRef := assignLaunchSessionRef(runtime, &record)
+		return runLaunchAttempt(ctx, runtime, payload, contextPack.Task.ID, sessionRef, stream, &record)
+	})
 
 	questions, qErr := ParseQuestions(record.Stdout)
 	if qErr != nil {
@@ -114,13 +83,133 @@ func LaunchAgentWithStream(ctx context.Context, runtime agent.Runtime, contextPa
 	return record, nil
 }
 
+// runWithRetries calls attempt, which runs the agent once and stores its
+// output on record, until it succeeds or fails other than transiently:
+// a non-zero exit with a rate-limit or network marker in stderr, without
+// hitting the task timeout. Transient failures are retried up to
+// runtime.MaxRetries times with exponential backoff, and every attempt of a
+// retried run is recorded in record.Attempts. It returns the error of the last
+// attempt.
+func runWithRetries(ctx context.Context, runtime agent.Runtime, record *RunRecord, attempt func() (timedOut bool, err error)) error {
+	var attempts []RunAttempt
+	for n := 1; ; n++ {
+		attemptStart := time.Now().UTC()
+		timedOut, execErr := attempt()
+		completed := time.Now().UTC()
+		record.CompletedAt = &completed
+
+		current := RunAttempt{
+			StartedAt:   attemptStart,
+			CompletedAt: completed,
+			ExitCode:    extractExitCode(execErr),
+		}
+		if execErr != nil {
+			current.Error = execErr.Error()
+		}
+		retry := n <= runtime.MaxRetries && execErr != nil && !timedOut && ctx.Err() == nil &&
+			current.ExitCode != nil && *current.ExitCode != 0 && agent.IsTransientFailure(record.Stderr)
+		if !retry {
+			if len(attempts) > 0 {
+				record.Attempts = append(attempts, current)
+			}
+			return execErr
+		}
+		// Keep the stderr of retried attempts; the final attempt's output is on the record.
+		current.Stderr = record.Stderr
+		current.Transient = true
+		attempts = append(attempts, current)
+		if err := agent.SleepContext(ctx, agent.RetryDelay(runtime.RetryBackoff, n)); err != nil {
+			record.Attempts = attempts
+			return execErr
+		}
+	}
+}
+
+// assignLaunchSessionRef sets the provider session reference for a new
+// attempt. Claude gets a fresh session id per attempt.
+func assignLaunchSessionRef(runtime agent.Runtime, record *RunRecord) string {
+	if !supportsResumeProvider(record.Provider) {
+		return ""
+	}
+	switch normalizeProvider(record.Provider) {
+	case "claude":
+		if !runtime.UseShell {
+			record.ProviderSessionRef = newSessionID()
+			return record.ProviderSessionRef
+		}
+	case "codex":
+		record.ProviderSessionRef = record.ID
+	}
+	return ""
+}
+
+// runLaunchAttempt runs the agent command once, storing its output on record.
+// timedOut reports that the attempt hit runtime.TaskTimeout.
+func runLaunchAttempt(ctx context.Context, runtime agent.Runtime, payload []byte, taskID string, sessionRef string, stream StreamConfig, record *RunRecord) (timedOut bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, runtime.TaskTimeout())
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.UseShell {
+		cmd = exec.CommandContext(ctx, "sh", "-c", runtime.Command)
+	} else {
+		args := append([]string{}, runtime.Args...)
+		args = buildLaunchArgs(runtime.Provider, args, sessionRef)
+		if runtime.MCP {
+			args = withMCPServer(runtime.Provider, args, stream.PlanPath, taskID)
+		}
+		cmd = exec.CommandContext(ctx, runtime.Command, args...)
+	}
+	cmd.Stdin = bytes.NewReader(payload)
+
+	// Each attempt starts its logs afresh; earlier attempts keep their stderr
+	// in RunRecord.Attempts.
+	logs, err := openRunLogs(stream.BaseDir, record)
+	if err != nil {
+		return false, err
+	}
+	defer logs.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = streamWriter(&stdout, logs.stdoutWriter(), stream.Stdout, os.Stdout)
+	cmd.Stderr = streamWriter(&stderr, logs.stderrWriter(), stream.Stderr, os.Stderr)
+
+	err = cmd.Run()
+	setRunOutput(record, stdout.String(), stderr.String())
+	return errors.Is(ctx.Err(), context.DeadlineExceeded), err
+}
+
+// runtimeForItem applies the item's agent overrides to runtime.
+func runtimeForItem(runtime agent.Runtime, it plan.WorkItem) agent.Runtime {
+	if it.Agent == nil {
+		return runtime
+	}
+	if it.Agent.TimeoutSeconds != nil {
+		runtime.ExecutionTimeout = time.Duration(*it.Agent.TimeoutSeconds) * time.Second
+	}
+	if it.Agent.MaxRetries != nil {
+		runtime.MaxRetries = *it.Agent.MaxRetries
+	}
+	return runtime
+}
+
 type StreamConfig struct {
 	Stdout io.Writer
 	Stderr io.Writer
+	// BaseDir, when set, streams output to <runID>.stdout.log and
+	// <runID>.stderr.log next to the run record while the agent runs.
+	BaseDir string
+	// PlanPath, when set, is the plan the run belongs to; the agent's MCP
+	// server is pointed at it.
+	PlanPath string
 }
 
-func streamWriter(buf *bytes.Buffer, cfgWriter io.Writer, envWriter io.Writer) io.Writer {
+func streamWriter(buf *bytes.Buffer, logWriter io.Writer, cfgWriter io.Writer, envWriter io.Writer) io.Writer {
 	writers := []io.Writer{buf}
+	if logWriter != nil {
+		writers = append(writers, logWriter)
+	}
 	if cfgWriter != nil {
 		writers = append(writers, cfgWriter)
 	}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
 )
 
 func TestLaunchAgentSuccess(t *testing.T) {
@@ -274,3 +275,111 @@ func readFileString(t *testing.T, path string) string {
 	}
 	return string(data)
 }
+
+func TestMCPServerArgs(t *testing.T) {
+	claude := mcpServerArgs("claude", []string{"--permission-mode", "bypassPermissions"}, "/bin/blackbird", "", "t1")
+	if len(claude) != 4 || claude[2] != "--mcp-config" {
+		t.Fatalf("claude args = %v", claude)
+	}
+	wantConfig := `{"mcpServers":{"blackbird":{"args":["mcp","--task","t1"],"command":"/bin/blackbird"}}}`
+	if claude[3] != wantConfig {
+		t.Fatalf("claude config = %s", claude[3])
+	}
+
+	codex := mcpServerArgs("codex", []string{"exec", "--full-auto"}, "/bin/blackbird", "", "t1")
+	wantCodex := []string{
+		"exec",
+		"-c", `mcp_servers.blackbird.command="/bin/blackbird"`,
+		"-c", `mcp_servers.blackbird.args=["mcp","--task","t1"]`,
+		"--full-auto",
+	}
+	if !reflect.DeepEqual(codex, wantCodex) {
+		t.Fatalf("codex args = %v", codex)
+	}
+
+	if got := mcpServerArgs("other", []string{"x"}, "/bin/blackbird", "", "t1"); !reflect.DeepEqual(got, []string{"x"}) {
+		t.Fatalf("unknown provider args = %v", got)
+	}
+
+	named := mcpServerArgs("codex", []string{"exec"}, "/bin/blackbird", "/repo/.blackbird/plans/m/blackbird.plan.json", "t1")
+	if want := `mcp_servers.blackbird.args=["--plan","/repo/.blackbird/plans/m/blackbird.plan.json","mcp","--task","t1"]`; named[4] != want {
+		t.Fatalf("codex args with plan = %v", named)
+	}
+}
+
+func TestLaunchAgentRetriesTransientFailures(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "count")
+	// Fails with a rate-limit message twice, then succeeds.
+	runtime := agent.Runtime{
+		Provider: "test",
+		Command: `n=$(cat ` + counter + ` 2>/dev/null || echo 0); n=$((n+1)); echo $n > ` + counter + `;` +
+			` if [ $n -lt 3 ]; then echo "429 rate limit exceeded" >&2; exit 1; fi; echo done`,
+		UseShell:     true,
+		Timeout:      2 * time.Second,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+	ctx := ContextPack{
+		SchemaVersion: ContextPackSchemaVersion,
+		Task:          TaskContext{ID: "task-retry", Title: "Task"},
+	}
+
+	record, err := LaunchAgent(context.Background(), runtime, ctx)
+	if err != nil {
+		t.Fatalf("LaunchAgent: %v", err)
+	}
+	if record.Status != RunStatusSuccess || strings.TrimSpace(record.Stdout) != "done" {
+		t.Fatalf("record = %+v", record)
+	}
+	if len(record.Attempts) != 3 {
+		t.Fatalf("attempts = %+v, want 3", record.Attempts)
+	}
+	first := record.Attempts[0]
+	if !first.Transient || first.ExitCode == nil || *first.ExitCode != 1 || !strings.Contains(first.Stderr, "rate limit") {
+		t.Fatalf("first attempt = %+v", first)
+	}
+	if last := record.Attempts[2]; last.Transient || last.Error != "" {
+		t.Fatalf("last attempt = %+v", last)
+	}
+}
+
+func TestLaunchAgentDoesNotRetryOtherFailures(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "count")
+	runtime := agent.Runtime{
+		Provider:     "test",
+		Command:      `echo x >> ` + counter + `; echo "syntax error" >&2; exit 2`,
+		UseShell:     true,
+		Timeout:      2 * time.Second,
+		MaxRetries:   3,
+		RetryBackoff: time.Millisecond,
+	}
+	ctx := ContextPack{
+		SchemaVersion: ContextPackSchemaVersion,
+		Task:          TaskContext{ID: "task-fail", Title: "Task"},
+	}
+
+	record, err := LaunchAgent(context.Background(), runtime, ctx)
+	if err == nil || record.Status != RunStatusFailed {
+		t.Fatalf("expected failure, got %+v, %v", record, err)
+	}
+	if len(record.Attempts) != 0 {
+		t.Fatalf("attempts = %+v, want none recorded", record.Attempts)
+	}
+	runs, _ := os.ReadFile(counter)
+	if strings.Count(string(runs), "x") != 1 {
+		t.Fatalf("command ran %d times, want 1", strings.Count(string(runs), "x"))
+	}
+}
+
+func TestRuntimeForItemAppliesOverrides(t *testing.T) {
+	timeout, retries := 30, 0
+	base := agent.Runtime{Timeout: time.Hour, MaxRetries: 2}
+
+	got := runtimeForItem(base, plan.WorkItem{Agent: &plan.AgentOverrides{TimeoutSeconds: &timeout, MaxRetries: &retries}})
+	if got.TaskTimeout() != 30*time.Second || got.MaxRetries != 0 || got.Timeout != time.Hour {
+		t.Fatalf("runtime = %+v", got)
+	}
+	if got := runtimeForItem(base, plan.WorkItem{}); got.TaskTimeout() != time.Hour || got.MaxRetries != 2 {
+		t.Fatalf("runtime without overrides = %+v", got)
+	}
+}
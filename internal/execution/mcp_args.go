@@ -0,0 +1,55 @@
+package execution
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// mcpServerName is the name the blackbird MCP server is registered under.
+const mcpServerName = "blackbird"
+
+// withMCPServer registers `blackbird mcp --task <taskID>` with a claude or
+// codex invocation, passing --plan so the server opens planPath rather than
+// whatever plan the agent's environment names. Other providers, and failures
+// to locate the blackbird binary, leave args unchanged.
+func withMCPServer(provider string, args []string, planPath string, taskID string) []string {
+	exe, err := os.Executable()
+	if err != nil {
+		return args
+	}
+	return mcpServerArgs(provider, args, exe, planPath, taskID)
+}
+
+func mcpServerArgs(provider string, args []string, exe string, planPath string, taskID string) []string {
+	serverArgs := []string{"mcp", "--task", taskID}
+	if planPath != "" {
+		serverArgs = append([]string{"--plan", planPath}, serverArgs...)
+	}
+	switch normalizeProvider(provider) {
+	case "claude":
+		config, err := json.Marshal(map[string]any{
+			"mcpServers": map[string]any{
+				mcpServerName: map[string]any{"command": exe, "args": serverArgs},
+			},
+		})
+		if err != nil {
+			return args
+		}
+		// --mcp-config takes a variadic list, so it must come last.
+		return append(append([]string{}, args...), "--mcp-config", string(config))
+	case "codex":
+		if len(args) == 0 || args[0] != "exec" {
+			return args
+		}
+		// Codex -c values are TOML; JSON strings and arrays are valid TOML.
+		command, _ := json.Marshal(exe)
+		list, _ := json.Marshal(serverArgs)
+		out := []string{"exec",
+			"-c", "mcp_servers." + mcpServerName + ".command=" + string(command),
+			"-c", "mcp_servers." + mcpServerName + ".args=" + string(list),
+		}
+		return append(out, args[1:]...)
+	default:
+		return args
+	}
+}
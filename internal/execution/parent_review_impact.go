@@ -0,0 +1,54 @@
+package execution
+
+import (
+	"fmt"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// InvalidatedParentReview is a recorded parent review that matches the plan
+// before a change but would no longer match after it.
+type InvalidatedParentReview struct {
+	ParentTaskID string
+	RunID        string
+	// Reason is "parent removed", "children incomplete" or "child completions changed".
+	Reason string
+}
+
+// ParentReviewsInvalidatedBy checks the latest review run of each parent in
+// parentTaskIDs. A review is reported when its completion signature matches
+// before but not after. Reviews already stale before the change, or parents
+// never reviewed, are skipped.
+func ParentReviewsInvalidatedBy(baseDir string, before, after plan.WorkGraph, parentTaskIDs []string) ([]InvalidatedParentReview, error) {
+	if baseDir == "" {
+		return nil, fmt.Errorf("baseDir required")
+	}
+	out := []InvalidatedParentReview{}
+	for _, parentTaskID := range parentTaskIDs {
+		latest, err := GetLatestReviewRun(baseDir, parentTaskID)
+		if err != nil {
+			return nil, err
+		}
+		if latest == nil || latest.ParentReviewCompletionSignature == "" {
+			continue
+		}
+		beforeSignature, err := parentReviewCompletionSignatureForTask(before, parentTaskID)
+		if err != nil || beforeSignature != latest.ParentReviewCompletionSignature {
+			continue
+		}
+
+		reason := ""
+		if _, ok := after.Items[parentTaskID]; !ok {
+			reason = "parent removed"
+		} else if afterSignature, err := parentReviewCompletionSignatureForTask(after, parentTaskID); err != nil {
+			reason = "children incomplete"
+		} else if afterSignature != beforeSignature {
+			reason = "child completions changed"
+		}
+		if reason == "" {
+			continue
+		}
+		out = append(out, InvalidatedParentReview{ParentTaskID: parentTaskID, RunID: latest.ID, Reason: reason})
+	}
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package execution
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestParentReviewsInvalidatedBy(t *testing.T) {
+	baseDir := t.TempDir()
+	before, childB, midParentID, rootParentID := parentReviewGateTestGraph()
+
+	signature, err := parentReviewCompletionSignatureForTask(before, midParentID)
+	if err != nil {
+		t.Fatalf("signature: %v", err)
+	}
+	startedAt := time.Date(2026, 2, 9, 10, 0, 0, 0, time.UTC)
+	if err := SaveRun(baseDir, parentReviewGateTestReviewRun(midParentID, "review-1", signature, startedAt)); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+
+	got, err := ParentReviewsInvalidatedBy(baseDir, before, plan.Clone(before), []string{midParentID, rootParentID})
+	if err != nil {
+		t.Fatalf("ParentReviewsInvalidatedBy unchanged: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("unchanged graph invalidated %+v", got)
+	}
+
+	reopened := plan.Clone(before)
+	child := reopened.Items[childB]
+	child.Status = plan.StatusTodo
+	reopened.Items[childB] = child
+	got, err = ParentReviewsInvalidatedBy(baseDir, before, reopened, []string{midParentID, rootParentID})
+	if err != nil {
+		t.Fatalf("ParentReviewsInvalidatedBy reopened: %v", err)
+	}
+	want := []InvalidatedParentReview{{ParentTaskID: midParentID, RunID: "review-1", Reason: "children incomplete"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalidated = %+v, want %+v", got, want)
+	}
+
+	removed := plan.Clone(before)
+	delete(removed.Items, childB)
+	parent := removed.Items[midParentID]
+	parent.ChildIDs = []string{"child-a"}
+	removed.Items[midParentID] = parent
+	got, err = ParentReviewsInvalidatedBy(baseDir, before, removed, []string{midParentID})
+	if err != nil {
+		t.Fatalf("ParentReviewsInvalidatedBy removed: %v", err)
+	}
+	if len(got) != 1 || got[0].Reason != "child completions changed" {
+		t.Fatalf("invalidated = %+v", got)
+	}
+}
@@ -41,9 +41,11 @@ func RunParentReview(ctx context.Context, cfg ParentReviewRunConfig) (RunRecord,
 		return RunRecord{}, err
 	}
 
-	record, execErr := LaunchAgentWithStream(ctx, cfg.Runtime, ctxPack, StreamConfig{
-		Stdout: cfg.StreamStdout,
-		Stderr: cfg.StreamStderr,
+	record, execErr := LaunchAgentWithStream(ctx, runtimeForItem(cfg.Runtime, cfg.Graph.Items[cfg.ParentTaskID]), ctxPack, StreamConfig{
+		Stdout:   cfg.StreamStdout,
+		Stderr:   cfg.StreamStderr,
+		BaseDir:  baseDir,
+		PlanPath: cfg.PlanPath,
 	})
 	if record.ID == "" {
 		if execErr != nil {
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 )
 
 // ListRuns returns all run records for a task, sorted by StartedAt and ID.
@@ -28,7 +29,7 @@ func ListRuns(baseDir, taskID string) ([]RunRecord, error) {
 
 	records := make([]RunRecord, 0, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
 			continue
 		}
 		path := filepath.Join(dir, entry.Name())
@@ -51,7 +52,8 @@ func ListRuns(baseDir, taskID string) ([]RunRecord, error) {
 	return records, nil
 }
 
-// LoadRun loads a specific run record by task ID and run ID.
+// LoadRun loads a specific run record by task ID and run ID, including its
+// full output.
 func LoadRun(baseDir, taskID, runID string) (RunRecord, error) {
 	if baseDir == "" {
 		return RunRecord{}, fmt.Errorf("baseDir required")
@@ -74,7 +76,7 @@ func LoadRun(baseDir, taskID, runID string) (RunRecord, error) {
 		return RunRecord{}, fmt.Errorf("decode run record: %w", err)
 	}
 
-	return record, nil
+	return LoadRunOutput(baseDir, record)
 }
 
 // GetLatestRun returns the most recent run for a task, or nil if none exist.
@@ -167,3 +169,28 @@ func runRecordLess(left, right RunRecord) bool {
 	}
 	return left.Type < right.Type
 }
+
+// AverageRunDurations returns the mean duration of the successful execute
+// runs of each task. Tasks without such runs are omitted.
+func AverageRunDurations(baseDir string, taskIDs []string) (map[string]time.Duration, error) {
+	out := map[string]time.Duration{}
+	for _, taskID := range taskIDs {
+		records, err := ListRuns(baseDir, taskID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", taskID, err)
+		}
+		var total time.Duration
+		count := 0
+		for _, record := range records {
+			if isReviewRun(record) || record.Status != RunStatusSuccess || record.CompletedAt == nil {
+				continue
+			}
+			total += record.CompletedAt.Sub(record.StartedAt)
+			count++
+		}
+		if count > 0 {
+			out[taskID] = total / time.Duration(count)
+		}
+	}
+	return out, nil
+}
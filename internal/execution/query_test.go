@@ -336,3 +336,38 @@ func testRunRecord(taskID, runID string, runType RunType, startedAt time.Time, s
 		ParentReviewCompletionSignature: signature,
 	}
 }
+
+func TestAverageRunDurations(t *testing.T) {
+	baseDir := t.TempDir()
+	start := time.Date(2026, 1, 28, 10, 0, 0, 0, time.UTC)
+	run := func(id string, status RunStatus, runType RunType, minutes int) RunRecord {
+		completed := start.Add(time.Duration(minutes) * time.Minute)
+		return RunRecord{
+			ID:          id,
+			TaskID:      "task-1",
+			Type:        runType,
+			StartedAt:   start,
+			CompletedAt: &completed,
+			Status:      status,
+			Context:     ContextPack{SchemaVersion: ContextPackSchemaVersion, Task: TaskContext{ID: "task-1"}},
+		}
+	}
+	for _, record := range []RunRecord{
+		run("run-1", RunStatusSuccess, RunTypeExecute, 10),
+		run("run-2", RunStatusSuccess, "", 20),
+		run("run-3", RunStatusFailed, RunTypeExecute, 90),
+		run("run-4", RunStatusSuccess, RunTypeReview, 90),
+	} {
+		if err := SaveRun(baseDir, record); err != nil {
+			t.Fatalf("SaveRun: %v", err)
+		}
+	}
+
+	durations, err := AverageRunDurations(baseDir, []string{"task-1", "task-2"})
+	if err != nil {
+		t.Fatalf("AverageRunDurations: %v", err)
+	}
+	if len(durations) != 1 || durations["task-1"] != 15*time.Minute {
+		t.Fatalf("durations = %v, want task-1 15m only", durations)
+	}
+}
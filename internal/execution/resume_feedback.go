@@ -14,6 +14,8 @@ import (
 )
 
 // ResumeWithFeedback resumes a provider session and injects feedback as the follow-up prompt.
+// Transient failures are retried as in LaunchAgentWithStream, resuming the
+// same session each time.
 func ResumeWithFeedback(ctx context.Context, runtime agent.Runtime, previous RunRecord, feedback string, stream StreamConfig) (RunRecord, error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -39,9 +41,6 @@ func ResumeWithFeedback(ctx context.Context, runtime agent.Runtime, previous Run
 		return RunRecord{}, fmt.Errorf("resume with feedback requires provider session ref for run %q", previous.ID)
 	}
 
-	if runtime.Timeout == 0 {
-		runtime.Timeout = agent.DefaultTimeout
-	}
 	if runtime.Command == "" {
 		cmd, ok := defaultProviderCommand(provider)
 		if !ok {
@@ -54,6 +53,9 @@ func ResumeWithFeedback(ctx context.Context, runtime agent.Runtime, previous Run
 	if err != nil {
 		return RunRecord{}, err
 	}
+	if runtime.MCP && !runtime.UseShell {
+		args = withMCPServer(provider, args, stream.PlanPath, previous.TaskID)
+	}
 
 	start := time.Now().UTC()
 	ctxPack := previous.Context
@@ -70,25 +72,9 @@ func ResumeWithFeedback(ctx context.Context, runtime agent.Runtime, previous Run
 		Context:            ctxPack,
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, runtime.Timeout)
-	defer cancel()
-
-	cmd, err := buildResumeCommand(ctx, runtime, args)
-	if err != nil {
-		return RunRecord{}, err
-	}
-	cmd.Stdin = strings.NewReader(feedback)
-
-	var stdout bytes.Buffer
-	var stderr bytes.Buffer
-	cmd.Stdout = streamWriter(&stdout, stream.Stdout, os.Stdout)
-	cmd.Stderr = streamWriter(&stderr, stream.Stderr, os.Stderr)
-
-	execErr := cmd.Run()
-	completed := time.Now().UTC()
-	record.CompletedAt = &completed
-	record.Stdout = stdout.String()
-	record.Stderr = stderr.String()
+	execErr := runWithRetries(ctx, runtime, &record, func() (bool, error) {
+		return runResumeAttempt(ctx, runtime, args, feedback, stream, &record)
+	})
 
 	questions, qErr := ParseQuestions(record.Stdout)
 	if qErr != nil {
@@ -115,6 +101,34 @@ func ResumeWithFeedback(ctx context.Context, runtime agent.Runtime, previous Run
 	return record, nil
 }
 
+// runResumeAttempt runs the resume command once, storing its output on
+// record. timedOut reports that the attempt hit runtime.TaskTimeout.
+func runResumeAttempt(ctx context.Context, runtime agent.Runtime, args []string, feedback string, stream StreamConfig, record *RunRecord) (timedOut bool, err error) {
+	ctx, cancel := context.WithTimeout(ctx, runtime.TaskTimeout())
+	defer cancel()
+
+	cmd, err := buildResumeCommand(ctx, runtime, args)
+	if err != nil {
+		return false, err
+	}
+	cmd.Stdin = strings.NewReader(feedback)
+
+	logs, err := openRunLogs(stream.BaseDir, record)
+	if err != nil {
+		return false, err
+	}
+	defer logs.Close()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+	cmd.Stdout = streamWriter(&stdout, logs.stdoutWriter(), stream.Stdout, os.Stdout)
+	cmd.Stderr = streamWriter(&stderr, logs.stderrWriter(), stream.Stderr, os.Stderr)
+
+	err = cmd.Run()
+	setRunOutput(record, stdout.String(), stderr.String())
+	return errors.Is(ctx.Err(), context.DeadlineExceeded), err
+}
+
 func resumeArgs(provider, sessionRef string, extra []string) ([]string, error) {
 	switch normalizeProvider(provider) {
 	case "codex":
@@ -114,6 +114,47 @@ func TestResumeWithFeedbackClaude(t *testing.T) {
 	assertParentReviewFeedbackPayload(t, record.Context, wantFeedback)
 }
 
+func TestResumeWithFeedbackRetriesTransientFailures(t *testing.T) {
+	dir := t.TempDir()
+	counter := filepath.Join(dir, "count")
+	stdinPath := filepath.Join(dir, "stdin")
+	// Fails with a rate-limit message once, then succeeds. The resume args
+	// are appended to the command, so they follow the final echo.
+	runtime := agent.Runtime{
+		Provider: "codex",
+		Command: `n=$(cat ` + counter + ` 2>/dev/null || echo 0); n=$((n+1)); echo $n > ` + counter + `; cat > ` + stdinPath + `;` +
+			` if [ $n -lt 2 ]; then echo "429 rate limit exceeded" >&2; exit 1; fi; echo done`,
+		UseShell:     true,
+		Timeout:      2 * time.Second,
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	}
+	prev := RunRecord{
+		ID:                 "run-1",
+		TaskID:             "task-1",
+		Provider:           "codex",
+		ProviderSessionRef: "session-123",
+		Context:            ContextPack{SchemaVersion: ContextPackSchemaVersion, Task: TaskContext{ID: "task-1", Title: "Task"}},
+	}
+
+	record, err := ResumeWithFeedback(context.Background(), runtime, prev, "fix this", StreamConfig{})
+	if err != nil {
+		t.Fatalf("ResumeWithFeedback: %v", err)
+	}
+	if record.Status != RunStatusSuccess || !strings.HasPrefix(record.Stdout, "done") {
+		t.Fatalf("record = %+v", record)
+	}
+	if len(record.Attempts) != 2 {
+		t.Fatalf("attempts = %+v, want 2", record.Attempts)
+	}
+	if first := record.Attempts[0]; !first.Transient || !strings.Contains(first.Stderr, "rate limit") {
+		t.Fatalf("first attempt = %+v", first)
+	}
+	if stdin := readFile(t, stdinPath); stdin != "fix this" {
+		t.Fatalf("retried attempt stdin = %q, want the feedback", stdin)
+	}
+}
+
 func TestResumeWithFeedbackRequiresFeedback(t *testing.T) {
 	runtime := agent.Runtime{
 		Provider: "codex",
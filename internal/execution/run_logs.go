@@ -0,0 +1,143 @@
+package execution
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"unicode/utf8"
+)
+
+// runLogTailBytes is the size of the stdout/stderr excerpt kept on the run
+// record when the full output lives in log files.
+const runLogTailBytes = 4096
+
+// runLogs holds the log files a run streams its output to.
+type runLogs struct {
+	stdout *os.File
+	stderr *os.File
+}
+
+// openRunLogs creates (or truncates) the stdout/stderr log files for record
+// under baseDir and references them on the record. A nil result with a nil
+// error means baseDir is empty and output stays inline.
+func openRunLogs(baseDir string, record *RunRecord) (*runLogs, error) {
+	if baseDir == "" {
+		return nil, nil
+	}
+	dir := filepath.Join(baseDir, runsDirName, record.TaskID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create run directory: %w", err)
+	}
+	stdoutName := record.ID + ".stdout.log"
+	stderrName := record.ID + ".stderr.log"
+	stdout, err := os.Create(filepath.Join(dir, stdoutName))
+	if err != nil {
+		return nil, fmt.Errorf("create stdout log: %w", err)
+	}
+	stderr, err := os.Create(filepath.Join(dir, stderrName))
+	if err != nil {
+		_ = stdout.Close()
+		return nil, fmt.Errorf("create stderr log: %w", err)
+	}
+	record.StdoutLog = stdoutName
+	record.StderrLog = stderrName
+	return &runLogs{stdout: stdout, stderr: stderr}, nil
+}
+
+func (l *runLogs) stdoutWriter() io.Writer {
+	if l == nil {
+		return nil
+	}
+	return l.stdout
+}
+
+func (l *runLogs) stderrWriter() io.Writer {
+	if l == nil {
+		return nil
+	}
+	return l.stderr
+}
+
+func (l *runLogs) Close() error {
+	if l == nil {
+		return nil
+	}
+	errOut := l.stdout.Close()
+	errErr := l.stderr.Close()
+	if errOut != nil {
+		return errOut
+	}
+	return errErr
+}
+
+// setRunOutput stores captured output on record. Records backed by log files
+// also get byte counts and tail excerpts, which is what SaveRun persists.
+func setRunOutput(record *RunRecord, stdout, stderr string) {
+	record.Stdout = stdout
+	record.Stderr = stderr
+	if record.StdoutLog == "" && record.StderrLog == "" {
+		return
+	}
+	record.StdoutBytes = int64(len(stdout))
+	record.StderrBytes = int64(len(stderr))
+	record.StdoutTail = logTail(stdout)
+	record.StderrTail = logTail(stderr)
+}
+
+// logTail returns the last runLogTailBytes of s, starting on a rune boundary.
+func logTail(s string) string {
+	if len(s) <= runLogTailBytes {
+		return s
+	}
+	s = s[len(s)-runLogTailBytes:]
+	for len(s) > 0 && !utf8.RuneStart(s[0]) {
+		s = s[1:]
+	}
+	return s
+}
+
+// OutputTail returns the end of the run's stdout and stderr: the saved
+// excerpt for log-backed records, or the inline output otherwise.
+func (r RunRecord) OutputTail() (stdout, stderr string) {
+	stdout, stderr = r.Stdout, r.Stderr
+	if stdout == "" {
+		stdout = r.StdoutTail
+	}
+	if stderr == "" {
+		stderr = r.StderrTail
+	}
+	return stdout, stderr
+}
+
+// LoadRunOutput fills record.Stdout and record.Stderr from the run's log
+// files. Records saved with inline output, or whose output is already loaded,
+// are returned unchanged. A missing log file falls back to the saved tail.
+func LoadRunOutput(baseDir string, record RunRecord) (RunRecord, error) {
+	dir := filepath.Join(baseDir, runsDirName, record.TaskID)
+	load := func(name, tail string) (string, error) {
+		data, err := os.ReadFile(filepath.Join(dir, filepath.Base(name)))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return tail, nil
+			}
+			return "", fmt.Errorf("read run log: %w", err)
+		}
+		return string(data), nil
+	}
+	if record.StdoutLog != "" && record.Stdout == "" {
+		out, err := load(record.StdoutLog, record.StdoutTail)
+		if err != nil {
+			return record, err
+		}
+		record.Stdout = out
+	}
+	if record.StderrLog != "" && record.Stderr == "" {
+		out, err := load(record.StderrLog, record.StderrTail)
+		if err != nil {
+			return record, err
+		}
+		record.Stderr = out
+	}
+	return record, nil
+}
@@ -0,0 +1,109 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+)
+
+func TestLaunchAgentStreamsOutputToLogFiles(t *testing.T) {
+	baseDir := t.TempDir()
+	runtime := agent.Runtime{
+		Provider: "test",
+		Command:  `head -c 5000 /dev/zero | tr '\0' x; echo; echo warn >&2`,
+		UseShell: true,
+		Timeout:  2 * time.Second,
+	}
+	ctxPack := ContextPack{
+		SchemaVersion: ContextPackSchemaVersion,
+		Task:          TaskContext{ID: "task", Title: "Task"},
+	}
+
+	record, err := LaunchAgentWithStream(context.Background(), runtime, ctxPack, StreamConfig{BaseDir: baseDir})
+	if err != nil {
+		t.Fatalf("LaunchAgentWithStream: %v", err)
+	}
+	if record.StdoutLog != record.ID+".stdout.log" || record.StdoutBytes != 5001 || len(record.Stdout) != 5001 {
+		t.Fatalf("record = stdoutLog %q, bytes %d, len %d", record.StdoutLog, record.StdoutBytes, len(record.Stdout))
+	}
+	if len(record.StdoutTail) != runLogTailBytes || record.StderrTail != "warn\n" {
+		t.Fatalf("tails = %d bytes, %q", len(record.StdoutTail), record.StderrTail)
+	}
+	logged, err := os.ReadFile(filepath.Join(baseDir, runsDirName, "task", record.StdoutLog))
+	if err != nil || string(logged) != record.Stdout {
+		t.Fatalf("stdout log = %d bytes, %v", len(logged), err)
+	}
+
+	if err := SaveRun(baseDir, record); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(baseDir, runsDirName, "task", record.ID+".json"))
+	if err != nil {
+		t.Fatalf("read record: %v", err)
+	}
+	if strings.Contains(string(data), `"stdout":`) || !strings.Contains(string(data), `"stdoutTail":`) {
+		t.Fatalf("saved record should hold only the tail: %s", data[:200])
+	}
+
+	runs, err := ListRuns(baseDir, "task")
+	if err != nil || len(runs) != 1 {
+		t.Fatalf("ListRuns = %+v, %v", runs, err)
+	}
+	if runs[0].Stdout != "" {
+		t.Fatalf("ListRuns should not load full output")
+	}
+	if stdout, stderr := runs[0].OutputTail(); len(stdout) != runLogTailBytes || stderr != "warn\n" {
+		t.Fatalf("OutputTail = %d bytes, %q", len(stdout), stderr)
+	}
+	loaded, err := LoadRun(baseDir, "task", record.ID)
+	if err != nil || loaded.Stdout != record.Stdout || loaded.Stderr != "warn\n" {
+		t.Fatalf("LoadRun = %d bytes stdout, %q, %v", len(loaded.Stdout), loaded.Stderr, err)
+	}
+
+	result, err := GCRuns(baseDir, GCOptions{Keep: 0})
+	if err != nil || len(result.Removed) != 1 || result.Bytes < 5001 {
+		t.Fatalf("GCRuns = %+v, %v", result, err)
+	}
+	if _, err := os.Stat(filepath.Join(baseDir, runsDirName, "task")); !os.IsNotExist(err) {
+		t.Fatalf("expected logs removed with the run, stat err = %v", err)
+	}
+}
+
+func TestLoadRunOutputKeepsInlineRecords(t *testing.T) {
+	baseDir := t.TempDir()
+	record := RunRecord{
+		ID:        "legacy",
+		TaskID:    "task",
+		StartedAt: time.Now(),
+		Status:    RunStatusSuccess,
+		Stdout:    "inline out",
+		Stderr:    "inline err",
+	}
+	if err := SaveRun(baseDir, record); err != nil {
+		t.Fatalf("SaveRun: %v", err)
+	}
+	loaded, err := LoadRun(baseDir, "task", "legacy")
+	if err != nil || loaded.Stdout != "inline out" || loaded.Stderr != "inline err" {
+		t.Fatalf("LoadRun = %+v, %v", loaded, err)
+	}
+
+	// A log-backed record whose log file is gone falls back to its tail.
+	missing := RunRecord{TaskID: "task", StdoutLog: "gone.stdout.log", StdoutTail: "tail"}
+	loaded, err = LoadRunOutput(baseDir, missing)
+	if err != nil || loaded.Stdout != "tail" {
+		t.Fatalf("LoadRunOutput = %+v, %v", loaded, err)
+	}
+}
+
+func TestLogTailStartsOnRuneBoundary(t *testing.T) {
+	s := strings.Repeat("é", runLogTailBytes)
+	got := logTail(s)
+	if len(got) > runLogTailBytes || !strings.HasPrefix(got, "é") {
+		t.Fatalf("tail = %d bytes, prefix %q", len(got), got[:2])
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/jbonatakis/blackbird/internal/agent"
 	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
 )
 
 type ExecuteStopReason string
@@ -28,6 +29,11 @@ type ExecuteResult struct {
 	TaskID string
 	Run    *RunRecord
 	Err    error
+	// Hooks holds on_execute_complete results (run hooks live on RunRecord).
+	Hooks []HookResult
+	// Waiting lists the tasks left waiting_user by this execute when
+	// ContinueOnWaitingUser kept it running; TaskID and Run are the first.
+	Waiting []string
 }
 
 type ExecuteConfig struct {
@@ -36,12 +42,17 @@ type ExecuteConfig struct {
 	Runtime             agent.Runtime
 	StopAfterEachTask   bool
 	ParentReviewEnabled bool
-	StreamStdout        io.Writer
-	StreamStderr        io.Writer
-	OnStateChange       func(ExecutionStageState)
-	OnParentReview      func(RunRecord)
-	OnTaskStart         func(taskID string)
-	OnTaskFinish        func(taskID string, record RunRecord, execErr error)
+	// ContinueOnWaitingUser keeps running other ready tasks when a task asks
+	// questions; only that task's dependents stay blocked.
+	ContinueOnWaitingUser bool
+	Hooks                 Hooks
+	Webhooks              *webhook.Dispatcher
+	StreamStdout          io.Writer
+	StreamStderr          io.Writer
+	OnStateChange         func(ExecutionStageState)
+	OnParentReview        func(RunRecord)
+	OnTaskStart           func(taskID string)
+	OnTaskFinish          func(taskID string, record RunRecord, execErr error)
 }
 
 type ResumeConfig struct {
@@ -52,6 +63,8 @@ type ResumeConfig struct {
 	Feedback     string
 	Context      *ContextPack
 	Runtime      agent.Runtime
+	Hooks        Hooks
+	Webhooks     *webhook.Dispatcher
 	StreamStdout io.Writer
 	StreamStderr io.Writer
 	OnTaskStart  func(taskID string)
@@ -85,6 +98,8 @@ func RunExecute(ctx context.Context, cfg ExecuteConfig) (ExecuteResult, error) {
 	baseDir := filepath.Dir(cfg.PlanPath)
 	preloaded := cfg.Graph != nil
 	var latestParentReviewRun *RunRecord
+	var firstWaiting *RunRecord
+	var waiting []string
 
 	for {
 		if ctx.Err() != nil {
@@ -97,15 +112,22 @@ func RunExecute(ctx context.Context, cfg ExecuteConfig) (ExecuteResult, error) {
 		}
 
 		ready := ReadyTasks(g)
+		if len(ready) == 0 && firstWaiting != nil {
+			return ExecuteResult{Reason: ExecuteReasonWaitingUser, TaskID: firstWaiting.TaskID, Run: firstWaiting, Waiting: waiting}, nil
+		}
 		if len(ready) == 0 {
+			result := ExecuteResult{Reason: ExecuteReasonCompleted}
 			if latestParentReviewRun != nil {
 				run := *latestParentReviewRun
-				return ExecuteResult{
-					Reason: ExecuteReasonCompleted,
-					Run:    &run,
-				}, nil
+				result.Run = &run
 			}
-			return ExecuteResult{Reason: ExecuteReasonCompleted}, nil
+			if hook, ok := runHook(ctx, cfg.Hooks, HookOnExecuteComplete, hookPayload{
+				PlanPath: cfg.PlanPath,
+				Reason:   ExecuteReasonCompleted,
+			}); ok {
+				result.Hooks = []HookResult{hook}
+			}
+			return result, nil
 		}
 		if ctx.Err() != nil {
 			return ExecuteResult{Reason: ExecuteReasonCanceled, Err: ctx.Err()}, nil
@@ -128,9 +150,13 @@ func RunExecute(ctx context.Context, cfg ExecuteConfig) (ExecuteResult, error) {
 			return ExecuteResult{Reason: ExecuteReasonError, TaskID: taskID, Err: err}, err
 		}
 
-		record, execErr := LaunchAgentWithStream(ctx, cfg.Runtime, ctxPack, StreamConfig{
-			Stdout: cfg.StreamStdout,
-			Stderr: cfg.StreamStderr,
+		record, execErr := launchWithHooks(ctx, cfg.Hooks, cfg.Webhooks, cfg.PlanPath, cfg.Runtime.Provider, ctxPack, func() (RunRecord, error) {
+			return LaunchAgentWithStream(ctx, runtimeForItem(cfg.Runtime, g.Items[taskID]), ctxPack, StreamConfig{
+				Stdout:   cfg.StreamStdout,
+				Stderr:   cfg.StreamStderr,
+				BaseDir:  baseDir,
+				PlanPath: cfg.PlanPath,
+			})
 		})
 		maybeAttachReviewSummary(baseDir, &record)
 		decisionGate := requiresDecisionGate(cfg.StopAfterEachTask, record.Status)
@@ -140,6 +166,9 @@ func RunExecute(ctx context.Context, cfg ExecuteConfig) (ExecuteResult, error) {
 		if err := SaveRun(baseDir, record); err != nil {
 			return ExecuteResult{Reason: ExecuteReasonError, TaskID: taskID, Err: err}, err
 		}
+		if decisionGate {
+			cfg.Webhooks.Notify(runEvent(webhook.EventDecisionRequired, cfg.PlanPath, record))
+		}
 
 		switch record.Status {
 		case RunStatusSuccess:
@@ -178,7 +207,14 @@ func RunExecute(ctx context.Context, cfg ExecuteConfig) (ExecuteResult, error) {
 		latestParentReviewRun = currentParentReviewRun
 
 		if record.Status == RunStatusWaitingUser {
-			return ExecuteResult{Reason: ExecuteReasonWaitingUser, TaskID: taskID, Run: &record}, nil
+			if !cfg.ContinueOnWaitingUser {
+				return ExecuteResult{Reason: ExecuteReasonWaitingUser, TaskID: taskID, Run: &record}, nil
+			}
+			if firstWaiting == nil {
+				run := record
+				firstWaiting = &run
+			}
+			waiting = append(waiting, taskID)
 		}
 		if pauseReviewRun != nil {
 			pauseTaskID := strings.TrimSpace(pauseReviewRun.TaskID)
@@ -274,9 +310,13 @@ func RunResume(ctx context.Context, cfg ResumeConfig) (RunRecord, error) {
 			return RunRecord{}, err
 		}
 
-		record, execErr := ResumeWithFeedback(ctx, cfg.Runtime, previous, resolvedFeedback.Feedback, StreamConfig{
-			Stdout: cfg.StreamStdout,
-			Stderr: cfg.StreamStderr,
+		record, execErr := launchWithHooks(ctx, cfg.Hooks, cfg.Webhooks, cfg.PlanPath, previous.Provider, previous.Context, func() (RunRecord, error) {
+			return ResumeWithFeedback(ctx, runtimeForItem(cfg.Runtime, g.Items[cfg.TaskID]), previous, resolvedFeedback.Feedback, StreamConfig{
+				Stdout:   cfg.StreamStdout,
+				Stderr:   cfg.StreamStderr,
+				BaseDir:  baseDir,
+				PlanPath: cfg.PlanPath,
+			})
 		})
 		if record.ID == "" {
 			return RunRecord{}, execErr
@@ -352,9 +392,13 @@ func RunResume(ctx context.Context, cfg ResumeConfig) (RunRecord, error) {
 		return RunRecord{}, err
 	}
 
-	record, execErr := LaunchAgentWithStream(ctx, cfg.Runtime, ctxPack, StreamConfig{
-		Stdout: cfg.StreamStdout,
-		Stderr: cfg.StreamStderr,
+	record, execErr := launchWithHooks(ctx, cfg.Hooks, cfg.Webhooks, cfg.PlanPath, cfg.Runtime.Provider, ctxPack, func() (RunRecord, error) {
+		return LaunchAgentWithStream(ctx, runtimeForItem(cfg.Runtime, g.Items[cfg.TaskID]), ctxPack, StreamConfig{
+			Stdout:   cfg.StreamStdout,
+			Stderr:   cfg.StreamStderr,
+			BaseDir:  baseDir,
+			PlanPath: cfg.PlanPath,
+		})
 	})
 	maybeAttachReviewSummary(baseDir, &record)
 	if err := SaveRun(baseDir, record); err != nil {
@@ -396,7 +440,10 @@ func latestWaitingRun(baseDir, taskID string) (*RunRecord, error) {
 	}
 	for i := len(runs) - 1; i >= 0; i-- {
 		if runs[i].Status == RunStatusWaitingUser {
-			waiting := runs[i]
+			waiting, err := LoadRunOutput(baseDir, runs[i])
+			if err != nil {
+				return nil, err
+			}
 			return &waiting, nil
 		}
 	}
@@ -441,6 +488,9 @@ func runParentReviewGateForCompletedTask(
 			StreamStdout:        cfg.StreamStdout,
 			StreamStderr:        cfg.StreamStderr,
 		})
+		if reviewErr == nil && requiresParentReviewPause(reviewRecord) {
+			reviewRecord = runParentReviewFailedHook(ctx, cfg.Hooks, cfg.Webhooks, cfg.PlanPath, reviewRecord)
+		}
 		if strings.TrimSpace(reviewRecord.ID) != "" {
 			recordCopy := reviewRecord
 			latestReviewRun = &recordCopy
@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -1044,3 +1045,45 @@ func countRuns(t *testing.T, baseDir string, items map[string]plan.WorkItem) int
 	}
 	return total
 }
+
+func TestRunExecuteContinueOnWaitingUserRunsOtherReadyTasks(t *testing.T) {
+	blocked := makeItem("c", plan.StatusTodo)
+	blocked.Deps = []string{"a"}
+	planPath := saveHookTestPlan(t, map[string]plan.WorkItem{
+		"a": makeItem("a", plan.StatusTodo),
+		"b": makeItem("b", plan.StatusTodo),
+		"c": blocked,
+	})
+
+	// The first task launched asks a question; later ones succeed.
+	marker := filepath.Join(t.TempDir(), "asked")
+	result, err := RunExecute(context.Background(), ExecuteConfig{
+		PlanPath: planPath,
+		Runtime: agent.Runtime{
+			Command:  fmt.Sprintf(`if [ ! -e %[1]q ]; then touch %[1]q; printf '{"tool":"AskUserQuestion","id":"q1","prompt":"Name?"}'; fi`, marker),
+			UseShell: true,
+			Timeout:  2 * time.Second,
+		},
+		ContinueOnWaitingUser: true,
+	})
+	if err != nil {
+		t.Fatalf("RunExecute: %v", err)
+	}
+	if result.Reason != ExecuteReasonWaitingUser || result.TaskID != "a" || result.Run == nil {
+		t.Fatalf("result = %+v", result)
+	}
+	if len(result.Waiting) != 1 || result.Waiting[0] != "a" {
+		t.Fatalf("waiting = %v", result.Waiting)
+	}
+
+	g, err := plan.Load(planPath)
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	want := map[string]plan.Status{"a": plan.StatusWaitingUser, "b": plan.StatusDone, "c": plan.StatusTodo}
+	for id, status := range want {
+		if g.Items[id].Status != status {
+			t.Fatalf("%s status = %s, want %s", id, g.Items[id].Status, status)
+		}
+	}
+}
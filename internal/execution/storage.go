@@ -10,7 +10,9 @@ import (
 
 const runsDirName = ".blackbird/runs"
 
-// SaveRun writes a run record to disk using an atomic write pattern.
+// SaveRun writes a run record to disk using an atomic write pattern. Output of
+// records backed by log files is not stored inline; only the log references,
+// byte counts and tails are.
 func SaveRun(baseDir string, record RunRecord) error {
 	if baseDir == "" {
 		return fmt.Errorf("baseDir required")
@@ -27,6 +29,12 @@ func SaveRun(baseDir string, record RunRecord) error {
 		return fmt.Errorf("create run directory: %w", err)
 	}
 
+	if record.StdoutLog != "" {
+		record.Stdout = ""
+	}
+	if record.StderrLog != "" {
+		record.Stderr = ""
+	}
 	data, err := json.MarshalIndent(record, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal run record: %w", err)
@@ -122,6 +122,12 @@ type RunRecord struct {
 	ExitCode                        *int                    `json:"exitCode,omitempty"`
 	Stdout                          string                  `json:"stdout,omitempty"`
 	Stderr                          string                  `json:"stderr,omitempty"`
+	StdoutLog                       string                  `json:"stdoutLog,omitempty"`
+	StderrLog                       string                  `json:"stderrLog,omitempty"`
+	StdoutBytes                     int64                   `json:"stdoutBytes,omitempty"`
+	StderrBytes                     int64                   `json:"stderrBytes,omitempty"`
+	StdoutTail                      string                  `json:"stdoutTail,omitempty"`
+	StderrTail                      string                  `json:"stderrTail,omitempty"`
 	Context                         ContextPack             `json:"context"`
 	Error                           string                  `json:"error,omitempty"`
 	DecisionRequired                bool                    `json:"decision_required,omitempty"`
@@ -135,6 +141,20 @@ type RunRecord struct {
 	ParentReviewFeedback            string                  `json:"parent_review_feedback,omitempty"`
 	ParentReviewResults             ParentReviewTaskResults `json:"parent_review_results,omitempty"`
 	ParentReviewCompletionSignature string                  `json:"parent_review_completion_signature,omitempty"`
+	Hooks                           []HookResult            `json:"hooks,omitempty"`
+	Attempts                        []RunAttempt            `json:"attempts,omitempty"`
+}
+
+// RunAttempt is one launch of the agent command. Attempts are only recorded
+// for runs that were retried; Stderr is kept for the retried attempts since
+// the final attempt's output is on the run itself.
+type RunAttempt struct {
+	StartedAt   time.Time `json:"startedAt"`
+	CompletedAt time.Time `json:"completedAt"`
+	ExitCode    *int      `json:"exitCode,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Stderr      string    `json:"stderr,omitempty"`
+	Transient   bool      `json:"transient,omitempty"`
 }
 
 func (r RunRecord) MarshalJSON() ([]byte, error) {
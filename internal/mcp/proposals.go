@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// ProposalsDirName holds plan patches proposed by agents and awaiting approval.
+const ProposalsDirName = ".blackbird/proposals"
+
+// ErrProposalNotFound is returned when no proposal has the requested id.
+var ErrProposalNotFound = errors.New("proposal not found")
+
+// Proposal is a plan patch an agent proposed while working on TaskID.
+type Proposal struct {
+	ID        string          `json:"id"`
+	TaskID    string          `json:"taskId,omitempty"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Rationale string          `json:"rationale,omitempty"`
+	Ops       []agent.PatchOp `json:"ops"`
+}
+
+// SaveProposal assigns p an id and stores it under baseDir.
+func SaveProposal(baseDir string, p Proposal) (Proposal, error) {
+	if len(p.Ops) == 0 {
+		return Proposal{}, fmt.Errorf("proposal has no ops")
+	}
+	var b [4]byte
+	_, _ = rand.Read(b[:])
+	p.ID = "p_" + hex.EncodeToString(b[:])
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC()
+	}
+
+	dir := filepath.Join(baseDir, ProposalsDirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Proposal{}, fmt.Errorf("create proposals directory: %w", err)
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return Proposal{}, fmt.Errorf("marshal proposal: %w", err)
+	}
+	data = append(data, '\n')
+	if err := plan.WriteFileAtomic(filepath.Join(dir, p.ID+".json"), data, 0o644); err != nil {
+		return Proposal{}, fmt.Errorf("write proposal: %w", err)
+	}
+	return p, nil
+}
+
+// ListProposals returns pending proposals, oldest first.
+func ListProposals(baseDir string) ([]Proposal, error) {
+	entries, err := os.ReadDir(filepath.Join(baseDir, ProposalsDirName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Proposal{}, nil
+		}
+		return nil, fmt.Errorf("read proposals directory: %w", err)
+	}
+	out := []Proposal{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".json") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		p, err := LoadProposal(baseDir, strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.Before(out[j].CreatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// LoadProposal reads one pending proposal.
+func LoadProposal(baseDir, id string) (Proposal, error) {
+	path, err := proposalPath(baseDir, id)
+	if err != nil {
+		return Proposal{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Proposal{}, fmt.Errorf("%w: %s", ErrProposalNotFound, id)
+		}
+		return Proposal{}, fmt.Errorf("read proposal: %w", err)
+	}
+	var p Proposal
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Proposal{}, fmt.Errorf("decode proposal %s: %w", id, err)
+	}
+	return p, nil
+}
+
+// DeleteProposal removes a pending proposal (used when it is rejected).
+func DeleteProposal(baseDir, id string) error {
+	path, err := proposalPath(baseDir, id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrProposalNotFound, id)
+		}
+		return fmt.Errorf("delete proposal: %w", err)
+	}
+	return nil
+}
+
+// ApplyProposal applies a pending proposal to the plan at planPath, saves it
+// through the undo journal and removes the proposal. It returns the plan
+// before and after.
+func ApplyProposal(planPath, id string, now time.Time) (plan.WorkGraph, plan.WorkGraph, error) {
+	baseDir := filepath.Dir(planPath)
+	p, err := LoadProposal(baseDir, id)
+	if err != nil {
+		return plan.WorkGraph{}, plan.WorkGraph{}, err
+	}
+	before, err := plan.Load(planPath)
+	if err != nil {
+		return plan.WorkGraph{}, plan.WorkGraph{}, err
+	}
+	after := plan.Clone(before)
+	if err := agent.ApplyPatch(&after, p.Ops, now); err != nil {
+		return plan.WorkGraph{}, plan.WorkGraph{}, fmt.Errorf("apply proposal %s: %w", id, err)
+	}
+	if errs := plan.Validate(after); len(errs) != 0 {
+		return plan.WorkGraph{}, plan.WorkGraph{}, fmt.Errorf("proposal %s leaves the plan invalid: %s", id, errs[0].Error())
+	}
+	if err := plan.SaveWithHistory(planPath, after, "proposals approve "+id); err != nil {
+		return plan.WorkGraph{}, plan.WorkGraph{}, err
+	}
+	if err := DeleteProposal(baseDir, id); err != nil {
+		return plan.WorkGraph{}, plan.WorkGraph{}, err
+	}
+	return before, after, nil
+}
+
+func proposalPath(baseDir, id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return "", fmt.Errorf("invalid proposal id %q", id)
+	}
+	return filepath.Join(baseDir, ProposalsDirName, id+".json"), nil
+}
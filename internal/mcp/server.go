@@ -0,0 +1,178 @@
+// Package mcp implements a Model Context Protocol server over stdio. Agents
+// working on a task use it to read the plan and record notes, proposed
+// subtasks and decisions back into it.
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// ProtocolVersion is the MCP revision this server implements. Clients that
+// request another revision are answered with this one.
+const ProtocolVersion = "2024-11-05"
+
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Server answers MCP requests for one plan file.
+type Server struct {
+	// PlanPath is the plan file the tools read and update.
+	PlanPath string
+	// TaskID is the task the agent is working on. Tools that take an id
+	// default to it.
+	TaskID string
+	// Now is used for timestamps; nil means time.Now().UTC().
+	Now func() time.Time
+
+	writeMu sync.Mutex
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+// Serve reads newline-delimited JSON-RPC messages from r and writes responses
+// to w until r is exhausted or ctx is done.
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			if resp := s.handleMessage(line); resp != nil {
+				if werr := s.write(w, resp); werr != nil {
+					return werr
+				}
+			}
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (s *Server) write(w io.Writer, resp *response) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("encode response: %w", err)
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// handleMessage returns nil for notifications, which get no response.
+func (s *Server) handleMessage(line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}}
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	resp := &response{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = &rpcError{Code: codeInvalidRequest, Message: "invalid request"}
+		return resp
+	}
+
+	result, err := s.dispatch(req)
+	if err != nil {
+		var rerr *rpcError
+		if !errors.As(err, &rerr) {
+			rerr = &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		resp.Error = rerr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) dispatch(req request) (any, error) {
+	switch req.Method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": ProtocolVersion,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": "blackbird", "version": serverVersion()},
+			"instructions":    instructions,
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": toolDefinitions()}, nil
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return s.callTool(params.Name, params.Arguments)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: fmt.Sprintf("method not found: %s", req.Method)}
+	}
+}
+
+const instructions = "Tools for the blackbird plan this task belongs to. " +
+	"Use get_task and get_dependency_outputs to see context, add_note and record_decision to leave notes for later tasks, " +
+	"and propose_subtask when work should be split out (the user approves proposals)."
+
+func serverVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+	return time.Now().UTC()
+}
+
+func (s *Server) baseDir() string {
+	return filepath.Dir(s.PlanPath)
+}
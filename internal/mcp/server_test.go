@@ -0,0 +1,189 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), plan.DefaultPlanFilename)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := plan.WorkItem{ID: "a", Title: "a", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusDone, CreatedAt: now, UpdatedAt: now}
+	b := a
+	b.ID, b.Title, b.Status, b.Deps = "b", "b", plan.StatusTodo, []string{"a"}
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"a": a, "b": b}}
+	if err := plan.SaveAtomic(path, g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	return &Server{PlanPath: path, TaskID: "b", Now: func() time.Time { return now }}
+}
+
+// session sends each request line and returns the decoded responses.
+func session(t *testing.T, s *Server, lines ...string) []response {
+	t.Helper()
+	var out bytes.Buffer
+	if err := s.Serve(context.Background(), strings.NewReader(strings.Join(lines, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	var resps []response
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var r response
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		resps = append(resps, r)
+	}
+	return resps
+}
+
+func toolText(t *testing.T, r response) (string, bool) {
+	t.Helper()
+	if r.Error != nil {
+		t.Fatalf("unexpected rpc error: %+v", r.Error)
+	}
+	data, _ := json.Marshal(r.Result)
+	var res toolResult
+	if err := json.Unmarshal(data, &res); err != nil || len(res.Content) != 1 {
+		t.Fatalf("tool result = %s", data)
+	}
+	return res.Content[0].Text, res.IsError
+}
+
+func TestHandshakeAndToolList(t *testing.T) {
+	s := newTestServer(t)
+	resps := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"nope"}`,
+	)
+	if len(resps) != 3 {
+		t.Fatalf("expected 3 responses (notification gets none), got %d", len(resps))
+	}
+	if !strings.Contains(mustJSON(t, resps[0].Result), `"protocolVersion":"`+ProtocolVersion+`"`) {
+		t.Fatalf("initialize = %s", mustJSON(t, resps[0].Result))
+	}
+	for _, name := range []string{"get_task", "list_ready", "get_dependency_outputs", "add_note", "propose_subtask", "ask_user_question", "record_decision"} {
+		if !strings.Contains(mustJSON(t, resps[1].Result), `"name":"`+name+`"`) {
+			t.Fatalf("tools/list missing %s", name)
+		}
+	}
+	if resps[2].Error == nil || resps[2].Error.Code != codeMethodNotFound {
+		t.Fatalf("unknown method response = %+v", resps[2])
+	}
+}
+
+func TestReadTools(t *testing.T) {
+	s := newTestServer(t)
+	resps := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"get_task","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_ready"}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"get_dependency_outputs","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"get_task","arguments":{"id":"zzz"}}}`,
+	)
+	if text, _ := toolText(t, resps[0]); !strings.Contains(text, `"id": "b"`) || !strings.Contains(text, `"actionable": true`) {
+		t.Fatalf("get_task = %s", text)
+	}
+	if text, _ := toolText(t, resps[1]); !strings.Contains(text, `"id": "b"`) || strings.Contains(text, `"id": "a"`) {
+		t.Fatalf("list_ready = %s", text)
+	}
+	if text, _ := toolText(t, resps[2]); !strings.Contains(text, `"id": "a"`) {
+		t.Fatalf("get_dependency_outputs = %s", text)
+	}
+	if text, isErr := toolText(t, resps[3]); !isErr || !strings.Contains(text, "unknown id") {
+		t.Fatalf("unknown id result = %s (isError %v)", text, isErr)
+	}
+}
+
+func TestNotesAndDecisionsAreSaved(t *testing.T) {
+	s := newTestServer(t)
+	session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"add_note","arguments":{"note":"used sqlite"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"record_decision","arguments":{"decision":"keep v1 API","rationale":"clients"}}}`,
+	)
+	g, err := plan.Load(s.PlanPath)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	notes := g.Items["b"].Notes
+	if notes == nil || *notes != "used sqlite\nDecision: keep v1 API (rationale: clients)" {
+		t.Fatalf("notes = %v", notes)
+	}
+	entries, _, err := plan.ListHistory(s.PlanPath)
+	if err != nil || len(entries) != 2 || entries[0].Label != "mcp add-note b" {
+		t.Fatalf("history = %+v, %v", entries, err)
+	}
+}
+
+func TestProposeSubtaskIsPendingUntilApproved(t *testing.T) {
+	s := newTestServer(t)
+	resps := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"propose_subtask","arguments":{"title":"Write migration","deps":["a"],"rationale":"schema change"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"propose_subtask","arguments":{"title":"Bad","deps":["missing"]}}}`,
+	)
+	if _, isErr := toolText(t, resps[0]); isErr {
+		t.Fatalf("propose_subtask failed: %s", mustJSON(t, resps[0].Result))
+	}
+	if _, isErr := toolText(t, resps[1]); !isErr {
+		t.Fatalf("expected unknown dep to fail")
+	}
+
+	baseDir := filepath.Dir(s.PlanPath)
+	proposals, err := ListProposals(baseDir)
+	if err != nil || len(proposals) != 1 {
+		t.Fatalf("proposals = %+v, %v", proposals, err)
+	}
+	g, _ := plan.Load(s.PlanPath)
+	if len(g.Items) != 2 {
+		t.Fatalf("plan changed before approval")
+	}
+
+	before, after, err := ApplyProposal(s.PlanPath, proposals[0].ID, time.Now().UTC())
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(before.Items) != 2 || len(after.Items) != 3 {
+		t.Fatalf("apply items before %d after %d", len(before.Items), len(after.Items))
+	}
+	newID := proposals[0].Ops[0].Item.ID
+	if after.Items[newID].ParentID == nil || *after.Items[newID].ParentID != "b" {
+		t.Fatalf("new item parent = %v", after.Items[newID].ParentID)
+	}
+	if left, _ := ListProposals(baseDir); len(left) != 0 {
+		t.Fatalf("proposal not removed after approval")
+	}
+}
+
+func TestAskUserQuestionReturnsParsableBlock(t *testing.T) {
+	s := newTestServer(t)
+	resps := session(t, s,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"ask_user_question","arguments":{"prompt":"Which DB?","options":["pg","sqlite"]}}}`,
+	)
+	text, _ := toolText(t, resps[0])
+	if !strings.Contains(text, `{"tool":"AskUserQuestion","id":"q1","prompt":"Which DB?","options":["pg","sqlite"]}`) {
+		t.Fatalf("ask_user_question = %s", text)
+	}
+	questions, err := execution.ParseQuestions(text)
+	if err != nil || len(questions) != 1 || questions[0].Prompt != "Which DB?" {
+		t.Fatalf("parsed questions = %+v, %v", questions, err)
+	}
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(data)
+}
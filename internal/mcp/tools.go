@@ -0,0 +1,524 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// defaultOutputChars caps dependency output returned by get_dependency_outputs.
+const defaultOutputChars = 4000
+
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+type toolResult struct {
+	Content []toolContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
+
+type toolContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func objectSchema(required []string, props map[string]any) map[string]any {
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func stringProp(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func stringListProp(description string) map[string]any {
+	return map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": description}
+}
+
+var idProp = stringProp("Task id (defaults to the current task)")
+
+func toolDefinitions() []tool {
+	return []tool{
+		{
+			Name:        "get_task",
+			Description: "Get a plan item with its readiness, parent, children, dependencies and dependents.",
+			InputSchema: objectSchema(nil, map[string]any{"id": idProp}),
+		},
+		{
+			Name:        "list_ready",
+			Description: "List leaf tasks that are todo with all dependencies done.",
+			InputSchema: objectSchema(nil, map[string]any{}),
+		},
+		{
+			Name:        "get_dependency_outputs",
+			Description: "For each dependency of a task: its status, notes, and the tail of its latest run output.",
+			InputSchema: objectSchema(nil, map[string]any{
+				"id":       idProp,
+				"maxChars": map[string]any{"type": "integer", "description": fmt.Sprintf("Maximum output characters per dependency (default %d)", defaultOutputChars)},
+			}),
+		},
+		{
+			Name:        "add_note",
+			Description: "Append a note to a plan item. Notes are visible to the user and to later tasks.",
+			InputSchema: objectSchema([]string{"note"}, map[string]any{"id": idProp, "note": stringProp("Note text")}),
+		},
+		{
+			Name:        "propose_subtask",
+			Description: "Propose a new subtask. It is stored as a pending patch and only added to the plan once the user approves it.",
+			InputSchema: objectSchema([]string{"title"}, map[string]any{
+				"parentId":           stringProp("Parent item id (defaults to the current task)"),
+				"title":              stringProp("Subtask title"),
+				"description":        stringProp("Subtask description"),
+				"acceptanceCriteria": stringListProp("Acceptance criteria"),
+				"prompt":             stringProp("Prompt for the agent that will execute the subtask"),
+				"deps":               stringListProp("Ids the subtask depends on"),
+				"rationale":          stringProp("Why the subtask is needed"),
+			}),
+		},
+		{
+			Name:        "ask_user_question",
+			Description: "Ask the user a question. Returns a block to print as your final output; blackbird pauses the task and resumes it with the answer.",
+			InputSchema: objectSchema([]string{"prompt"}, map[string]any{
+				"id":      stringProp("Question id (optional)"),
+				"prompt":  stringProp("Question text"),
+				"options": stringListProp("Allowed answers (optional)"),
+			}),
+		},
+		{
+			Name:        "record_decision",
+			Description: "Record a design decision on a plan item so later tasks and reviewers can see it.",
+			InputSchema: objectSchema([]string{"decision"}, map[string]any{
+				"id":        idProp,
+				"decision":  stringProp("The decision"),
+				"rationale": stringProp("Why it was made"),
+			}),
+		},
+	}
+}
+
+// callTool runs a tool. Tool failures are reported in the result (isError)
+// rather than as protocol errors, so the agent can see and react to them.
+func (s *Server) callTool(name string, raw json.RawMessage) (toolResult, error) {
+	var (
+		out any
+		err error
+	)
+	switch name {
+	case "get_task":
+		out, err = s.getTask(raw)
+	case "list_ready":
+		out, err = s.listReady()
+	case "get_dependency_outputs":
+		out, err = s.getDependencyOutputs(raw)
+	case "add_note":
+		out, err = s.addNote(raw)
+	case "propose_subtask":
+		out, err = s.proposeSubtask(raw)
+	case "ask_user_question":
+		out, err = s.askUserQuestion(raw)
+	case "record_decision":
+		out, err = s.recordDecision(raw)
+	default:
+		return toolResult{}, &rpcError{Code: codeInvalidParams, Message: fmt.Sprintf("unknown tool: %s", name)}
+	}
+	if err != nil {
+		return toolResult{Content: []toolContent{{Type: "text", Text: err.Error()}}, IsError: true}, nil
+	}
+	text, ok := out.(string)
+	if !ok {
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return toolResult{}, err
+		}
+		text = string(data)
+	}
+	return toolResult{Content: []toolContent{{Type: "text", Text: text}}}, nil
+}
+
+func decodeArgs(raw json.RawMessage, v any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) loadPlan() (plan.WorkGraph, error) {
+	g, err := plan.Load(s.PlanPath)
+	if err != nil {
+		return plan.WorkGraph{}, err
+	}
+	if errs := plan.Validate(g); len(errs) != 0 {
+		return plan.WorkGraph{}, fmt.Errorf("plan is invalid: %s", errs[0].Error())
+	}
+	return g, nil
+}
+
+func (s *Server) taskID(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		id = s.TaskID
+	}
+	if id == "" {
+		return "", fmt.Errorf("id required (no current task)")
+	}
+	return id, nil
+}
+
+func lookup(g plan.WorkGraph, id string) (plan.WorkItem, error) {
+	it, ok := g.Items[id]
+	if !ok {
+		return plan.WorkItem{}, fmt.Errorf("unknown id %q", id)
+	}
+	return it, nil
+}
+
+type itemRef struct {
+	ID     string      `json:"id"`
+	Title  string      `json:"title"`
+	Status plan.Status `json:"status"`
+}
+
+func refs(g plan.WorkGraph, ids []string) []itemRef {
+	out := make([]itemRef, 0, len(ids))
+	for _, id := range ids {
+		it := g.Items[id]
+		if plan.IsExternalDep(id) {
+			_, it, _ = plan.ResolveExternalDep(g, id)
+		}
+		out = append(out, itemRef{ID: id, Title: it.Title, Status: it.Status})
+	}
+	return out
+}
+
+type taskView struct {
+	plan.WorkItem
+	Readiness    plan.Readiness `json:"readiness"`
+	Parent       *itemRef       `json:"parent,omitempty"`
+	Children     []itemRef      `json:"children"`
+	Dependencies []itemRef      `json:"dependencies"`
+	Dependents   []itemRef      `json:"dependents"`
+}
+
+func (s *Server) getTask(raw json.RawMessage) (any, error) {
+	var args struct {
+		ID string `json:"id"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	id, err := s.taskID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.loadPlan()
+	if err != nil {
+		return nil, err
+	}
+	it, err := lookup(g, id)
+	if err != nil {
+		return nil, err
+	}
+	view := taskView{
+		WorkItem:     it,
+		Readiness:    plan.ItemReadiness(g, it),
+		Children:     refs(g, it.ChildIDs),
+		Dependencies: refs(g, it.Deps),
+		Dependents:   refs(g, plan.Dependents(g, id)),
+	}
+	if it.ParentID != nil {
+		if ref := refs(g, []string{*it.ParentID}); len(ref) == 1 {
+			view.Parent = &ref[0]
+		}
+	}
+	return view, nil
+}
+
+func (s *Server) listReady() (any, error) {
+	g, err := s.loadPlan()
+	if err != nil {
+		return nil, err
+	}
+	return refs(g, execution.ReadyTasks(g)), nil
+}
+
+type dependencyOutput struct {
+	itemRef
+	Notes     string         `json:"notes,omitempty"`
+	LatestRun *dependencyRun `json:"latestRun,omitempty"`
+}
+
+type dependencyRun struct {
+	ID          string              `json:"id"`
+	Status      execution.RunStatus `json:"status"`
+	CompletedAt *time.Time          `json:"completedAt,omitempty"`
+	Output      string              `json:"output,omitempty"`
+	Truncated   bool                `json:"truncated,omitempty"`
+}
+
+func (s *Server) getDependencyOutputs(raw json.RawMessage) (any, error) {
+	var args struct {
+		ID       string `json:"id"`
+		MaxChars int    `json:"maxChars"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if args.MaxChars <= 0 {
+		args.MaxChars = defaultOutputChars
+	}
+	id, err := s.taskID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.loadPlan()
+	if err != nil {
+		return nil, err
+	}
+	it, err := lookup(g, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]dependencyOutput, 0, len(it.Deps))
+	for _, depID := range it.Deps {
+		dep := g.Items[depID]
+		// Runs of an external dep are stored with the plan it belongs to.
+		baseDir, runTaskID := s.baseDir(), depID
+		if plan.IsExternalDep(depID) {
+			d, ext, err := plan.ResolveExternalDep(g, depID)
+			if err != nil {
+				return nil, fmt.Errorf("dependency %q: %w", depID, err)
+			}
+			dep, baseDir, runTaskID = ext, filepath.Dir(d.Path(plan.ProjectRoot(s.PlanPath))), d.ID
+		}
+		entry := dependencyOutput{itemRef: itemRef{ID: depID, Title: dep.Title, Status: dep.Status}}
+		if dep.Notes != nil {
+			entry.Notes = *dep.Notes
+		}
+		run, err := execution.GetLatestRun(baseDir, runTaskID)
+		if err != nil {
+			return nil, err
+		}
+		if run != nil {
+			loaded, err := execution.LoadRunOutput(baseDir, *run)
+			if err != nil {
+				return nil, err
+			}
+			output, truncated := tail(loaded.Stdout, args.MaxChars)
+			entry.LatestRun = &dependencyRun{
+				ID:          run.ID,
+				Status:      run.Status,
+				CompletedAt: run.CompletedAt,
+				Output:      output,
+				Truncated:   truncated,
+			}
+		}
+		out = append(out, entry)
+	}
+	return out, nil
+}
+
+func tail(s string, max int) (string, bool) {
+	s = strings.TrimSpace(s)
+	r := []rune(s)
+	if len(r) <= max {
+		return s, false
+	}
+	return string(r[len(r)-max:]), true
+}
+
+func (s *Server) addNote(raw json.RawMessage) (any, error) {
+	var args struct {
+		ID   string `json:"id"`
+		Note string `json:"note"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.Note) == "" {
+		return nil, fmt.Errorf("note required")
+	}
+	id, err := s.appendNote(args.ID, strings.TrimSpace(args.Note), "add-note")
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("note added to %s", id), nil
+}
+
+func (s *Server) recordDecision(raw json.RawMessage) (any, error) {
+	var args struct {
+		ID        string `json:"id"`
+		Decision  string `json:"decision"`
+		Rationale string `json:"rationale"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	decision := strings.TrimSpace(args.Decision)
+	if decision == "" {
+		return nil, fmt.Errorf("decision required")
+	}
+	line := "Decision: " + decision
+	if rationale := strings.TrimSpace(args.Rationale); rationale != "" {
+		line += " (rationale: " + rationale + ")"
+	}
+	id, err := s.appendNote(args.ID, line, "record-decision")
+	if err != nil {
+		return nil, err
+	}
+	return fmt.Sprintf("decision recorded on %s", id), nil
+}
+
+// appendNote adds text as a new line of the item's notes and saves the plan
+// through the undo journal.
+func (s *Server) appendNote(rawID, text, action string) (string, error) {
+	id, err := s.taskID(rawID)
+	if err != nil {
+		return "", err
+	}
+	g, err := s.loadPlan()
+	if err != nil {
+		return "", err
+	}
+	it, err := lookup(g, id)
+	if err != nil {
+		return "", err
+	}
+	notes := text
+	if it.Notes != nil && strings.TrimSpace(*it.Notes) != "" {
+		notes = strings.TrimRight(*it.Notes, "\n") + "\n" + text
+	}
+	it.Notes = &notes
+	it.UpdatedAt = s.now()
+	g.Items[id] = it
+	if err := plan.SaveWithHistory(s.PlanPath, g, "mcp "+action+" "+id); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (s *Server) proposeSubtask(raw json.RawMessage) (any, error) {
+	var args struct {
+		ParentID           string   `json:"parentId"`
+		Title              string   `json:"title"`
+		Description        string   `json:"description"`
+		AcceptanceCriteria []string `json:"acceptanceCriteria"`
+		Prompt             string   `json:"prompt"`
+		Deps               []string `json:"deps"`
+		Rationale          string   `json:"rationale"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(args.Title) == "" {
+		return nil, fmt.Errorf("title required")
+	}
+	parentID, err := s.taskID(args.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	g, err := s.loadPlan()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := lookup(g, parentID); err != nil {
+		return nil, err
+	}
+	deps := []string{}
+	for _, dep := range args.Deps {
+		dep = strings.TrimSpace(dep)
+		if dep == "" {
+			continue
+		}
+		if _, err := lookup(g, dep); err != nil {
+			return nil, err
+		}
+		deps = append(deps, dep)
+	}
+	ac := args.AcceptanceCriteria
+	if ac == nil {
+		ac = []string{}
+	}
+
+	now := s.now()
+	item := plan.WorkItem{
+		ID:                 plan.NewItemID(g),
+		Title:              strings.TrimSpace(args.Title),
+		Description:        args.Description,
+		AcceptanceCriteria: ac,
+		Prompt:             args.Prompt,
+		ChildIDs:           []string{},
+		Deps:               deps,
+		Status:             plan.StatusTodo,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}
+	// Check the patch applies now so the agent hears about problems
+	// (e.g. a dependency cycle) instead of the user at approval time.
+	op := agent.PatchOp{Op: agent.PatchAdd, Item: &item, ParentID: &parentID, Rationale: strings.TrimSpace(args.Rationale)}
+	trial := plan.Clone(g)
+	if err := agent.ApplyPatch(&trial, []agent.PatchOp{op}, now); err != nil {
+		return nil, err
+	}
+
+	proposal, err := SaveProposal(s.baseDir(), Proposal{
+		TaskID:    s.TaskID,
+		CreatedAt: now,
+		Rationale: op.Rationale,
+		Ops:       []agent.PatchOp{op},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"proposalId": proposal.ID,
+		"itemId":     item.ID,
+		"status":     "pending user approval",
+		"approve":    "blackbird proposals approve " + proposal.ID,
+	}, nil
+}
+
+func (s *Server) askUserQuestion(raw json.RawMessage) (any, error) {
+	var args struct {
+		ID      string   `json:"id"`
+		Prompt  string   `json:"prompt"`
+		Options []string `json:"options"`
+	}
+	if err := decodeArgs(raw, &args); err != nil {
+		return nil, err
+	}
+	prompt := strings.TrimSpace(args.Prompt)
+	if prompt == "" {
+		return nil, fmt.Errorf("prompt required")
+	}
+	id := strings.TrimSpace(args.ID)
+	if id == "" {
+		id = "q1"
+	}
+	block, err := json.Marshal(struct {
+		Tool    string   `json:"tool"`
+		ID      string   `json:"id"`
+		Prompt  string   `json:"prompt"`
+		Options []string `json:"options,omitempty"`
+	}{Tool: "AskUserQuestion", ID: id, Prompt: prompt, Options: args.Options})
+	if err != nil {
+		return nil, err
+	}
+	return "To ask the user, print this JSON object as your final output and stop. " +
+		"Blackbird will pause the task and resume you with the answer.\n\n" + string(block), nil
+}
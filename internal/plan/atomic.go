@@ -7,7 +7,9 @@ import (
 	"runtime"
 )
 
-func atomicWriteFile(path string, data []byte, perm os.FileMode) error {
+// WriteFileAtomic writes data to path through a temp file in the same directory
+// and a rename, so readers never see a partial file.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
 	dir := filepath.Dir(path)
 
 	// Best-effort: keep tmp in same directory for atomic rename.
@@ -49,7 +51,7 @@ func atomicWriteFile(path string, data []byte, perm os.FileMode) error {
 	// Windows doesn't support syncing directories the same way; skip it.
 	if runtime.GOOS != "windows" {
 		if err := fsyncDir(dir); err != nil {
-			return fmt.Errorf("fsync plan directory: %w", err)
+			return fmt.Errorf("fsync directory: %w", err)
 		}
 	}
 
@@ -4,6 +4,7 @@ func Clone(g WorkGraph) WorkGraph {
 	out := WorkGraph{
 		SchemaVersion: g.SchemaVersion,
 		Items:         map[string]WorkItem{},
+		ExternalRoot:  g.ExternalRoot,
 	}
 	if g.Items == nil {
 		out.Items = nil
@@ -13,6 +14,13 @@ func Clone(g WorkGraph) WorkGraph {
 	for id, it := range g.Items {
 		out.Items[id] = cloneItem(it)
 	}
+	if g.External != nil {
+		out.External = make(map[string]ExternalRef, len(g.External))
+		for dep, ref := range g.External {
+			ref.Item = cloneItem(ref.Item)
+			out.External[dep] = ref
+		}
+	}
 	return out
 }
 
@@ -38,5 +46,18 @@ func cloneItem(it WorkItem) WorkItem {
 	if it.DepRationale != nil {
 		out.DepRationale = copyRationale(it.DepRationale)
 	}
+	out.Agent = it.Agent.clone()
+	out.EstimateMinutes = copyIntPtr(it.EstimateMinutes)
+	if it.LintIgnore != nil {
+		out.LintIgnore = append([]string{}, it.LintIgnore...)
+	}
 	return out
 }
+
+func copyIntPtr(v *int) *int {
+	if v == nil {
+		return nil
+	}
+	out := *v
+	return &out
+}
@@ -19,13 +19,21 @@ func Dependents(g WorkGraph, id string) []string {
 }
 
 // UnmetDeps returns prerequisite IDs whose status is not done.
-// The result preserves the order of it.Deps.
+// The result preserves the order of it.Deps. External deps
+// ("plan:<name>#<id>") are checked against g.External; unresolved ones are
+// unmet.
 func UnmetDeps(g WorkGraph, it WorkItem) []string {
 	if len(it.Deps) == 0 {
 		return nil
 	}
 	out := make([]string, 0, len(it.Deps))
 	for _, depID := range it.Deps {
+		if IsExternalDep(depID) {
+			if _, dep, err := ResolveExternalDep(g, depID); err != nil || dep.Status != StatusDone {
+				out = append(out, depID)
+			}
+			continue
+		}
 		dep, ok := g.Items[depID]
 		if !ok {
 			// Validation will report unknown deps; treat as unmet here too.
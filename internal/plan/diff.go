@@ -116,6 +116,15 @@ func itemContentEqual(a, b WorkItem) bool {
 	if !rationaleEqual(a.DepRationale, b.DepRationale) {
 		return false
 	}
+	if a.Agent.key() != b.Agent.key() {
+		return false
+	}
+	if !stringSliceEqual(a.LintIgnore, b.LintIgnore) {
+		return false
+	}
+	if estimateKey(a.EstimateMinutes) != estimateKey(b.EstimateMinutes) {
+		return false
+	}
 	return true
 }
 
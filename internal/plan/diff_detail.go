@@ -0,0 +1,223 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LineOp marks a line in a LineChange.
+type LineOp string
+
+const (
+	LineEqual   LineOp = " "
+	LineRemoved LineOp = "-"
+	LineAdded   LineOp = "+"
+)
+
+type LineChange struct {
+	Op   LineOp
+	Text string
+}
+
+// FieldChange is how one field of an item differs between two graphs. Which
+// parts are set depends on the field kind.
+type FieldChange struct {
+	Field string
+	// Before and After hold scalar values (title, status, parentId, ...).
+	Before string
+	After  string
+	// Lines is the line diff of multi-line text (description, prompt, notes).
+	Lines []LineChange
+	// Removed and Added are the set difference of list fields
+	// (acceptanceCriteria, deps, depRationale, childIds, lintIgnore).
+	Removed []string
+	Added   []string
+	// Reordered is set when a list keeps the same entries in another order.
+	Reordered bool
+}
+
+type ItemChangeKind string
+
+const (
+	ItemAdded   ItemChangeKind = "added"
+	ItemRemoved ItemChangeKind = "removed"
+	ItemUpdated ItemChangeKind = "updated"
+)
+
+// ItemDiff is one item that differs between two graphs. Fields is set for
+// updated items only; createdAt and updatedAt are not compared.
+type ItemDiff struct {
+	ID     string
+	Kind   ItemChangeKind
+	Title  string
+	Fields []FieldChange
+}
+
+// DetailedDiffSummary extends DiffSummary with per-field changes.
+type DetailedDiffSummary struct {
+	DiffSummary
+	// Items are sorted by ID.
+	Items []ItemDiff
+}
+
+// DetailedDiff compares before and after field by field.
+func DetailedDiff(before, after WorkGraph) DetailedDiffSummary {
+	out := DetailedDiffSummary{DiffSummary: Diff(before, after)}
+	for _, id := range out.Added {
+		out.Items = append(out.Items, ItemDiff{ID: id, Kind: ItemAdded, Title: after.Items[id].Title})
+	}
+	for _, id := range out.Removed {
+		out.Items = append(out.Items, ItemDiff{ID: id, Kind: ItemRemoved, Title: before.Items[id].Title})
+	}
+	for id, b := range before.Items {
+		a, ok := after.Items[id]
+		if !ok {
+			continue
+		}
+		if fields := ItemFieldChanges(b, a); len(fields) != 0 {
+			out.Items = append(out.Items, ItemDiff{ID: id, Kind: ItemUpdated, Title: a.Title, Fields: fields})
+		}
+	}
+	sort.Slice(out.Items, func(i, j int) bool { return out.Items[i].ID < out.Items[j].ID })
+	return out
+}
+
+// ItemFieldChanges returns the fields that differ between two versions of an
+// item, in a fixed order.
+func ItemFieldChanges(a, b WorkItem) []FieldChange {
+	var out []FieldChange
+	scalar := func(field, x, y string) {
+		if x != y {
+			out = append(out, FieldChange{Field: field, Before: x, After: y})
+		}
+	}
+	text := func(field, x, y string) {
+		if x != y {
+			out = append(out, FieldChange{Field: field, Lines: LineDiff(x, y)})
+		}
+	}
+	list := func(field string, x, y []string) {
+		if change, ok := listChange(field, x, y); ok {
+			out = append(out, change)
+		}
+	}
+
+	scalar("title", a.Title, b.Title)
+	scalar("status", string(a.Status), string(b.Status))
+	scalar("parentId", parentKey(a.ParentID), parentKey(b.ParentID))
+	text("description", a.Description, b.Description)
+	text("prompt", a.Prompt, b.Prompt)
+	list("acceptanceCriteria", a.AcceptanceCriteria, b.AcceptanceCriteria)
+	list("deps", a.Deps, b.Deps)
+	list("depRationale", rationaleEntries(a.DepRationale), rationaleEntries(b.DepRationale))
+	list("childIds", a.ChildIDs, b.ChildIDs)
+	text("notes", notesKey(a.Notes), notesKey(b.Notes))
+	scalar("agent", a.Agent.key(), b.Agent.key())
+	list("lintIgnore", a.LintIgnore, b.LintIgnore)
+	scalar("estimateMinutes", estimateKey(a.EstimateMinutes), estimateKey(b.EstimateMinutes))
+	return out
+}
+
+func listChange(field string, a, b []string) (FieldChange, bool) {
+	if stringSliceEqual(a, b) {
+		return FieldChange{}, false
+	}
+	change := FieldChange{Field: field}
+	inA := map[string]bool{}
+	for _, v := range a {
+		inA[v] = true
+	}
+	inB := map[string]bool{}
+	for _, v := range b {
+		inB[v] = true
+	}
+	for _, v := range a {
+		if !inB[v] {
+			change.Removed = append(change.Removed, v)
+		}
+	}
+	for _, v := range b {
+		if !inA[v] {
+			change.Added = append(change.Added, v)
+		}
+	}
+	if len(change.Removed) == 0 && len(change.Added) == 0 {
+		change.Reordered = true
+	}
+	return change, true
+}
+
+func rationaleEntries(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k, v := range m {
+		out = append(out, fmt.Sprintf("%s: %s", k, v))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func parentKey(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func notesKey(n *string) string {
+	if n == nil {
+		return ""
+	}
+	return *n
+}
+
+// LineDiff returns a line-by-line diff of a and b based on their longest
+// common subsequence of lines.
+func LineDiff(a, b string) []LineChange {
+	x, y := splitLines(a), splitLines(b)
+	// lcs[i][j] is the LCS length of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []LineChange
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			out = append(out, LineChange{Op: LineEqual, Text: x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, LineChange{Op: LineRemoved, Text: x[i]})
+			i++
+		default:
+			out = append(out, LineChange{Op: LineAdded, Text: y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		out = append(out, LineChange{Op: LineRemoved, Text: x[i]})
+	}
+	for ; j < len(y); j++ {
+		out = append(out, LineChange{Op: LineAdded, Text: y[j]})
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
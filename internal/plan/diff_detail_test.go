@@ -0,0 +1,77 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDetailedDiffFieldChanges(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	parent := wi("p", t0)
+	parent.ChildIDs = []string{"a", "b"}
+	a := wi("a", t0)
+	a.ParentID = &parent.ID
+	a.Description = "line one\nline two\nline three"
+	a.AcceptanceCriteria = []string{"works", "fast"}
+	b := wi("b", t0)
+	b.ParentID = &parent.ID
+	before := graphOf(parent, a, b, wi("gone", t0))
+
+	after := Clone(before)
+	p := after.Items["p"]
+	p.ChildIDs = []string{"b", "a"}
+	after.Items["p"] = p
+	changed := after.Items["a"]
+	changed.Title = "A renamed"
+	changed.Description = "line one\nline 2\nline three"
+	changed.AcceptanceCriteria = []string{"works", "documented"}
+	changed.Deps = []string{"b"}
+	changed.UpdatedAt = now
+	after.Items["a"] = changed
+	touched := after.Items["b"]
+	touched.UpdatedAt = now
+	after.Items["b"] = touched
+	delete(after.Items, "gone")
+	after.Items["new"] = wi("new", now)
+
+	d := DetailedDiff(before, after)
+	var kinds []string
+	for _, it := range d.Items {
+		kinds = append(kinds, it.ID+":"+string(it.Kind))
+	}
+	if want := []string{"a:updated", "gone:removed", "new:added", "p:updated"}; !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("items = %v, want %v", kinds, want)
+	}
+
+	wantA := []FieldChange{
+		{Field: "title", Before: "a", After: "A renamed"},
+		{Field: "description", Lines: []LineChange{
+			{Op: LineEqual, Text: "line one"},
+			{Op: LineRemoved, Text: "line two"},
+			{Op: LineAdded, Text: "line 2"},
+			{Op: LineEqual, Text: "line three"},
+		}},
+		{Field: "acceptanceCriteria", Removed: []string{"fast"}, Added: []string{"documented"}},
+		{Field: "deps", Added: []string{"b"}},
+	}
+	if !reflect.DeepEqual(d.Items[0].Fields, wantA) {
+		t.Fatalf("a fields = %+v", d.Items[0].Fields)
+	}
+	if want := []FieldChange{{Field: "childIds", Reordered: true}}; !reflect.DeepEqual(d.Items[3].Fields, want) {
+		t.Fatalf("p fields = %+v", d.Items[3].Fields)
+	}
+}
+
+func TestLineDiffEdges(t *testing.T) {
+	if got := LineDiff("", "x"); !reflect.DeepEqual(got, []LineChange{{Op: LineAdded, Text: "x"}}) {
+		t.Fatalf("add = %+v", got)
+	}
+	if got := LineDiff("x\ny", ""); !reflect.DeepEqual(got, []LineChange{{Op: LineRemoved, Text: "x"}, {Op: LineRemoved, Text: "y"}}) {
+		t.Fatalf("remove = %+v", got)
+	}
+	if got := LineDiff("same", "same"); !reflect.DeepEqual(got, []LineChange{{Op: LineEqual, Text: "same"}}) {
+		t.Fatalf("equal = %+v", got)
+	}
+}
@@ -0,0 +1,230 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ExternalDepPrefix marks a dep on an item of another plan in the same
+// repository: "plan:<name>#<id>".
+const ExternalDepPrefix = "plan:"
+
+// ErrExternalItemNotFound is returned when a referenced plan exists but has no
+// item with the referenced ID.
+var ErrExternalItemNotFound = errors.New("item not found in referenced plan")
+
+// ExternalDep is a parsed "plan:<name>#<id>" dep. Plan takes the same values
+// as --plan (a plan name or a path) and is resolved against the project root
+// of the plan holding the dep.
+type ExternalDep struct {
+	Plan string
+	ID   string
+}
+
+func (d ExternalDep) String() string {
+	return ExternalDepPrefix + d.Plan + "#" + d.ID
+}
+
+// IsExternalDep reports whether dep refers to another plan.
+func IsExternalDep(dep string) bool {
+	return strings.HasPrefix(dep, ExternalDepPrefix)
+}
+
+// ParseExternalDep parses a "plan:<name>#<id>" dep.
+func ParseExternalDep(dep string) (ExternalDep, error) {
+	if !IsExternalDep(dep) {
+		return ExternalDep{}, fmt.Errorf("not an external dep: %q", dep)
+	}
+	rest := strings.TrimPrefix(dep, ExternalDepPrefix)
+	idx := strings.LastIndex(rest, "#")
+	if idx < 0 {
+		return ExternalDep{}, fmt.Errorf("invalid external dep %q (want plan:<name>#<id>)", dep)
+	}
+	d := ExternalDep{Plan: rest[:idx], ID: rest[idx+1:]}
+	if strings.TrimSpace(d.Plan) == "" || strings.TrimSpace(d.ID) == "" {
+		return ExternalDep{}, fmt.Errorf("invalid external dep %q (want plan:<name>#<id>)", dep)
+	}
+	return d, nil
+}
+
+// Path returns the plan file the dep refers to, resolving the plan against the
+// project root of the plan that holds the dep.
+func (d ExternalDep) Path(root string) string {
+	return ResolvePlanPath(root, d.Plan)
+}
+
+// ErrExternalDepUnresolved is returned for an external dep that was not
+// present when its plan was loaded, such as one added by an edit since.
+// Validate and the dep mutations resolve such deps on demand (see
+// lookupExternalRef); display helpers treat them as unmet.
+var ErrExternalDepUnresolved = errors.New("external dep not resolved")
+
+// ExternalRef is the item an external dep referred to when its plan was
+// loaded, or the error resolving it: ErrPlanNotFound when the referenced plan
+// does not exist, ErrExternalItemNotFound when it has no such item.
+type ExternalRef struct {
+	Item WorkItem
+	Err  error
+}
+
+// ResolveExternalDeps loads the plans that g's external deps refer to,
+// read-only, and records each referenced item in g.External. Plans are
+// resolved against the project of planPath, the file g was loaded from, which
+// is kept in g.ExternalRoot. UnmetDeps and the display helpers only read
+// g.External.
+//
+// A dep that fails to resolve here does not make g invalid, so a plan whose
+// referenced item was deleted can still be loaded and repaired; see
+// ExternalDepErrors.
+func ResolveExternalDeps(planPath string, g *WorkGraph) {
+	root := ProjectRoot(planPath)
+	g.External = nil
+	g.ExternalRoot = root
+	for _, it := range g.Items {
+		for _, dep := range it.Deps {
+			if !IsExternalDep(dep) {
+				continue
+			}
+			if _, ok := g.External[dep]; ok {
+				continue
+			}
+			if g.External == nil {
+				g.External = map[string]ExternalRef{}
+			}
+			g.External[dep] = resolveExternalRef(root, dep)
+		}
+	}
+}
+
+func resolveExternalRef(root, dep string) ExternalRef {
+	d, err := ParseExternalDep(dep)
+	if err != nil {
+		return ExternalRef{Err: err}
+	}
+	other, err := loadExternalPlan(d.Path(root))
+	if err != nil {
+		return ExternalRef{Err: err}
+	}
+	it, ok := other.Items[d.ID]
+	if !ok {
+		return ExternalRef{Err: ErrExternalItemNotFound}
+	}
+	return ExternalRef{Item: it}
+}
+
+// ResolveExternalDep parses dep and returns the item it referred to when g
+// was loaded. It returns ErrExternalDepUnresolved for a dep g.External does
+// not cover.
+func ResolveExternalDep(g WorkGraph, dep string) (ExternalDep, WorkItem, error) {
+	d, err := ParseExternalDep(dep)
+	if err != nil {
+		return ExternalDep{}, WorkItem{}, err
+	}
+	ref, ok := g.External[dep]
+	if !ok {
+		return d, WorkItem{}, ErrExternalDepUnresolved
+	}
+	return d, ref.Item, ref.Err
+}
+
+// lookupExternalRef returns the ref g.External holds for dep, and whether it
+// was there. A dep added since g was loaded is resolved against
+// g.ExternalRoot instead; graphs that were not loaded from a plan file have no
+// root, and such deps are only checked for syntax.
+func lookupExternalRef(g WorkGraph, dep string) (ExternalRef, bool) {
+	if ref, ok := g.External[dep]; ok {
+		return ref, true
+	}
+	if _, err := ParseExternalDep(dep); err != nil {
+		return ExternalRef{Err: err}, false
+	}
+	if g.ExternalRoot == "" {
+		return ExternalRef{}, false
+	}
+	return resolveExternalRef(g.ExternalRoot, dep), false
+}
+
+// ExternalDepErrors reports the external deps of g that did not resolve when
+// g was loaded: the referenced plan or item no longer exists. Validate does
+// not, so that such a plan can still be edited; `blackbird validate` reports
+// them as errors and other commands as warnings.
+func ExternalDepErrors(g WorkGraph) []ValidationError {
+	ids := make([]string, 0, len(g.Items))
+	for id := range g.Items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []ValidationError
+	for _, id := range ids {
+		for i, dep := range g.Items[id].Deps {
+			ref, ok := g.External[dep]
+			if !ok || ref.Err == nil {
+				continue
+			}
+			errs = append(errs, ValidationError{
+				Path:    fmt.Sprintf("$.items[%q].deps[%d]", id, i),
+				Message: externalDepError(dep, ref.Err).Error(),
+			})
+		}
+	}
+	return errs
+}
+
+type externalPlan struct {
+	stamp string
+	g     WorkGraph
+	err   error
+}
+
+// externalPlans caches referenced plans by path. The TUI reloads its plan on
+// a timer, so a referenced plan is only re-read when its files change.
+var externalPlans = struct {
+	sync.Mutex
+	byPath map[string]externalPlan
+}{byPath: map[string]externalPlan{}}
+
+func loadExternalPlan(path string) (WorkGraph, error) {
+	stamp := planStamp(path)
+	if stamp == "" {
+		return WorkGraph{}, ErrPlanNotFound
+	}
+	externalPlans.Lock()
+	defer externalPlans.Unlock()
+	if cached, ok := externalPlans.byPath[path]; ok && cached.stamp == stamp {
+		return cached.g, cached.err
+	}
+	// loadFile, not Load: the referenced plan's own external deps are not
+	// needed, and resolving them could recurse through a cycle of plans.
+	g, err := loadFile(path)
+	externalPlans.byPath[path] = externalPlan{stamp: stamp, g: g, err: err}
+	return g, err
+}
+
+// planStamp summarizes the modification times and sizes of the files backing
+// the plan at path, or returns "" when no plan exists there.
+func planStamp(path string) string {
+	if info, err := os.Stat(path); err == nil {
+		return fmt.Sprintf("%d/%d", info.ModTime().UnixNano(), info.Size())
+	}
+	if !IsSplit(path) {
+		return ""
+	}
+	entries, err := os.ReadDir(SplitDir(path))
+	if err != nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, "%s:%d/%d;", e.Name(), info.ModTime().UnixNano(), info.Size())
+	}
+	return b.String()
+}
@@ -0,0 +1,140 @@
+package plan
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExternalDepsResolveAgainstReferencedPlan(t *testing.T) {
+	root := t.TempDir()
+	// Deps resolve against the project of the plan holding them, not the
+	// working directory or BLACKBIRD_PLAN.
+	t.Chdir(t.TempDir())
+	t.Setenv(PlanEnvVar, "elsewhere")
+
+	now := time.Now().UTC()
+	backendPath := filepath.Join(NamedPlanDir(root, "backend"), DefaultPlanFilename)
+	backend := graphOf(wi("api", now))
+	if err := SaveAtomic(backendPath, backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+
+	webPath := filepath.Join(root, DefaultPlanFilename)
+	web := wi("web", now)
+	web.Deps = []string{"plan:backend#api"}
+	if err := SaveAtomic(webPath, graphOf(web, wi("docs", now))); err != nil {
+		t.Fatalf("save web plan: %v", err)
+	}
+	g, err := Load(webPath)
+	if err != nil {
+		t.Fatalf("load web plan: %v", err)
+	}
+	if errs := Validate(g); len(errs) != 0 {
+		t.Fatalf("unexpected validation errors: %v", errs)
+	}
+	if got := UnmetDeps(g, g.Items["web"]); len(got) != 1 || got[0] != "plan:backend#api" {
+		t.Fatalf("UnmetDeps = %v, want the external dep", got)
+	}
+
+	// Completing the task in the other plan unblocks the dependent task once
+	// the plan is loaded again; UnmetDeps itself reads nothing from disk.
+	api := backend.Items["api"]
+	api.Status = StatusDone
+	backend.Items["api"] = api
+	if err := SaveAtomic(backendPath, backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+	if got := UnmetDeps(g, g.Items["web"]); len(got) != 1 {
+		t.Fatalf("UnmetDeps before reload = %v, want the loaded snapshot", got)
+	}
+	if g, err = Load(webPath); err != nil {
+		t.Fatalf("reload web plan: %v", err)
+	}
+	if got := UnmetDeps(g, g.Items["web"]); len(got) != 0 {
+		t.Fatalf("UnmetDeps after completion = %v, want none", got)
+	}
+
+	for dep, want := range map[string]string{
+		"plan:backend#missing": `unknown dep id "missing" in plan "backend"`,
+		"plan:frontend#api":    `unknown plan "frontend"`,
+	} {
+		web := wi("web", now)
+		web.Deps = []string{dep}
+		bad := graphOf(web)
+		ResolveExternalDeps(webPath, &bad)
+		// A dep whose plan or item has gone since it was added leaves the plan
+		// usable, so that it can be removed...
+		if errs := Validate(bad); len(errs) != 0 {
+			t.Fatalf("Validate with dep %q = %v, want no errors", dep, errs)
+		}
+		errs := ExternalDepErrors(bad)
+		if len(errs) != 1 || !strings.Contains(errs[0].Message, want) {
+			t.Fatalf("ExternalDepErrors with dep %q = %v, want %q", dep, errs, want)
+		}
+		if got := UnmetDeps(bad, bad.Items["web"]); len(got) != 1 {
+			t.Fatalf("UnmetDeps with dep %q = %v, want it unmet", dep, got)
+		}
+		if err := SetDeps(&bad, "web", []string{dep}, now); err != nil {
+			t.Fatalf("SetDeps keeping dep %q: %v", dep, err)
+		}
+		if err := RemoveDep(&bad, "web", dep, now); err != nil {
+			t.Fatalf("RemoveDep %q: %v", dep, err)
+		}
+
+		// ...but adding one is an error.
+		if err := AddDep(&g, "docs", dep, now); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("AddDep %q = %v, want %q", dep, err, want)
+		}
+		if err := SetDeps(&g, "docs", []string{dep}, now); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("SetDeps %q = %v, want %q", dep, err, want)
+		}
+		// So is one added by editing the items directly, as patches do.
+		next := Clone(g)
+		docs := next.Items["docs"]
+		docs.Deps = []string{dep}
+		next.Items["docs"] = docs
+		if errs := Validate(next); len(errs) != 1 || !strings.Contains(errs[0].Message, want) {
+			t.Fatalf("Validate with added dep %q = %v, want %q", dep, errs, want)
+		}
+	}
+
+	malformed := wi("web", now)
+	malformed.Deps = []string{"plan:backend"}
+	bad := graphOf(malformed)
+	ResolveExternalDeps(webPath, &bad)
+	if errs := Validate(bad); len(errs) != 1 || !strings.Contains(errs[0].Message, "want plan:<name>#<id>") {
+		t.Fatalf("Validate with malformed dep = %v, want it rejected", errs)
+	}
+
+	if err := AddDep(&g, "docs", "plan:backend#api", now); err != nil {
+		t.Fatalf("AddDep external: %v", err)
+	}
+	if err := AddDep(&g, "docs", "plan:backend", now); err == nil {
+		t.Fatalf("expected AddDep to reject a malformed external dep")
+	}
+	if errs := Validate(g); len(errs) != 0 {
+		t.Fatalf("unexpected validation errors: %v", errs)
+	}
+
+	// Graphs not loaded from a plan file have no project to resolve against.
+	docs := wi("docs", now)
+	docs.Deps = []string{"plan:backend#missing"}
+	if errs := Validate(graphOf(docs)); len(errs) != 0 {
+		t.Fatalf("Validate without a project root = %v, want no errors", errs)
+	}
+}
+
+func TestValidateReservesExternalDepPrefix(t *testing.T) {
+	now := time.Now().UTC()
+	errs := Validate(graphOf(wi("plan:backend#api", now)))
+	if len(errs) != 1 || !strings.Contains(errs[0].Message, `must not start with "plan:"`) {
+		t.Fatalf("Validate = %v, want the reserved prefix rejected", errs)
+	}
+
+	g := graphOf()
+	if err := AddItem(&g, wi("plan:x", now), nil, nil, now); err == nil {
+		t.Fatalf("expected AddItem to reject an id starting with plan:")
+	}
+}
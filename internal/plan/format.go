@@ -0,0 +1,52 @@
+package plan
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Format is the on-disk encoding of a plan file.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// ParseFormat validates and parses a format name ("json", "yaml", or "yml").
+func ParseFormat(s string) (Format, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "json":
+		return FormatJSON, true
+	case "yaml", "yml":
+		return FormatYAML, true
+	default:
+		return "", false
+	}
+}
+
+// FormatForPath infers the plan format from a file extension; anything that is
+// not .yaml/.yml is treated as JSON.
+func FormatForPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// PathForFormat returns path with its extension replaced to match format.
+func PathForFormat(path string, format Format) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + "." + string(format)
+}
+
+// DetectFormat sniffs plan data whose file name carries no extension (for
+// example git merge driver temp files): a leading '{' means JSON.
+func DetectFormat(b []byte) Format {
+	trimmed := strings.TrimLeft(string(b), " \t\r\n\ufeff")
+	if trimmed == "" || trimmed[0] == '{' {
+		return FormatJSON
+	}
+	return FormatYAML
+}
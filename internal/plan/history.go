@@ -0,0 +1,382 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Plan history: every journaled save records the graph after the write under
+// .blackbird/history/, so edits can be undone and redone:
+//
+//	.blackbird/history/state.json       {"applied": N}  id of the newest applied entry
+//	.blackbird/history/<id>.json        historyRecord
+//
+// The graph before the write is stored only when it differs from the previous
+// entry's graph (the plan was changed outside the journal, or there is no
+// previous entry); otherwise it is read from that entry. Entries with ids
+// above "applied" were undone and can be redone until the next journaled save
+// discards them. Only the newest HistoryLimit entries are kept.
+const (
+	HistoryDirName   = ".blackbird/history"
+	historyStateFile = "state.json"
+	HistoryLimit     = 100
+)
+
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// HistoryConflictError is returned by Undo/Redo when the plan on disk no
+// longer matches the journal (for example after execution updated statuses).
+type HistoryConflictError struct {
+	Entry HistoryEntry
+}
+
+func (e HistoryConflictError) Error() string {
+	return fmt.Sprintf("plan changed since %q (history entry %d) was recorded", e.Entry.Label, e.Entry.ID)
+}
+
+// HistoryEntry is one journaled save. Before is nil when the save created the plan.
+type HistoryEntry struct {
+	ID        int        `json:"id"`
+	Label     string     `json:"label"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Before    *WorkGraph `json:"before"`
+	After     WorkGraph  `json:"after"`
+}
+
+// historyRecord is the stored form of a HistoryEntry. When BeforePrevious is
+// set, Before is the After of entry ID-1.
+type historyRecord struct {
+	ID             int        `json:"id"`
+	Label          string     `json:"label"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	Before         *WorkGraph `json:"before"`
+	BeforePrevious bool       `json:"beforePrevious,omitempty"`
+	After          WorkGraph  `json:"after"`
+}
+
+type historyState struct {
+	Applied int `json:"applied"`
+}
+
+// HistoryDir returns the history directory for a plan path.
+func HistoryDir(planPath string) string {
+	return filepath.Join(filepath.Dir(planPath), filepath.FromSlash(HistoryDirName))
+}
+
+// SaveWithHistory saves g like SaveAtomic and journals the previous graph under
+// label (typically the originating command, e.g. "plan refine"). Saves that do
+// not change the plan are not journaled.
+func SaveWithHistory(path string, g WorkGraph, label string) error {
+	var before *WorkGraph
+	prev, err := Load(path)
+	switch {
+	case err == nil:
+		before = &prev
+	case errors.Is(err, ErrPlanNotFound):
+	default:
+		// The previous plan is unreadable; save without journaling rather than fail.
+		return SaveAtomic(path, g)
+	}
+
+	if err := SaveAtomic(path, g); err != nil {
+		return err
+	}
+	if before != nil && graphsEqual(*before, g) {
+		return nil
+	}
+	if err := recordHistory(path, label, before, g, time.Now().UTC()); err != nil {
+		return fmt.Errorf("record plan history: %w", err)
+	}
+	return nil
+}
+
+func recordHistory(path, label string, before *WorkGraph, after WorkGraph, now time.Time) error {
+	dir := HistoryDir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	state, err := readHistoryState(dir)
+	if err != nil {
+		return err
+	}
+	ids, err := historyIDs(dir)
+	if err != nil {
+		return err
+	}
+	// A new save discards the redo branch.
+	for _, id := range ids {
+		if id > state.Applied {
+			if err := os.Remove(historyEntryPath(dir, id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	rec := historyRecord{ID: state.Applied + 1, Label: label, CreatedAt: now, Before: before, After: after}
+	if before != nil {
+		if prev, err := readHistoryRecord(dir, state.Applied); err == nil && graphsEqual(prev.After, *before) {
+			rec.Before, rec.BeforePrevious = nil, true
+		}
+	}
+	if err := writeHistoryRecord(dir, rec); err != nil {
+		return err
+	}
+	if err := writeHistoryState(dir, historyState{Applied: rec.ID}); err != nil {
+		return err
+	}
+
+	cutoff := rec.ID - HistoryLimit
+	if len(ids) == 0 || ids[0] > cutoff {
+		return nil
+	}
+	// The oldest kept entry can no longer read its before graph from a pruned entry.
+	if oldest, err := readHistoryRecord(dir, cutoff+1); err == nil && oldest.BeforePrevious {
+		entry, err := readHistoryEntry(dir, oldest.ID)
+		if err != nil {
+			return err
+		}
+		oldest.Before, oldest.BeforePrevious = entry.Before, false
+		if err := writeHistoryRecord(dir, oldest); err != nil {
+			return err
+		}
+	}
+	for _, id := range ids {
+		if id <= cutoff {
+			if err := os.Remove(historyEntryPath(dir, id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ListHistory returns the journal entries (oldest first) and the id of the
+// newest applied entry; entries with larger ids can be redone.
+func ListHistory(path string) ([]HistoryEntry, int, error) {
+	dir := HistoryDir(path)
+	state, err := readHistoryState(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids, err := historyIDs(dir)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries := make([]HistoryEntry, 0, len(ids))
+	for _, id := range ids {
+		e, err := readHistoryEntry(dir, id)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, state.Applied, nil
+}
+
+// Undo restores the plan saved before the newest applied entry. Unless force is
+// set, it refuses with HistoryConflictError when the plan on disk differs from
+// what that entry saved.
+func Undo(path string, force bool) (HistoryEntry, error) {
+	dir := HistoryDir(path)
+	state, err := readHistoryState(dir)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	if state.Applied == 0 {
+		return HistoryEntry{}, ErrNothingToUndo
+	}
+	entry, err := readHistoryEntry(dir, state.Applied)
+	if errors.Is(err, os.ErrNotExist) {
+		return HistoryEntry{}, ErrNothingToUndo
+	}
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	if !force {
+		if err := checkCurrentPlan(path, &entry.After, entry); err != nil {
+			return HistoryEntry{}, err
+		}
+	}
+	if err := restorePlan(path, entry.Before); err != nil {
+		return HistoryEntry{}, err
+	}
+	if err := writeHistoryState(dir, historyState{Applied: entry.ID - 1}); err != nil {
+		return HistoryEntry{}, err
+	}
+	return entry, nil
+}
+
+// Redo re-applies the entry after the newest applied one, with the same
+// conflict check as Undo.
+func Redo(path string, force bool) (HistoryEntry, error) {
+	dir := HistoryDir(path)
+	state, err := readHistoryState(dir)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	entry, err := readHistoryEntry(dir, state.Applied+1)
+	if errors.Is(err, os.ErrNotExist) {
+		return HistoryEntry{}, ErrNothingToRedo
+	}
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	if !force {
+		if err := checkCurrentPlan(path, entry.Before, entry); err != nil {
+			return HistoryEntry{}, err
+		}
+	}
+	after := entry.After
+	if err := restorePlan(path, &after); err != nil {
+		return HistoryEntry{}, err
+	}
+	if err := writeHistoryState(dir, historyState{Applied: entry.ID}); err != nil {
+		return HistoryEntry{}, err
+	}
+	return entry, nil
+}
+
+func checkCurrentPlan(path string, want *WorkGraph, entry HistoryEntry) error {
+	current, err := Load(path)
+	if errors.Is(err, ErrPlanNotFound) {
+		if want == nil {
+			return nil
+		}
+		return HistoryConflictError{Entry: entry}
+	}
+	if err != nil {
+		return err
+	}
+	if want == nil || !graphsEqual(current, *want) {
+		return HistoryConflictError{Entry: entry}
+	}
+	return nil
+}
+
+// restorePlan writes g to path, or removes the plan when g is nil.
+func restorePlan(path string, g *WorkGraph) error {
+	if g != nil {
+		if err := SaveAtomic(path, *g); err != nil {
+			return fmt.Errorf("write plan file: %w", err)
+		}
+		return nil
+	}
+	if IsSplit(path) {
+		return RemoveSplit(path)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove plan file: %w", err)
+	}
+	return nil
+}
+
+func graphsEqual(a, b WorkGraph) bool {
+	ab, errA := Marshal(a, FormatJSON)
+	bb, errB := Marshal(b, FormatJSON)
+	return errA == nil && errB == nil && bytes.Equal(ab, bb)
+}
+
+func historyEntryPath(dir string, id int) string {
+	return filepath.Join(dir, fmt.Sprintf("%06d.json", id))
+}
+
+func historyIDs(dir string) ([]int, error) {
+	ents, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read history directory: %w", err)
+	}
+	var ids []int
+	for _, e := range ents {
+		name := e.Name()
+		if e.IsDir() || name == historyStateFile || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimSuffix(name, ".json"))
+		if err != nil || id <= 0 {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// readHistoryEntry reads entry id, resolving its before graph from the
+// previous entry when it was not stored.
+func readHistoryEntry(dir string, id int) (HistoryEntry, error) {
+	rec, err := readHistoryRecord(dir, id)
+	if err != nil {
+		return HistoryEntry{}, err
+	}
+	e := HistoryEntry{ID: rec.ID, Label: rec.Label, CreatedAt: rec.CreatedAt, Before: rec.Before, After: rec.After}
+	if rec.BeforePrevious {
+		prev, err := readHistoryRecord(dir, id-1)
+		if err != nil {
+			return HistoryEntry{}, fmt.Errorf("read history entry %d before entry %d: %w", id-1, id, err)
+		}
+		e.Before = &prev.After
+	}
+	return e, nil
+}
+
+func readHistoryRecord(dir string, id int) (historyRecord, error) {
+	path := historyEntryPath(dir, id)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return historyRecord{}, err
+	}
+	var rec historyRecord
+	if err := json.Unmarshal(b, &rec); err != nil {
+		return historyRecord{}, fmt.Errorf("parse history entry %s: %w", path, err)
+	}
+	return rec, nil
+}
+
+func writeHistoryRecord(dir string, rec historyRecord) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(historyEntryPath(dir, rec.ID), append(b, '\n'), 0o644)
+}
+
+func readHistoryState(dir string) (historyState, error) {
+	path := filepath.Join(dir, historyStateFile)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return historyState{}, nil
+		}
+		return historyState{}, fmt.Errorf("read history state: %w", err)
+	}
+	var s historyState
+	if err := json.Unmarshal(b, &s); err != nil {
+		return historyState{}, fmt.Errorf("parse history state %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func writeHistoryState(dir string, s historyState) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create history directory: %w", err)
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return WriteFileAtomic(filepath.Join(dir, historyStateFile), append(b, '\n'), 0o644)
+}
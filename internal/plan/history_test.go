@@ -0,0 +1,148 @@
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveWithHistoryUndoRedo(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultPlanFilename)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	g1 := splitTestGraph(now)
+
+	if err := SaveWithHistory(path, g1, "init"); err != nil {
+		t.Fatalf("SaveWithHistory: %v", err)
+	}
+	g2 := Clone(g1)
+	delete(g2.Items, "ops/deploy")
+	if err := SaveWithHistory(path, g2, "delete ops/deploy"); err != nil {
+		t.Fatalf("SaveWithHistory: %v", err)
+	}
+	// Unchanged saves are not journaled.
+	if err := SaveWithHistory(path, g2, "noop"); err != nil {
+		t.Fatalf("SaveWithHistory: %v", err)
+	}
+
+	entries, applied, err := ListHistory(path)
+	if err != nil {
+		t.Fatalf("ListHistory: %v", err)
+	}
+	if len(entries) != 2 || applied != 2 || entries[1].Label != "delete ops/deploy" {
+		t.Fatalf("entries = %#v, applied = %d", entries, applied)
+	}
+
+	entry, err := Undo(path, false)
+	if err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	if entry.Label != "delete ops/deploy" {
+		t.Fatalf("undo label = %q", entry.Label)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, ok := got.Items["ops/deploy"]; !ok {
+		t.Fatalf("expected deleted item to be restored")
+	}
+
+	if _, err := Undo(path, false); err != nil {
+		t.Fatalf("Undo init: %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected plan to be removed when undoing its creation, stat err = %v", err)
+	}
+	if _, err := Undo(path, false); !errors.Is(err, ErrNothingToUndo) {
+		t.Fatalf("expected ErrNothingToUndo, got %v", err)
+	}
+
+	if _, err := Redo(path, false); err != nil {
+		t.Fatalf("Redo: %v", err)
+	}
+	if _, err := Redo(path, false); err != nil {
+		t.Fatalf("Redo: %v", err)
+	}
+	got, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, ok := got.Items["ops/deploy"]; ok {
+		t.Fatalf("expected redo to delete the item again")
+	}
+	if _, err := Redo(path, false); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("expected ErrNothingToRedo, got %v", err)
+	}
+}
+
+func TestUndoRefusesWhenPlanChangedOutsideHistory(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultPlanFilename)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	g := splitTestGraph(now)
+	if err := SaveWithHistory(path, g, "init"); err != nil {
+		t.Fatalf("SaveWithHistory: %v", err)
+	}
+	// Unjournaled write, as the execution runner does for status updates.
+	if err := SetStatus(&g, "login", StatusDone, now.Add(time.Minute)); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if err := SaveAtomic(path, g); err != nil {
+		t.Fatalf("SaveAtomic: %v", err)
+	}
+
+	var conflict HistoryConflictError
+	if _, err := Undo(path, false); !errors.As(err, &conflict) {
+		t.Fatalf("expected HistoryConflictError, got %v", err)
+	}
+	if _, err := Undo(path, true); err != nil {
+		t.Fatalf("forced Undo: %v", err)
+	}
+}
+
+func TestSaveWithHistoryDiscardsRedoAndPrunes(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultPlanFilename)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	g := NewEmptyWorkGraph()
+	for i := 0; i < HistoryLimit+5; i++ {
+		g = Clone(g)
+		id := fmt.Sprintf("t%d", i)
+		g.Items[id] = WorkItem{ID: id, Title: id, AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: StatusTodo, CreatedAt: now, UpdatedAt: now}
+		if err := SaveWithHistory(path, g, "add"); err != nil {
+			t.Fatalf("SaveWithHistory %d: %v", i, err)
+		}
+	}
+	entries, applied, err := ListHistory(path)
+	if err != nil {
+		t.Fatalf("ListHistory: %v", err)
+	}
+	if len(entries) != HistoryLimit || applied != HistoryLimit+5 || entries[0].ID != 6 {
+		t.Fatalf("len = %d, applied = %d, first = %d", len(entries), applied, entries[0].ID)
+	}
+	// The oldest kept entry stores its before graph once its predecessor is pruned.
+	if entries[0].Before == nil || len(entries[0].Before.Items) != 5 {
+		t.Fatalf("oldest entry before = %v", entries[0].Before)
+	}
+	// Other entries store only the graph after the save.
+	rec, err := readHistoryRecord(HistoryDir(path), applied)
+	if err != nil || rec.Before != nil || !rec.BeforePrevious {
+		t.Fatalf("newest record = before %v, beforePrevious %v, err %v", rec.Before, rec.BeforePrevious, err)
+	}
+
+	if _, err := Undo(path, false); err != nil {
+		t.Fatalf("Undo: %v", err)
+	}
+	g = Clone(g)
+	delete(g.Items, "t0")
+	if err := SaveWithHistory(path, g, "delete"); err != nil {
+		t.Fatalf("SaveWithHistory: %v", err)
+	}
+	if _, err := Redo(path, false); !errors.Is(err, ErrNothingToRedo) {
+		t.Fatalf("expected redo branch to be discarded, got %v", err)
+	}
+}
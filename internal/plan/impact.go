@@ -0,0 +1,189 @@
+package plan
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// Impact describes what a change from one graph to another touches beyond
+// the items it changes directly.
+type Impact struct {
+	// Changed are the items removed, moved to another parent, or rescoped
+	// (title, description, prompt or acceptance criteria edited).
+	Changed []string
+	// Removed are the changed items that no longer exist.
+	Removed []string
+	// Dependents are the items outside Changed that depend on a changed item,
+	// directly or transitively. A dep on a parent counts as a dep on each of
+	// its descendants.
+	Dependents []string
+	// DoneDependents are the dependents already done: finished work that
+	// assumed the changed items as they were.
+	DoneDependents []string
+	// Parents are the parent items whose children, or their status or
+	// updatedAt, differ between the graphs. These inputs make up the
+	// completion signature of a parent review.
+	Parents []string
+}
+
+// Empty reports whether the change touches nothing.
+func (i Impact) Empty() bool {
+	return len(i.Changed) == 0 && len(i.Parents) == 0
+}
+
+// AnalyzeImpact compares before and after and reports the impact of the
+// change on the before graph.
+func AnalyzeImpact(before, after WorkGraph) Impact {
+	var out Impact
+	changed := map[string]bool{}
+	for id, b := range before.Items {
+		a, ok := after.Items[id]
+		switch {
+		case !ok:
+			out.Removed = append(out.Removed, id)
+		case !parentChanged(b.ParentID, a.ParentID) && !scopeChanged(b, a):
+			continue
+		}
+		changed[id] = true
+		out.Changed = append(out.Changed, id)
+	}
+
+	// Walk reverse deps from each changed item and its ancestors, so a dep on
+	// a parent of a changed item is followed too.
+	seen := map[string]bool{}
+	queue := append([]string{}, out.Changed...)
+	sort.Strings(queue)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, cur := range selfAndAncestors(before, id) {
+			for _, dependent := range Dependents(before, cur) {
+				if changed[dependent] || seen[dependent] {
+					continue
+				}
+				seen[dependent] = true
+				out.Dependents = append(out.Dependents, dependent)
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	for _, id := range out.Dependents {
+		if before.Items[id].Status == StatusDone {
+			out.DoneDependents = append(out.DoneDependents, id)
+		}
+	}
+
+	for id, b := range before.Items {
+		if len(b.ChildIDs) == 0 {
+			continue
+		}
+		a, ok := after.Items[id]
+		if !ok || childInputsChanged(before, after, b, a) {
+			out.Parents = append(out.Parents, id)
+		}
+	}
+
+	sort.Strings(out.Changed)
+	sort.Strings(out.Removed)
+	sort.Strings(out.Dependents)
+	sort.Strings(out.DoneDependents)
+	sort.Strings(out.Parents)
+	return out
+}
+
+func scopeChanged(a, b WorkItem) bool {
+	return a.Title != b.Title ||
+		a.Description != b.Description ||
+		a.Prompt != b.Prompt ||
+		!stringSliceEqual(a.AcceptanceCriteria, b.AcceptanceCriteria)
+}
+
+func childInputsChanged(before, after WorkGraph, b, a WorkItem) bool {
+	if !stringSliceEqual(b.ChildIDs, a.ChildIDs) {
+		return true
+	}
+	for _, childID := range b.ChildIDs {
+		bc, bok := before.Items[childID]
+		ac, aok := after.Items[childID]
+		if bok != aok || bc.Status != ac.Status || !bc.UpdatedAt.Equal(ac.UpdatedAt) {
+			return true
+		}
+	}
+	return false
+}
+
+func selfAndAncestors(g WorkGraph, id string) []string {
+	out := []string{id}
+	seen := map[string]bool{id: true}
+	for cur := g.Items[id].ParentID; cur != nil && *cur != "" && !seen[*cur]; cur = g.Items[*cur].ParentID {
+		seen[*cur] = true
+		out = append(out, *cur)
+	}
+	return out
+}
+
+// ExternalDependent is an item of another plan with a dep on an item of the
+// plan being changed.
+type ExternalDependent struct {
+	// Plan is the name of the plan holding Item; empty for the default plan.
+	Plan string
+	Item WorkItem
+	// Dep is Item's dep on the changed plan ("plan:<name>#<id>").
+	Dep string
+}
+
+// ExternalDependents returns the items of the other plans of planPath's
+// project (see ListPlans) that depend on one of ids in g, the plan at
+// planPath, or on an ancestor of one. They are sorted by plan, then item ID.
+// Plans that cannot be read are skipped.
+func ExternalDependents(planPath string, g WorkGraph, ids []string) ([]ExternalDependent, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	root := ProjectRoot(planPath)
+	plans, err := ListPlans(root)
+	if err != nil {
+		return nil, err
+	}
+	self := absPath(planPath)
+	targets := map[string]bool{}
+	for _, id := range ids {
+		for _, cur := range selfAndAncestors(g, id) {
+			targets[cur] = true
+		}
+	}
+
+	var out []ExternalDependent
+	for _, p := range plans {
+		if absPath(p.Path) == self {
+			continue
+		}
+		other, err := loadExternalPlan(p.Path)
+		if err != nil {
+			continue
+		}
+		otherIDs := make([]string, 0, len(other.Items))
+		for id := range other.Items {
+			otherIDs = append(otherIDs, id)
+		}
+		sort.Strings(otherIDs)
+		for _, id := range otherIDs {
+			for _, dep := range other.Items[id].Deps {
+				d, err := ParseExternalDep(dep)
+				if err != nil || !targets[d.ID] || absPath(d.Path(root)) != self {
+					continue
+				}
+				out = append(out, ExternalDependent{Plan: p.Name, Item: other.Items[id], Dep: dep})
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
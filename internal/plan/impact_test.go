@@ -0,0 +1,94 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeImpactDeleteFollowsTransitiveAndParentDeps(t *testing.T) {
+	// feature{a, b}; c -> a; d -> c (done); e -> feature; f unrelated.
+	var t0 time.Time
+	feature := wi("feature", t0)
+	feature.ChildIDs = []string{"a", "b"}
+	a := wi("a", t0)
+	a.ParentID = &feature.ID
+	a.Status = StatusDone
+	b := wi("b", t0)
+	b.ParentID = &feature.ID
+	b.Status = StatusDone
+	c := wi("c", t0)
+	c.Deps = []string{"a"}
+	d := wi("d", t0)
+	d.Deps = []string{"c"}
+	d.Status = StatusDone
+	e := wi("e", t0)
+	e.Deps = []string{"feature"}
+	before := graphOf(feature, a, b, c, d, e, wi("f", t0))
+
+	after := Clone(before)
+	delete(after.Items, "b")
+	parent := after.Items["feature"]
+	parent.ChildIDs = []string{"a"}
+	after.Items["feature"] = parent
+
+	got := AnalyzeImpact(before, after)
+	want := Impact{
+		Changed:        []string{"b"},
+		Removed:        []string{"b"},
+		Dependents:     []string{"e"},
+		DoneDependents: nil,
+		Parents:        []string{"feature"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("impact = %+v, want %+v", got, want)
+	}
+
+	rescoped := Clone(before)
+	item := rescoped.Items["a"]
+	item.Prompt = "do something else"
+	rescoped.Items["a"] = item
+	got = AnalyzeImpact(before, rescoped)
+	if !reflect.DeepEqual(got.Dependents, []string{"c", "d", "e"}) || !reflect.DeepEqual(got.DoneDependents, []string{"d"}) {
+		t.Fatalf("rescope impact = %+v", got)
+	}
+	if len(got.Parents) != 0 {
+		t.Fatalf("rescope without updatedAt change touched parents %v", got.Parents)
+	}
+}
+
+func TestAnalyzeImpactMoveAndNoop(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	var t0 time.Time
+	p1 := wi("p1", t0)
+	p1.ChildIDs = []string{"x", "y"}
+	p2 := wi("p2", t0)
+	p2.ChildIDs = []string{"z"}
+	x := wi("x", t0)
+	x.ParentID = &p1.ID
+	y := wi("y", t0)
+	y.ParentID = &p1.ID
+	z := wi("z", t0)
+	z.ParentID = &p2.ID
+	w := wi("w", t0)
+	w.Deps = []string{"x"}
+	before := graphOf(p1, p2, x, y, z, w)
+
+	if impact := AnalyzeImpact(before, Clone(before)); !impact.Empty() {
+		t.Fatalf("expected empty impact, got %+v", impact)
+	}
+
+	after := Clone(before)
+	moved := after.Items["x"]
+	moved.ParentID = &p2.ID
+	moved.UpdatedAt = now
+	after.Items["x"] = moved
+	from, to := after.Items["p1"], after.Items["p2"]
+	from.ChildIDs = []string{"y"}
+	to.ChildIDs = []string{"z", "x"}
+	after.Items["p1"], after.Items["p2"] = from, to
+	got := AnalyzeImpact(before, after)
+	if !reflect.DeepEqual(got.Changed, []string{"x"}) || !reflect.DeepEqual(got.Dependents, []string{"w"}) || !reflect.DeepEqual(got.Parents, []string{"p1", "p2"}) {
+		t.Fatalf("move impact = %+v", got)
+	}
+}
@@ -7,19 +7,51 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"reflect"
 )
 
 var ErrPlanNotFound = errors.New("plan file not found")
 
+// Load reads the plan at path. When path does not exist but the project uses
+// the split layout (see split.go), the plan is assembled from the feature files.
+// External deps are resolved against the project the plan belongs to.
 func Load(path string) (WorkGraph, error) {
+	g, err := loadFile(path)
+	if err != nil {
+		return WorkGraph{}, err
+	}
+	ResolveExternalDeps(path, &g)
+	return g, nil
+}
+
+func loadFile(path string) (WorkGraph, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
+			if IsSplit(path) {
+				return loadSplit(path)
+			}
 			return WorkGraph{}, ErrPlanNotFound
 		}
 		return WorkGraph{}, fmt.Errorf("read plan file %s: %w", path, err)
 	}
+	return Decode(path, b, FormatForPath(path))
+}
+
+// Decode parses plan data in the given format; name is used in error messages.
+func Decode(name string, b []byte, format Format) (WorkGraph, error) {
+	if format == FormatYAML {
+		var err error
+		b, err = yamlToJSON(b, reflect.TypeOf(WorkGraph{}))
+		if err != nil {
+			return WorkGraph{}, fmt.Errorf("parse plan file %s: %w", name, err)
+		}
+	}
+	return decodePlan(name, b)
+}
 
+func decodePlan(path string, b []byte) (WorkGraph, error) {
 	dec := json.NewDecoder(bytes.NewReader(b))
 	dec.DisallowUnknownFields()
 
@@ -37,11 +69,40 @@ func Load(path string) (WorkGraph, error) {
 	return g, nil
 }
 
+// SaveAtomic writes g to path in the format implied by its extension, or to
+// the per-feature files when the project uses the split layout.
 func SaveAtomic(path string, g WorkGraph) error {
+	if IsSplit(path) {
+		return SaveSplit(path, g)
+	}
+	return SaveFile(path, g)
+}
+
+// SaveFile atomically writes g as a single plan file, regardless of layout.
+func SaveFile(path string, g WorkGraph) error {
+	b, err := Marshal(g, FormatForPath(path))
+	if err != nil {
+		return err
+	}
+	// Named plans live in their own directory, which may not exist yet.
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create plan directory: %w", err)
+	}
+	return WriteFileAtomic(path, b, 0o644)
+}
+
+// Marshal encodes g in the given format, with a trailing newline.
+func Marshal(g WorkGraph, format Format) ([]byte, error) {
+	if format == FormatYAML {
+		b, err := encodeYAML(g)
+		if err != nil {
+			return nil, fmt.Errorf("marshal plan: %w", err)
+		}
+		return b, nil
+	}
 	b, err := json.MarshalIndent(g, "", "  ")
 	if err != nil {
-		return fmt.Errorf("marshal plan: %w", err)
+		return nil, fmt.Errorf("marshal plan: %w", err)
 	}
-	b = append(b, '\n')
-	return atomicWriteFile(path, b, 0o644)
+	return append(b, '\n'), nil
 }
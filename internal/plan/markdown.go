@@ -0,0 +1,333 @@
+package plan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Markdown import conventions:
+//   - Headings (#..######) and list items (-, *, +, 1.) become work items.
+//     Heading levels and list indentation define the parent/child hierarchy;
+//     list items nest under the nearest preceding heading.
+//   - A leading checkbox marks status: "[x]" is done, "[ ]" is todo.
+//   - "(after: a, b)" in a title adds deps; references may be ids or titles.
+//   - "{#id}" in a title sets an explicit id; otherwise the id is a slug of the title.
+//   - Non-list text indented under a list item (or any paragraph under a
+//     heading) becomes that item's description.
+var (
+	mdHeadingRe  = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	mdListItemRe = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdCheckboxRe = regexp.MustCompile(`^\[([ xX])\]\s*`)
+	mdAfterRe    = regexp.MustCompile(`\(\s*after:\s*([^)]*)\)`)
+	mdExplicitRe = regexp.MustCompile(`\{#([A-Za-z0-9_.:-]+)\}`)
+	mdFenceRe    = regexp.MustCompile("^\\s*(```|~~~)")
+	mdSlugStrip  = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+const mdMaxSlugLen = 48
+
+type mdNode struct {
+	line       int
+	title      string
+	explicitID string
+	afterRefs  []string
+	done       bool
+	parent     int // index into nodes, -1 for root
+	indent     int // content column for description lines
+	descLines  []string
+	id         string
+	childIdxes []int
+}
+
+type mdListFrame struct {
+	indent int
+	node   int
+}
+
+type mdHeadingFrame struct {
+	level int
+	node  int
+}
+
+// ParseMarkdown deterministically converts a Markdown checklist/outline into a WorkGraph.
+// The same input always yields the same ids and hierarchy; all timestamps are set to now.
+func ParseMarkdown(r io.Reader, now time.Time) (WorkGraph, error) {
+	var nodes []*mdNode
+	var headings []mdHeadingFrame
+	var lists []mdListFrame
+	current := -1
+	inFence := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := strings.ReplaceAll(strings.TrimRight(scanner.Text(), " \t\r"), "\t", "    ")
+
+		if mdFenceRe.MatchString(raw) {
+			inFence = !inFence
+			if current >= 0 {
+				nodes[current].appendDesc(raw)
+			}
+			continue
+		}
+		if inFence {
+			if current >= 0 {
+				nodes[current].appendDesc(raw)
+			}
+			continue
+		}
+
+		if m := mdHeadingRe.FindStringSubmatch(raw); m != nil {
+			level := len(m[1])
+			for len(headings) > 0 && headings[len(headings)-1].level >= level {
+				headings = headings[:len(headings)-1]
+			}
+			parent := -1
+			if len(headings) > 0 {
+				parent = headings[len(headings)-1].node
+			}
+			n, err := newMDNode(lineNo, m[2], parent, 0)
+			if err != nil {
+				return WorkGraph{}, err
+			}
+			nodes = append(nodes, n)
+			current = len(nodes) - 1
+			headings = append(headings, mdHeadingFrame{level: level, node: current})
+			lists = lists[:0]
+			continue
+		}
+
+		if m := mdListItemRe.FindStringSubmatch(raw); m != nil {
+			indent := len(m[1])
+			for len(lists) > 0 && lists[len(lists)-1].indent >= indent {
+				lists = lists[:len(lists)-1]
+			}
+			parent := -1
+			if len(lists) > 0 {
+				parent = lists[len(lists)-1].node
+			} else if len(headings) > 0 {
+				parent = headings[len(headings)-1].node
+			}
+			n, err := newMDNode(lineNo, m[3], parent, indent+len(m[2])+1)
+			if err != nil {
+				return WorkGraph{}, err
+			}
+			nodes = append(nodes, n)
+			current = len(nodes) - 1
+			lists = append(lists, mdListFrame{indent: indent, node: current})
+			continue
+		}
+
+		if strings.TrimSpace(raw) == "" {
+			if current >= 0 {
+				nodes[current].appendDesc("")
+			}
+			continue
+		}
+
+		// Unindented text ends any open list and belongs to the enclosing heading.
+		if leadingSpaces(raw) == 0 && len(lists) > 0 {
+			lists = lists[:0]
+			current = -1
+			if len(headings) > 0 {
+				current = headings[len(headings)-1].node
+			}
+		}
+		if current >= 0 {
+			nodes[current].appendDesc(raw)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return WorkGraph{}, fmt.Errorf("read markdown: %w", err)
+	}
+
+	if err := assignMDIDs(nodes); err != nil {
+		return WorkGraph{}, err
+	}
+
+	g := WorkGraph{SchemaVersion: SchemaVersion, Items: map[string]WorkItem{}}
+	for i, n := range nodes {
+		if n.parent >= 0 {
+			nodes[n.parent].childIdxes = append(nodes[n.parent].childIdxes, i)
+		}
+	}
+	for _, n := range nodes {
+		deps, err := resolveMDRefs(nodes, n)
+		if err != nil {
+			return WorkGraph{}, err
+		}
+		childIDs := make([]string, 0, len(n.childIdxes))
+		for _, ci := range n.childIdxes {
+			childIDs = append(childIDs, nodes[ci].id)
+		}
+		var parentID *string
+		if n.parent >= 0 {
+			pid := nodes[n.parent].id
+			parentID = &pid
+		}
+		status := StatusTodo
+		if n.done {
+			status = StatusDone
+		}
+		g.Items[n.id] = WorkItem{
+			ID:                 n.id,
+			Title:              n.title,
+			Description:        n.description(),
+			AcceptanceCriteria: []string{},
+			Prompt:             "",
+			ParentID:           parentID,
+			ChildIDs:           childIDs,
+			Deps:               deps,
+			Status:             status,
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		}
+	}
+
+	// Containers whose children were all checked off are done as well.
+	for _, n := range nodes {
+		if n.done && len(n.childIdxes) == 0 {
+			PropagateParentCompletion(&g, n.id, now)
+		}
+	}
+
+	return g, nil
+}
+
+func newMDNode(line int, text string, parent int, indent int) (*mdNode, error) {
+	n := &mdNode{line: line, parent: parent, indent: indent}
+	text = strings.TrimSpace(text)
+	if m := mdCheckboxRe.FindStringSubmatch(text); m != nil {
+		n.done = m[1] != " "
+		text = text[len(m[0]):]
+	}
+	for _, m := range mdAfterRe.FindAllStringSubmatch(text, -1) {
+		for _, ref := range strings.Split(m[1], ",") {
+			if ref = strings.TrimSpace(ref); ref != "" {
+				n.afterRefs = append(n.afterRefs, ref)
+			}
+		}
+	}
+	text = mdAfterRe.ReplaceAllString(text, "")
+	if m := mdExplicitRe.FindAllStringSubmatch(text, -1); len(m) > 0 {
+		if len(m) > 1 {
+			return nil, fmt.Errorf("line %d: multiple explicit ids", line)
+		}
+		n.explicitID = m[0][1]
+	}
+	text = mdExplicitRe.ReplaceAllString(text, "")
+	n.title = strings.Join(strings.Fields(text), " ")
+	if n.title == "" {
+		return nil, fmt.Errorf("line %d: item title is empty", line)
+	}
+	return n, nil
+}
+
+func (n *mdNode) appendDesc(line string) {
+	strip := leadingSpaces(line)
+	if strip > n.indent {
+		strip = n.indent
+	}
+	n.descLines = append(n.descLines, line[strip:])
+}
+
+func (n *mdNode) description() string {
+	var out []string
+	blank := false
+	for _, line := range n.descLines {
+		if strings.TrimSpace(line) == "" {
+			blank = len(out) > 0
+			continue
+		}
+		if blank {
+			out = append(out, "")
+			blank = false
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+func assignMDIDs(nodes []*mdNode) error {
+	taken := map[string]int{}
+	for _, n := range nodes {
+		if n.explicitID == "" {
+			continue
+		}
+		if prev, ok := taken[n.explicitID]; ok {
+			return fmt.Errorf("line %d: duplicate id %q (first used on line %d)", n.line, n.explicitID, prev)
+		}
+		taken[n.explicitID] = n.line
+		n.id = n.explicitID
+	}
+	for _, n := range nodes {
+		if n.id != "" {
+			continue
+		}
+		base := markdownSlug(n.title)
+		id := base
+		for i := 2; ; i++ {
+			if _, ok := taken[id]; !ok {
+				break
+			}
+			id = fmt.Sprintf("%s-%d", base, i)
+		}
+		taken[id] = n.line
+		n.id = id
+	}
+	return nil
+}
+
+func resolveMDRefs(nodes []*mdNode, n *mdNode) ([]string, error) {
+	deps := []string{}
+	for _, ref := range n.afterRefs {
+		id, ok := lookupMDRef(nodes, ref)
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown dependency %q", n.line, ref)
+		}
+		if id == n.id {
+			return nil, fmt.Errorf("line %d: item cannot depend on itself", n.line)
+		}
+		if !contains(deps, id) {
+			deps = append(deps, id)
+		}
+	}
+	return deps, nil
+}
+
+// lookupMDRef resolves an (after: ...) reference by exact id first, then by title slug.
+func lookupMDRef(nodes []*mdNode, ref string) (string, bool) {
+	for _, n := range nodes {
+		if n.id == ref {
+			return n.id, true
+		}
+	}
+	slug := markdownSlug(ref)
+	for _, n := range nodes {
+		if markdownSlug(n.title) == slug {
+			return n.id, true
+		}
+	}
+	return "", false
+}
+
+func markdownSlug(s string) string {
+	slug := strings.Trim(mdSlugStrip.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(slug) > mdMaxSlugLen {
+		slug = strings.TrimRight(slug[:mdMaxSlugLen], "-")
+	}
+	if slug == "" {
+		return "item"
+	}
+	return slug
+}
+
+func leadingSpaces(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " "))
+}
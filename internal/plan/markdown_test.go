@@ -0,0 +1,134 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMarkdownOutline(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	input := `# Storage layer
+
+Persist plans on disk.
+
+- [x] Define schema {#schema}
+  Field names match the JSON file.
+
+  Keep it versioned.
+- [ ] Write loader (after: schema)
+  - [ ] Decode items
+  - [ ] Reject trailing data (after: Decode items)
+
+## CLI
+1. Wire commands (after: write-loader, schema)
+`
+	g, err := ParseMarkdown(strings.NewReader(input), now)
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if errs := Validate(g); len(errs) != 0 {
+		t.Fatalf("Validate: %v", errs)
+	}
+
+	root, ok := g.Items["storage-layer"]
+	if !ok {
+		t.Fatalf("expected storage-layer item, got %v", keys(g))
+	}
+	if root.ParentID != nil {
+		t.Fatalf("storage-layer parent = %v, want nil", *root.ParentID)
+	}
+	if root.Description != "Persist plans on disk." {
+		t.Fatalf("storage-layer description = %q", root.Description)
+	}
+	if got, want := strings.Join(root.ChildIDs, ","), "schema,write-loader,cli"; got != want {
+		t.Fatalf("storage-layer children = %s, want %s", got, want)
+	}
+
+	schema := g.Items["schema"]
+	if schema.Status != StatusDone {
+		t.Fatalf("schema status = %s, want done", schema.Status)
+	}
+	if schema.Title != "Define schema" {
+		t.Fatalf("schema title = %q", schema.Title)
+	}
+	if want := "Field names match the JSON file.\n\nKeep it versioned."; schema.Description != want {
+		t.Fatalf("schema description = %q, want %q", schema.Description, want)
+	}
+
+	loader := g.Items["write-loader"]
+	if loader.Status != StatusTodo {
+		t.Fatalf("write-loader status = %s, want todo", loader.Status)
+	}
+	if got := strings.Join(loader.Deps, ","); got != "schema" {
+		t.Fatalf("write-loader deps = %s, want schema", got)
+	}
+	if got := strings.Join(loader.ChildIDs, ","); got != "decode-items,reject-trailing-data" {
+		t.Fatalf("write-loader children = %s", got)
+	}
+	if got := strings.Join(g.Items["reject-trailing-data"].Deps, ","); got != "decode-items" {
+		t.Fatalf("reject-trailing-data deps = %s, want decode-items", got)
+	}
+
+	wire := g.Items["wire-commands"]
+	if wire.ParentID == nil || *wire.ParentID != "cli" {
+		t.Fatalf("wire-commands parent = %v, want cli", wire.ParentID)
+	}
+	if got := strings.Join(wire.Deps, ","); got != "write-loader,schema" {
+		t.Fatalf("wire-commands deps = %s", got)
+	}
+	if !wire.CreatedAt.Equal(now) || !wire.UpdatedAt.Equal(now) {
+		t.Fatalf("timestamps not set to now: %v %v", wire.CreatedAt, wire.UpdatedAt)
+	}
+}
+
+func TestParseMarkdownDuplicateTitlesGetSuffixes(t *testing.T) {
+	g, err := ParseMarkdown(strings.NewReader("- Tests\n- Tests\n- Tests\n"), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	for _, id := range []string{"tests", "tests-2", "tests-3"} {
+		if _, ok := g.Items[id]; !ok {
+			t.Fatalf("expected id %q, got %v", id, keys(g))
+		}
+	}
+}
+
+func TestParseMarkdownPropagatesParentCompletion(t *testing.T) {
+	g, err := ParseMarkdown(strings.NewReader("# Done feature\n- [x] a\n- [X] b\n# Open feature\n- [x] c\n- [ ] d\n"), time.Now().UTC())
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	if g.Items["done-feature"].Status != StatusDone {
+		t.Fatalf("done-feature status = %s, want done", g.Items["done-feature"].Status)
+	}
+	if g.Items["open-feature"].Status != StatusTodo {
+		t.Fatalf("open-feature status = %s, want todo", g.Items["open-feature"].Status)
+	}
+}
+
+func TestParseMarkdownErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown dep":  "- a (after: missing)\n",
+		"self dep":     "- a {#x} (after: x)\n",
+		"duplicate id": "- a {#x}\n- b {#x}\n",
+		"empty title":  "- [ ] (after: a)\n",
+	}
+	for name, input := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseMarkdown(strings.NewReader(input), time.Now().UTC()); err == nil {
+				t.Fatalf("expected error")
+			} else if !strings.HasPrefix(err.Error(), "line ") {
+				t.Fatalf("expected line-numbered error, got %v", err)
+			}
+		})
+	}
+}
+
+func keys(g WorkGraph) []string {
+	out := make([]string, 0, len(g.Items))
+	for id := range g.Items {
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,400 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MergeConflict records a field that changed differently on both sides of a
+// three-way merge and how it was resolved.
+type MergeConflict struct {
+	ItemID     string
+	Field      string
+	Resolution string
+}
+
+type MergeResult struct {
+	Graph     WorkGraph
+	Conflicts []MergeConflict
+}
+
+const (
+	mergeSideOurs   = "ours"
+	mergeSideTheirs = "theirs"
+)
+
+// Merge3 performs a semantic three-way merge of plan graphs at the WorkItem level.
+//
+//   - Items added or deleted on one side are added/deleted. An item deleted on
+//     one side but modified on the other is kept.
+//   - Deps and childIds merge as sets: additions and removals from both sides
+//     are applied to base (order follows ours, then theirs).
+//   - Other fields take whichever side changed them. When both sides changed a
+//     field differently, the side with the later updatedAt wins (ours on ties)
+//     and a conflict marker is appended to the item's notes.
+//
+// Parent/child links are normalized afterwards; callers should still run Validate.
+func Merge3(base, ours, theirs WorkGraph, now time.Time) MergeResult {
+	res := MergeResult{Graph: WorkGraph{SchemaVersion: ours.SchemaVersion, Items: map[string]WorkItem{}}}
+	if res.Graph.SchemaVersion == 0 {
+		res.Graph.SchemaVersion = theirs.SchemaVersion
+	}
+
+	ids := map[string]bool{}
+	for _, g := range []WorkGraph{base, ours, theirs} {
+		for id := range g.Items {
+			ids[id] = true
+		}
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	for _, id := range sorted {
+		b, inBase := base.Items[id]
+		o, inOurs := ours.Items[id]
+		t, inTheirs := theirs.Items[id]
+
+		switch {
+		case inOurs && inTheirs:
+			if !inBase {
+				b = WorkItem{ID: id}
+			}
+			merged, conflicts := mergeItem(b, o, t)
+			res.Graph.Items[id] = merged
+			res.Conflicts = append(res.Conflicts, conflicts...)
+		case inOurs && !inTheirs:
+			if !inBase {
+				res.Graph.Items[id] = o
+				continue
+			}
+			if itemUnchanged(b, o) {
+				continue // deleted on theirs
+			}
+			res.Graph.Items[id] = o
+			res.Conflicts = append(res.Conflicts, MergeConflict{ItemID: id, Field: "item", Resolution: "deleted on theirs but modified on ours; kept ours"})
+		case !inOurs && inTheirs:
+			if !inBase {
+				res.Graph.Items[id] = t
+				continue
+			}
+			if itemUnchanged(b, t) {
+				continue // deleted on ours
+			}
+			res.Graph.Items[id] = t
+			res.Conflicts = append(res.Conflicts, MergeConflict{ItemID: id, Field: "item", Resolution: "deleted on ours but modified on theirs; kept theirs"})
+		}
+	}
+
+	normalizeMergedLinks(&res.Graph)
+
+	for _, c := range res.Conflicts {
+		it, ok := res.Graph.Items[c.ItemID]
+		if !ok {
+			continue
+		}
+		marker := fmt.Sprintf("[merge conflict %s] %s: %s", now.UTC().Format(time.RFC3339), c.Field, c.Resolution)
+		if it.Notes == nil || *it.Notes == "" {
+			it.Notes = &marker
+		} else {
+			combined := *it.Notes + "\n" + marker
+			it.Notes = &combined
+		}
+		res.Graph.Items[c.ItemID] = it
+	}
+	return res
+}
+
+func itemUnchanged(a, b WorkItem) bool {
+	return itemContentEqual(a, b) && !parentChanged(a.ParentID, b.ParentID) && stringSliceEqual(a.ChildIDs, b.ChildIDs)
+}
+
+func mergeItem(b, o, t WorkItem) (WorkItem, []MergeConflict) {
+	oursWins := !t.UpdatedAt.After(o.UpdatedAt)
+	var conflicts []MergeConflict
+	conflict := func(field string, oursVal, theirsVal string) {
+		winner, kept, dropped := mergeSideOurs, oursVal, theirsVal
+		if !oursWins {
+			winner, kept, dropped = mergeSideTheirs, theirsVal, oursVal
+		}
+		conflicts = append(conflicts, MergeConflict{
+			ItemID:     o.ID,
+			Field:      field,
+			Resolution: fmt.Sprintf("kept %s %s over %s", winner, mergeExcerpt(kept), mergeExcerpt(dropped)),
+		})
+	}
+
+	out := o
+	out.ID = o.ID
+
+	var c bool
+	if out.Title, c = merge3String(b.Title, o.Title, t.Title, oursWins); c {
+		conflict("title", o.Title, t.Title)
+	}
+	if out.Description, c = merge3String(b.Description, o.Description, t.Description, oursWins); c {
+		conflict("description", o.Description, t.Description)
+	}
+	if out.Prompt, c = merge3String(b.Prompt, o.Prompt, t.Prompt, oursWins); c {
+		conflict("prompt", o.Prompt, t.Prompt)
+	}
+	var status string
+	if status, c = merge3String(string(b.Status), string(o.Status), string(t.Status), oursWins); c {
+		conflict("status", string(o.Status), string(t.Status))
+	}
+	out.Status = Status(status)
+
+	var parent string
+	var parentSet bool
+	bp, bSet := optString(b.ParentID)
+	op, oSet := optString(o.ParentID)
+	tp, tSet := optString(t.ParentID)
+	if parent, c = merge3String(encodeOpt(bp, bSet), encodeOpt(op, oSet), encodeOpt(tp, tSet), oursWins); c {
+		conflict("parentId", orRoot(op, oSet), orRoot(tp, tSet))
+	}
+	parent, parentSet = decodeOpt(parent)
+	if parentSet {
+		out.ParentID = &parent
+	} else {
+		out.ParentID = nil
+	}
+
+	if stringSliceEqual(o.AcceptanceCriteria, b.AcceptanceCriteria) {
+		out.AcceptanceCriteria = append([]string{}, t.AcceptanceCriteria...)
+	} else if !stringSliceEqual(t.AcceptanceCriteria, b.AcceptanceCriteria) && !stringSliceEqual(o.AcceptanceCriteria, t.AcceptanceCriteria) {
+		conflict("acceptanceCriteria", strings.Join(o.AcceptanceCriteria, "; "), strings.Join(t.AcceptanceCriteria, "; "))
+		if !oursWins {
+			out.AcceptanceCriteria = append([]string{}, t.AcceptanceCriteria...)
+		}
+	}
+	if out.AcceptanceCriteria == nil {
+		out.AcceptanceCriteria = []string{}
+	}
+
+	// Notes from both sides are kept rather than dropped.
+	bn, _ := optString(b.Notes)
+	on, oHas := optString(o.Notes)
+	tn, tHas := optString(t.Notes)
+	switch {
+	case on == tn || tn == bn:
+		out.Notes = copyOpt(on, oHas)
+	case on == bn:
+		out.Notes = copyOpt(tn, tHas)
+	default:
+		first, second := on, tn
+		if !oursWins {
+			first, second = tn, on
+		}
+		joined := strings.TrimSpace(first + "\n" + second)
+		out.Notes = &joined
+		conflicts = append(conflicts, MergeConflict{ItemID: o.ID, Field: "notes", Resolution: "both sides edited notes; kept both"})
+	}
+
+	var agentKey string
+	if agentKey, c = merge3String(b.Agent.key(), o.Agent.key(), t.Agent.key(), oursWins); c {
+		conflict("agent", o.Agent.key(), t.Agent.key())
+	}
+	switch agentKey {
+	case o.Agent.key():
+		out.Agent = o.Agent.clone()
+	case t.Agent.key():
+		out.Agent = t.Agent.clone()
+	default:
+		out.Agent = b.Agent.clone()
+	}
+
+	var estimate string
+	if estimate, c = merge3String(estimateKey(b.EstimateMinutes), estimateKey(o.EstimateMinutes), estimateKey(t.EstimateMinutes), oursWins); c {
+		conflict("estimateMinutes", estimateKey(o.EstimateMinutes), estimateKey(t.EstimateMinutes))
+	}
+	switch estimate {
+	case estimateKey(o.EstimateMinutes):
+		out.EstimateMinutes = copyIntPtr(o.EstimateMinutes)
+	case estimateKey(t.EstimateMinutes):
+		out.EstimateMinutes = copyIntPtr(t.EstimateMinutes)
+	default:
+		out.EstimateMinutes = copyIntPtr(b.EstimateMinutes)
+	}
+
+	out.Deps = mergeIDSet(b.Deps, o.Deps, t.Deps)
+	out.ChildIDs = mergeIDSet(b.ChildIDs, o.ChildIDs, t.ChildIDs)
+	out.LintIgnore = mergeIDSet(b.LintIgnore, o.LintIgnore, t.LintIgnore)
+	if len(out.LintIgnore) == 0 {
+		out.LintIgnore = nil
+	}
+
+	out.DepRationale = nil
+	keys := map[string]bool{}
+	for _, m := range []map[string]string{b.DepRationale, o.DepRationale, t.DepRationale} {
+		for k := range m {
+			keys[k] = true
+		}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+	for _, k := range sortedKeys {
+		bv, bOK := b.DepRationale[k]
+		ov, oOK := o.DepRationale[k]
+		tv, tOK := t.DepRationale[k]
+		v, c := merge3String(encodeOpt(bv, bOK), encodeOpt(ov, oOK), encodeOpt(tv, tOK), oursWins)
+		if c {
+			conflict("depRationale."+k, ov, tv)
+		}
+		if val, ok := decodeOpt(v); ok {
+			if out.DepRationale == nil {
+				out.DepRationale = map[string]string{}
+			}
+			out.DepRationale[k] = val
+		}
+	}
+
+	out.CreatedAt = o.CreatedAt
+	if !t.CreatedAt.IsZero() && (out.CreatedAt.IsZero() || t.CreatedAt.Before(out.CreatedAt)) {
+		out.CreatedAt = t.CreatedAt
+	}
+	out.UpdatedAt = o.UpdatedAt
+	if t.UpdatedAt.After(out.UpdatedAt) {
+		out.UpdatedAt = t.UpdatedAt
+	}
+	return out, conflicts
+}
+
+// merge3String returns the merged value and whether both sides changed it differently.
+func merge3String(base, ours, theirs string, oursWins bool) (string, bool) {
+	switch {
+	case ours == theirs:
+		return ours, false
+	case ours == base:
+		return theirs, false
+	case theirs == base:
+		return ours, false
+	case oursWins:
+		return ours, true
+	default:
+		return theirs, true
+	}
+}
+
+// mergeIDSet applies additions and removals from both sides to base.
+func mergeIDSet(base, ours, theirs []string) []string {
+	removed := map[string]bool{}
+	for _, id := range base {
+		if !containsID(ours, id) || !containsID(theirs, id) {
+			removed[id] = true
+		}
+	}
+	out := []string{}
+	for _, list := range [][]string{ours, theirs} {
+		for _, id := range list {
+			if removed[id] || containsID(out, id) {
+				continue
+			}
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// normalizeMergedLinks drops references to missing items (external deps are
+// kept) and makes childIds agree with parentId (parentId is authoritative).
+func normalizeMergedLinks(g *WorkGraph) {
+	for id, it := range g.Items {
+		if it.ParentID != nil {
+			if _, ok := g.Items[*it.ParentID]; !ok || *it.ParentID == id {
+				it.ParentID = nil
+			}
+		}
+		deps := make([]string, 0, len(it.Deps))
+		for _, dep := range it.Deps {
+			if _, ok := g.Items[dep]; ok || IsExternalDep(dep) {
+				deps = append(deps, dep)
+			}
+		}
+		it.Deps = deps
+		for k := range it.DepRationale {
+			if !containsID(deps, k) {
+				delete(it.DepRationale, k)
+			}
+		}
+		if len(it.DepRationale) == 0 {
+			it.DepRationale = nil
+		}
+		g.Items[id] = it
+	}
+
+	childrenOf := map[string][]string{}
+	for id, it := range g.Items {
+		if it.ParentID != nil {
+			childrenOf[*it.ParentID] = append(childrenOf[*it.ParentID], id)
+		}
+	}
+	for id, it := range g.Items {
+		actual := childrenOf[id]
+		sort.Strings(actual)
+		children := make([]string, 0, len(actual))
+		for _, cid := range it.ChildIDs {
+			if containsID(actual, cid) && !containsID(children, cid) {
+				children = append(children, cid)
+			}
+		}
+		for _, cid := range actual {
+			if !containsID(children, cid) {
+				children = append(children, cid)
+			}
+		}
+		it.ChildIDs = children
+		g.Items[id] = it
+	}
+}
+
+// Optional strings are encoded with a leading marker so that "unset" and ""
+// stay distinct in merge3String.
+func encodeOpt(s string, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return "=" + s
+}
+
+func decodeOpt(s string) (string, bool) {
+	if s == "" {
+		return "", false
+	}
+	return s[1:], true
+}
+
+func optString(p *string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	return *p, true
+}
+
+func copyOpt(s string, ok bool) *string {
+	if !ok {
+		return nil
+	}
+	return &s
+}
+
+func orRoot(s string, ok bool) string {
+	if !ok || s == "" {
+		return "root"
+	}
+	return s
+}
+
+func mergeExcerpt(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	const max = 60
+	if r := []rune(s); len(r) > max {
+		s = string(r[:max]) + "..."
+	}
+	return fmt.Sprintf("%q", s)
+}
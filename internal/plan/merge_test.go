@@ -0,0 +1,147 @@
+package plan
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMerge3CombinesIndependentEdits(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+	base := graphOf(wi("a", t0), wi("b", t0), wi("c", t0), wi("d", t0))
+	a := base.Items["a"]
+	a.Deps = []string{"b"}
+	base.Items["a"] = a
+
+	ours := Clone(base)
+	oa := ours.Items["a"]
+	oa.Title = "A (ours)"
+	oa.Deps = []string{"b", "c"}
+	oa.UpdatedAt = t1
+	ours.Items["a"] = oa
+
+	theirs := Clone(base)
+	ta := theirs.Items["a"]
+	ta.Prompt = "do it"
+	ta.Deps = []string{"d"} // removes b, adds d
+	ta.UpdatedAt = t1
+	theirs.Items["a"] = ta
+
+	res := Merge3(base, ours, theirs, t1)
+	if len(res.Conflicts) != 0 {
+		t.Fatalf("unexpected conflicts: %+v", res.Conflicts)
+	}
+	got := res.Graph.Items["a"]
+	if got.Title != "A (ours)" || got.Prompt != "do it" {
+		t.Fatalf("expected both field edits, got title=%q prompt=%q", got.Title, got.Prompt)
+	}
+	if strings.Join(got.Deps, ",") != "c,d" {
+		t.Fatalf("deps = %v, want [c d]", got.Deps)
+	}
+	if got.Notes != nil {
+		t.Fatalf("expected no conflict notes, got %q", *got.Notes)
+	}
+	if errs := Validate(res.Graph); len(errs) != 0 {
+		t.Fatalf("Validate: %v", errs)
+	}
+}
+
+func TestMerge3ConflictLastWriterWinsWithNote(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := graphOf(wi("a", t0))
+
+	ours := Clone(base)
+	oa := ours.Items["a"]
+	oa.Title = "ours title"
+	oa.UpdatedAt = t0.Add(time.Minute)
+	ours.Items["a"] = oa
+
+	theirs := Clone(base)
+	ta := theirs.Items["a"]
+	ta.Title = "theirs title"
+	ta.UpdatedAt = t0.Add(time.Hour)
+	theirs.Items["a"] = ta
+
+	res := Merge3(base, ours, theirs, t0.Add(2*time.Hour))
+	got := res.Graph.Items["a"]
+	if got.Title != "theirs title" {
+		t.Fatalf("title = %q, want later writer (theirs)", got.Title)
+	}
+	if !got.UpdatedAt.Equal(t0.Add(time.Hour)) {
+		t.Fatalf("updatedAt = %v, want latest", got.UpdatedAt)
+	}
+	if len(res.Conflicts) != 1 || res.Conflicts[0].Field != "title" {
+		t.Fatalf("conflicts = %+v, want one title conflict", res.Conflicts)
+	}
+	if got.Notes == nil || !strings.Contains(*got.Notes, "[merge conflict") || !strings.Contains(*got.Notes, `"ours title"`) {
+		t.Fatalf("expected conflict marker in notes, got %v", got.Notes)
+	}
+}
+
+func TestMerge3DeletesAndChildren(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	parent := "p"
+	child := func(id string) WorkItem {
+		it := wi(id, t0)
+		it.ParentID = &parent
+		return it
+	}
+	p := wi("p", t0)
+	p.ChildIDs = []string{"c1"}
+	base := graphOf(p, child("c1"), wi("gone", t0), wi("kept", t0))
+
+	ours := Clone(base)
+	op := ours.Items["p"]
+	op.ChildIDs = []string{"c1", "c2"}
+	ours.Items["p"] = op
+	ours.Items["c2"] = child("c2")
+	delete(ours.Items, "gone")
+	delete(ours.Items, "kept")
+
+	theirs := Clone(base)
+	tp := theirs.Items["p"]
+	tp.ChildIDs = []string{"c1", "c3"}
+	theirs.Items["p"] = tp
+	theirs.Items["c3"] = child("c3")
+	tk := theirs.Items["kept"]
+	tk.Description = "edited on theirs"
+	theirs.Items["kept"] = tk
+
+	res := Merge3(base, ours, theirs, t0)
+	if _, ok := res.Graph.Items["gone"]; ok {
+		t.Fatalf("expected unchanged item deleted on ours to be removed")
+	}
+	if res.Graph.Items["kept"].Description != "edited on theirs" {
+		t.Fatalf("expected item modified on theirs to be kept")
+	}
+	if got := strings.Join(res.Graph.Items["p"].ChildIDs, ","); got != "c1,c2,c3" {
+		t.Fatalf("childIds = %s, want c1,c2,c3", got)
+	}
+	if errs := Validate(res.Graph); len(errs) != 0 {
+		t.Fatalf("Validate: %v", errs)
+	}
+}
+
+func TestMerge3DropsReferencesToDeletedItems(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := graphOf(wi("a", t0), wi("b", t0))
+
+	ours := Clone(base)
+	delete(ours.Items, "b")
+
+	theirs := Clone(base)
+	ta := theirs.Items["a"]
+	ta.Deps = []string{"b"}
+	ta.DepRationale = map[string]string{"b": "needs b"}
+	theirs.Items["a"] = ta
+
+	res := Merge3(base, ours, theirs, t0)
+	if _, ok := res.Graph.Items["b"]; ok {
+		t.Fatalf("expected b deleted")
+	}
+	got := res.Graph.Items["a"]
+	if len(got.Deps) != 0 || got.DepRationale != nil {
+		t.Fatalf("expected dangling dep removed, got deps=%v rationale=%v", got.Deps, got.DepRationale)
+	}
+}
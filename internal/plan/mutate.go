@@ -1,6 +1,8 @@
 package plan
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"sort"
 	"time"
@@ -22,6 +24,19 @@ func (e HierarchyCycleError) Error() string {
 	return fmt.Sprintf("hierarchy cycle detected: %s", joinCycle(e.Cycle))
 }
 
+// NewItemID returns an unused id of the form "w_<hex>".
+// Ids are generated only on creation and never re-generated.
+func NewItemID(g WorkGraph) string {
+	for {
+		var b [6]byte
+		_, _ = rand.Read(b[:])
+		id := "w_" + hex.EncodeToString(b[:])
+		if _, ok := g.Items[id]; !ok {
+			return id
+		}
+	}
+}
+
 func AddItem(g *WorkGraph, it WorkItem, parentID *string, index *int, now time.Time) error {
 	if g.Items == nil {
 		g.Items = map[string]WorkItem{}
@@ -29,6 +44,9 @@ func AddItem(g *WorkGraph, it WorkItem, parentID *string, index *int, now time.T
 	if it.ID == "" {
 		return fmt.Errorf("id is required")
 	}
+	if IsExternalDep(it.ID) {
+		return fmt.Errorf("id must not start with %q", ExternalDepPrefix)
+	}
 	if _, ok := g.Items[it.ID]; ok {
 		return fmt.Errorf("id already exists: %q", it.ID)
 	}
@@ -172,8 +190,8 @@ func AddDep(g *WorkGraph, id string, depID string, now time.Time) error {
 	if !ok {
 		return fmt.Errorf("unknown id %q", id)
 	}
-	if _, ok := g.Items[depID]; !ok {
-		return fmt.Errorf("unknown dep id %q", depID)
+	if err := checkDepExists(*g, depID); err != nil {
+		return err
 	}
 	if depID == id {
 		return fmt.Errorf("cannot add self-dependency: %q", id)
@@ -198,6 +216,19 @@ func AddDep(g *WorkGraph, id string, depID string, now time.Time) error {
 	return nil
 }
 
+// checkDepExists reports an error unless depID is an item of g or an
+// external dep whose plan and item exist.
+func checkDepExists(g WorkGraph, depID string) error {
+	if IsExternalDep(depID) {
+		ref, _ := lookupExternalRef(g, depID)
+		return externalDepError(depID, ref.Err)
+	}
+	if _, ok := g.Items[depID]; !ok {
+		return fmt.Errorf("unknown dep id %q", depID)
+	}
+	return nil
+}
+
 func RemoveDep(g *WorkGraph, id string, depID string, now time.Time) error {
 	it, ok := g.Items[id]
 	if !ok {
@@ -266,8 +297,14 @@ func SetDeps(g *WorkGraph, id string, deps []string, now time.Time) error {
 			continue
 		}
 		seen[depID] = true
-		if _, ok := g.Items[depID]; !ok {
-			return fmt.Errorf("unknown dep id %q", depID)
+		// Keeping an external dep whose item has since gone is not an error;
+		// only deps being added must resolve.
+		if IsExternalDep(depID) && containsID(it.Deps, depID) {
+			out = append(out, depID)
+			continue
+		}
+		if err := checkDepExists(*g, depID); err != nil {
+			return err
 		}
 		out = append(out, depID)
 	}
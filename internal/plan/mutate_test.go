@@ -243,3 +243,13 @@ func wi(id string, now time.Time) WorkItem {
 		UpdatedAt:          now,
 	}
 }
+
+func graphOf(items ...WorkItem) WorkGraph {
+	g := WorkGraph{SchemaVersion: SchemaVersion, Items: map[string]WorkItem{}}
+	for _, it := range items {
+		g.Items[it.ID] = it
+	}
+	return g
+}
+
+func intPtr(n int) *int { return &n }
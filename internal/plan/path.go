@@ -1,16 +1,168 @@
 package plan
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
-// PlanPath returns the plan file path for the current working directory.
+// PlanEnvVar selects the plan commands operate on: a plan name or a path.
+// The --plan flag sets it for the process.
+const PlanEnvVar = "BLACKBIRD_PLAN"
+
+// PlansDirName holds named plans, one directory per plan. Each directory is a
+// plan base directory like the project root: its runs, history, pending
+// feedback and agent selection live under its own .blackbird/.
+const PlansDirName = ".blackbird/plans"
+
+// PlanPath returns the plan file path for the current working directory,
+// honoring BLACKBIRD_PLAN.
 func PlanPath() string {
+	ref := os.Getenv(PlanEnvVar)
 	wd, err := os.Getwd()
 	if err != nil {
 		// If this fails, other file ops will fail too; keep path deterministic.
+		if ref != "" {
+			return ResolvePlanPath(".", ref)
+		}
 		return DefaultPlanFilename
 	}
-	return filepath.Join(wd, DefaultPlanFilename)
+	return ResolvePlanPath(wd, ref)
+}
+
+// PlanPathIn returns the plan file path for dir. An existing JSON plan takes
+// precedence, then an existing YAML plan (.yaml, then .yml); otherwise the
+// JSON default is used.
+func PlanPathIn(dir string) string {
+	for _, name := range []string{DefaultPlanFilename, YAMLPlanFilename, YMLPlanFilename} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(dir, DefaultPlanFilename)
+}
+
+// ResolvePlanPath returns the plan file for ref relative to the project root
+// dir. An empty ref is the default plan. A ref that looks like a path (it
+// has a separator or a plan file extension) is a plan file, or a directory
+// holding one; anything else names a plan under PlansDirName.
+func ResolvePlanPath(dir, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return PlanPathIn(dir)
+	}
+	if !isPlanPathRef(ref) {
+		return PlanPathIn(NamedPlanDir(dir, ref))
+	}
+	path := ref
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return PlanPathIn(path)
+	}
+	return path
+}
+
+func isPlanPathRef(ref string) bool {
+	if ref == "." || ref == ".." || strings.ContainsAny(ref, `/\`) {
+		return true
+	}
+	return hasPlanExt(ref)
+}
+
+func hasPlanExt(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// CheckPlanRef rejects a path ref whose plan file shares its directory with
+// another plan. Runs, history and agent selection live under the plan's
+// directory, so two plans there would overwrite each other's state. Names and
+// a directory's default plan file are always accepted.
+func CheckPlanRef(dir, ref string) error {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || !isPlanPathRef(ref) {
+		return nil
+	}
+	path := ResolvePlanPath(dir, ref)
+	planDir := filepath.Dir(path)
+	if PlanPathIn(planDir) == path {
+		// The directory's own plan, as if no ref were given.
+		return nil
+	}
+	entries, err := os.ReadDir(planDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || e.Name() == filepath.Base(path) || !hasPlanExt(e.Name()) {
+			continue
+		}
+		// Other JSON and YAML files (package.json, CI config) are not plans.
+		if _, err := Load(filepath.Join(planDir, e.Name())); err != nil {
+			continue
+		}
+		return fmt.Errorf("plan %s shares its directory with plan %s and would share its runs and history; move one into its own directory or use a named plan (--plan <name>)", path, e.Name())
+	}
+	return nil
+}
+
+// NamedPlanDir returns the directory of the named plan under root.
+func NamedPlanDir(root, name string) string {
+	return filepath.Join(root, filepath.FromSlash(PlansDirName), name)
+}
+
+// ProjectRoot returns the project directory of the plan at planPath, where
+// repository-wide files (config, templates) live: the root a named plan was
+// resolved from, or the plan's own directory otherwise.
+func ProjectRoot(planPath string) string {
+	dir := filepath.Dir(planPath)
+	root := filepath.Dir(filepath.Dir(filepath.Dir(dir)))
+	if NamedPlanDir(root, filepath.Base(dir)) == dir {
+		return root
+	}
+	return dir
+}
+
+// NamedPlan is a plan found by ListPlans. Name is empty for the default plan.
+type NamedPlan struct {
+	Name string
+	Path string
+}
+
+// ListPlans returns the plans of the project at root that exist on disk: the
+// default plan first, then named plans sorted by name.
+func ListPlans(root string) ([]NamedPlan, error) {
+	var out []NamedPlan
+	if path := PlanPathIn(root); Exists(path) {
+		out = append(out, NamedPlan{Path: path})
+	}
+	entries, err := os.ReadDir(filepath.Join(root, filepath.FromSlash(PlansDirName)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return out, nil
+		}
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		if path := PlanPathIn(NamedPlanDir(root, e.Name())); Exists(path) {
+			out = append(out, NamedPlan{Name: e.Name(), Path: path})
+		}
+	}
+	return out, nil
 }
@@ -3,6 +3,7 @@ package plan
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -37,3 +38,108 @@ func TestPlanPathUsesWorkingDirectory(t *testing.T) {
 		t.Fatalf("PlanPath() = %q, want %q", got, want)
 	}
 }
+
+func TestResolvePlanPathNamesAndPaths(t *testing.T) {
+	root := t.TempDir()
+	named := NamedPlanDir(root, "migration")
+	if err := os.MkdirAll(named, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(named, YAMLPlanFilename), []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write plan: %v", err)
+	}
+	sub := filepath.Join(root, "services", "billing")
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	cases := []struct {
+		ref  string
+		want string
+	}{
+		{"", filepath.Join(root, DefaultPlanFilename)},
+		{"migration", filepath.Join(named, YAMLPlanFilename)},
+		{"feature", filepath.Join(root, ".blackbird", "plans", "feature", DefaultPlanFilename)},
+		{"services/billing", filepath.Join(sub, DefaultPlanFilename)},
+		{"other.plan.yaml", filepath.Join(root, "other.plan.yaml")},
+		{filepath.Join(sub, "custom.json"), filepath.Join(sub, "custom.json")},
+	}
+	for _, tc := range cases {
+		if got := ResolvePlanPath(root, tc.ref); got != tc.want {
+			t.Errorf("ResolvePlanPath(%q) = %q, want %q", tc.ref, got, tc.want)
+		}
+	}
+
+	if got := ProjectRoot(filepath.Join(named, YAMLPlanFilename)); got != root {
+		t.Fatalf("ProjectRoot(named) = %q, want %q", got, root)
+	}
+	if got := ProjectRoot(filepath.Join(sub, DefaultPlanFilename)); got != sub {
+		t.Fatalf("ProjectRoot(sub) = %q, want %q", got, sub)
+	}
+
+	t.Chdir(root)
+	t.Setenv(PlanEnvVar, "migration")
+	if got := PlanPath(); filepath.Base(filepath.Dir(got)) != "migration" {
+		t.Fatalf("PlanPath() with %s = %q", PlanEnvVar, got)
+	}
+}
+
+func TestCheckPlanRefRejectsPlansSharingADirectory(t *testing.T) {
+	root := t.TempDir()
+	for name, body := range map[string]string{
+		"a.json":       "{}",
+		"package.json": `{"name": "web"}`,
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(body), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	for _, ref := range []string{"", "migration", "a.json"} {
+		if err := CheckPlanRef(root, ref); err != nil {
+			t.Errorf("CheckPlanRef(%q) = %v, want nil", ref, err)
+		}
+	}
+	if err := CheckPlanRef(root, "b.json"); err == nil || !strings.Contains(err.Error(), "a.json") {
+		t.Fatalf("CheckPlanRef(b.json) = %v, want conflict with a.json", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, DefaultPlanFilename), []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write default plan: %v", err)
+	}
+	if err := CheckPlanRef(root, "a.json"); err == nil {
+		t.Fatalf("CheckPlanRef(a.json) next to the default plan = nil, want conflict")
+	}
+	for _, ref := range []string{".", DefaultPlanFilename, filepath.Join(root, DefaultPlanFilename)} {
+		if err := CheckPlanRef(root, ref); err != nil {
+			t.Errorf("CheckPlanRef(%q) = %v, want nil", ref, err)
+		}
+	}
+}
+
+func TestListPlansFindsDefaultAndNamedPlans(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{root, NamedPlanDir(root, "b"), NamedPlanDir(root, "a")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, DefaultPlanFilename), []byte("{}"), 0o644); err != nil {
+			t.Fatalf("write plan: %v", err)
+		}
+	}
+	if err := os.MkdirAll(NamedPlanDir(root, "empty"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	plans, err := ListPlans(root)
+	if err != nil {
+		t.Fatalf("ListPlans: %v", err)
+	}
+	var names []string
+	for _, p := range plans {
+		names = append(names, p.Name)
+	}
+	if len(names) != 3 || names[0] != "" || names[1] != "a" || names[2] != "b" {
+		t.Fatalf("plans = %q", names)
+	}
+}
@@ -31,3 +31,28 @@ func ReadinessLabel(status Status, depsOK bool, manualBlocked bool) string {
 	}
 	return ""
 }
+
+// Readiness summarizes whether an item can be worked on now.
+type Readiness struct {
+	Label           string   `json:"label"`
+	DepsSatisfied   bool     `json:"depsSatisfied"`
+	UnmetDeps       []string `json:"unmetDeps"`
+	ManuallyBlocked bool     `json:"manuallyBlocked"`
+	Actionable      bool     `json:"actionable"`
+}
+
+// ItemReadiness derives the readiness of it within g.
+func ItemReadiness(g WorkGraph, it WorkItem) Readiness {
+	unmet := UnmetDeps(g, it)
+	if unmet == nil {
+		unmet = []string{}
+	}
+	depsOK := len(unmet) == 0
+	return Readiness{
+		Label:           ReadinessLabel(it.Status, depsOK, it.Status == StatusBlocked),
+		DepsSatisfied:   depsOK,
+		UnmetDeps:       unmet,
+		ManuallyBlocked: it.Status == StatusBlocked,
+		Actionable:      it.Status == StatusTodo && depsOK,
+	}
+}
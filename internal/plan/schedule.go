@@ -0,0 +1,384 @@
+package plan
+
+import (
+	"sort"
+	"time"
+)
+
+// DurationSource says where a scheduled task's duration came from.
+type DurationSource string
+
+const (
+	DurationFromEstimate DurationSource = "estimate"
+	DurationFromHistory  DurationSource = "history"
+	// DurationFromAverage is the mean of the historical durations of other
+	// tasks, used when a task has neither an estimate nor runs of its own.
+	DurationFromAverage DurationSource = "average"
+	DurationUnknown     DurationSource = "unknown"
+)
+
+// ScheduledTask is one remaining leaf task in a Schedule.
+type ScheduledTask struct {
+	ID       string
+	Wave     int
+	Duration time.Duration
+	Source   DurationSource
+	// EarliestStart assumes unlimited parallelism.
+	EarliestStart time.Duration
+	// Slack is how long the task can slip without delaying the finish.
+	Slack    time.Duration
+	Critical bool
+	Deps     []string
+}
+
+// ScheduleWave is one level of the schedule: tasks whose prerequisites all
+// finish in earlier waves, so they can run in parallel.
+type ScheduleWave struct {
+	TaskIDs []string
+	// Duration is the longest task in the wave.
+	Duration time.Duration
+}
+
+// UnschedulableTask is a remaining task that can never become ready.
+type UnschedulableTask struct {
+	ID string
+	// BlockedBy lists the deps that will not complete: skipped or unknown
+	// items (in this plan or another), items in a dependency cycle, or other
+	// unschedulable tasks.
+	BlockedBy []string
+}
+
+// ExternalWaitTask is a remaining task that cannot start until an unfinished
+// item of another plan is done, so when it runs depends on that plan.
+type ExternalWaitTask struct {
+	ID string
+	// WaitingOn lists the unfinished external deps ("plan:<name>#<id>") and
+	// the remaining tasks that wait on one.
+	WaitingOn []string
+}
+
+// Schedule is the critical path and wave analysis of a plan's remaining work.
+type Schedule struct {
+	// Tasks are ordered by wave, then ID.
+	Tasks []ScheduledTask
+	Waves []ScheduleWave
+	// CriticalPath is the longest chain of dependent tasks by duration.
+	CriticalPath []string
+	// Duration is the length of the critical path: the finish time with
+	// unlimited parallelism.
+	Duration time.Duration
+	// WaveDuration is the finish time when waves run one after another.
+	WaveDuration      time.Duration
+	MaxParallelism    int
+	WaitingOnExternal []ExternalWaitTask
+	Unschedulable     []UnschedulableTask
+}
+
+// AnalyzeSchedule computes the critical path and level-by-level schedule of
+// the remaining (not done or skipped) leaf tasks. Durations come from each
+// task's EstimateMinutes, else from history (typically the mean run duration
+// per task), else from the mean of history across tasks.
+//
+// Deps follow execution: a task waits for its own unmet deps, and a dep on a
+// parent item waits for that parent's remaining leaf tasks. Tasks behind an
+// unfinished item of another plan are left out of the schedule and listed in
+// WaitingOnExternal.
+func AnalyzeSchedule(g WorkGraph, history map[string]time.Duration) Schedule {
+	remaining := map[string]bool{}
+	var ids []string
+	for id, it := range g.Items {
+		if len(it.ChildIDs) != 0 || it.Status == StatusDone || it.Status == StatusSkipped {
+			continue
+		}
+		remaining[id] = true
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	preds := make(map[string][]string, len(ids))
+	blockedBy := map[string][]string{}
+	waitingOn := map[string][]string{}
+	for _, id := range ids {
+		seen := map[string]bool{}
+		for _, dep := range UnmetDeps(g, g.Items[id]) {
+			depItem, ok := g.Items[dep]
+			switch {
+			case externalPending(g, dep):
+				waitingOn[id] = append(waitingOn[id], dep)
+			case remaining[dep]:
+				addUnique(preds, id, dep, seen)
+			case ok && len(depItem.ChildIDs) != 0:
+				for _, leaf := range remainingLeaves(g, dep, remaining) {
+					if leaf != id {
+						addUnique(preds, id, leaf, seen)
+					}
+				}
+			default:
+				blockedBy[id] = append(blockedBy[id], dep)
+			}
+		}
+		sort.Strings(preds[id])
+	}
+
+	order, cyclic := topoOrder(ids, preds)
+	for _, id := range cyclic {
+		blockedBy[id] = append(blockedBy[id], cyclicPreds(id, preds, cyclic)...)
+	}
+	// Anything waiting on an unschedulable task is unschedulable too, and
+	// anything else waiting on a task that waits for another plan waits too.
+	for _, id := range order {
+		for _, dep := range preds[id] {
+			if len(blockedBy[dep]) != 0 {
+				blockedBy[id] = append(blockedBy[id], dep)
+			}
+		}
+	}
+	for _, id := range order {
+		for _, dep := range preds[id] {
+			if len(waitingOn[dep]) != 0 && len(blockedBy[dep]) == 0 {
+				waitingOn[id] = append(waitingOn[id], dep)
+			}
+		}
+	}
+
+	var s Schedule
+	for _, id := range ids {
+		switch {
+		case len(blockedBy[id]) != 0:
+			s.Unschedulable = append(s.Unschedulable, UnschedulableTask{ID: id, BlockedBy: blockedBy[id]})
+		case len(waitingOn[id]) != 0:
+			s.WaitingOnExternal = append(s.WaitingOnExternal, ExternalWaitTask{ID: id, WaitingOn: waitingOn[id]})
+		}
+	}
+
+	fallback, hasFallback := averageDuration(history)
+	tasks := map[string]*ScheduledTask{}
+	var scheduled []string
+	for _, id := range order {
+		if len(blockedBy[id]) != 0 || len(waitingOn[id]) != 0 {
+			continue
+		}
+		t := &ScheduledTask{ID: id, Deps: preds[id], Source: DurationUnknown}
+		it := g.Items[id]
+		switch d, ok := history[id]; {
+		case it.EstimateMinutes != nil:
+			t.Duration, t.Source = time.Duration(*it.EstimateMinutes)*time.Minute, DurationFromEstimate
+		case ok:
+			t.Duration, t.Source = d, DurationFromHistory
+		case hasFallback:
+			t.Duration, t.Source = fallback, DurationFromAverage
+		}
+		for _, dep := range preds[id] {
+			p := tasks[dep]
+			t.Wave = max(t.Wave, p.Wave+1)
+			t.EarliestStart = max(t.EarliestStart, p.EarliestStart+p.Duration)
+		}
+		s.Duration = max(s.Duration, t.EarliestStart+t.Duration)
+		tasks[id] = t
+		scheduled = append(scheduled, id)
+	}
+
+	// Backward pass over dependents for slack.
+	latestFinish := map[string]time.Duration{}
+	for i := len(scheduled) - 1; i >= 0; i-- {
+		id := scheduled[i]
+		t := tasks[id]
+		finish := s.Duration
+		for _, succ := range scheduleDependents(g, id, tasks) {
+			finish = min(finish, latestFinish[succ]-tasks[succ].Duration)
+		}
+		latestFinish[id] = finish
+		t.Slack = finish - t.Duration - t.EarliestStart
+		t.Critical = t.Slack == 0
+	}
+
+	for _, id := range scheduled {
+		t := tasks[id]
+		for len(s.Waves) <= t.Wave {
+			s.Waves = append(s.Waves, ScheduleWave{})
+		}
+		w := &s.Waves[t.Wave]
+		w.TaskIDs = append(w.TaskIDs, id)
+		w.Duration = max(w.Duration, t.Duration)
+	}
+	for i := range s.Waves {
+		sort.Strings(s.Waves[i].TaskIDs)
+		s.WaveDuration += s.Waves[i].Duration
+		s.MaxParallelism = max(s.MaxParallelism, len(s.Waves[i].TaskIDs))
+		for _, id := range s.Waves[i].TaskIDs {
+			s.Tasks = append(s.Tasks, *tasks[id])
+		}
+	}
+	s.CriticalPath = criticalPath(tasks, scheduled, s.Duration)
+	return s
+}
+
+// scheduleDependents returns the scheduled tasks waiting on id, directly or
+// through a dep on one of id's ancestors.
+func scheduleDependents(g WorkGraph, id string, tasks map[string]*ScheduledTask) []string {
+	var out []string
+	seen := map[string]bool{}
+	for cur := id; ; {
+		for _, dependent := range Dependents(g, cur) {
+			t, ok := tasks[dependent]
+			if !ok || seen[dependent] || !containsString(t.Deps, id) {
+				continue
+			}
+			seen[dependent] = true
+			out = append(out, dependent)
+		}
+		parent := g.Items[cur].ParentID
+		if parent == nil {
+			break
+		}
+		if _, ok := g.Items[*parent]; !ok {
+			break
+		}
+		cur = *parent
+	}
+	return out
+}
+
+// criticalPath walks back from the last task to finish through the
+// prerequisites that determine each start time. Ties go to the smaller ID.
+func criticalPath(tasks map[string]*ScheduledTask, order []string, total time.Duration) []string {
+	var end *ScheduledTask
+	for _, id := range order {
+		t := tasks[id]
+		if t.EarliestStart+t.Duration != total {
+			continue
+		}
+		if end == nil || t.ID < end.ID {
+			end = t
+		}
+	}
+	if end == nil {
+		return nil
+	}
+	path := []string{end.ID}
+	for cur := end; len(cur.Deps) != 0; {
+		var next *ScheduledTask
+		for _, dep := range cur.Deps {
+			p := tasks[dep]
+			if p.EarliestStart+p.Duration == cur.EarliestStart {
+				next = p
+				break
+			}
+		}
+		if next == nil {
+			break
+		}
+		path = append(path, next.ID)
+		cur = next
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// externalPending reports whether dep is an item of another plan that exists
+// but is not done yet. Unresolved external deps and skipped items never
+// complete, so tasks behind them are unschedulable instead.
+func externalPending(g WorkGraph, dep string) bool {
+	if !IsExternalDep(dep) {
+		return false
+	}
+	_, it, err := ResolveExternalDep(g, dep)
+	return err == nil && it.Status != StatusDone && it.Status != StatusSkipped
+}
+
+func remainingLeaves(g WorkGraph, id string, remaining map[string]bool) []string {
+	var out []string
+	seen := map[string]bool{}
+	var walk func(string)
+	walk = func(cur string) {
+		if seen[cur] {
+			return
+		}
+		seen[cur] = true
+		if remaining[cur] {
+			out = append(out, cur)
+		}
+		for _, child := range g.Items[cur].ChildIDs {
+			walk(child)
+		}
+	}
+	walk(id)
+	return out
+}
+
+// topoOrder returns ids in dependency order (Kahn's algorithm, smallest ID
+// first) and the ids left over because they sit on or behind a cycle.
+func topoOrder(ids []string, preds map[string][]string) (order, cyclic []string) {
+	indegree := map[string]int{}
+	succs := map[string][]string{}
+	for _, id := range ids {
+		indegree[id] = len(preds[id])
+		for _, dep := range preds[id] {
+			succs[dep] = append(succs[dep], id)
+		}
+	}
+	var queue []string
+	for _, id := range ids {
+		if indegree[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		sort.Strings(queue)
+		id := queue[0]
+		queue = queue[1:]
+		order = append(order, id)
+		for _, succ := range succs[id] {
+			indegree[succ]--
+			if indegree[succ] == 0 {
+				queue = append(queue, succ)
+			}
+		}
+	}
+	for _, id := range ids {
+		if indegree[id] > 0 {
+			cyclic = append(cyclic, id)
+		}
+	}
+	return order, cyclic
+}
+
+func cyclicPreds(id string, preds map[string][]string, cyclic []string) []string {
+	var out []string
+	for _, dep := range preds[id] {
+		if containsString(cyclic, dep) {
+			out = append(out, dep)
+		}
+	}
+	return out
+}
+
+func averageDuration(history map[string]time.Duration) (time.Duration, bool) {
+	if len(history) == 0 {
+		return 0, false
+	}
+	var total time.Duration
+	for _, d := range history {
+		total += d
+	}
+	return total / time.Duration(len(history)), true
+}
+
+func addUnique(preds map[string][]string, id, dep string, seen map[string]bool) {
+	if seen[dep] {
+		return
+	}
+	seen[dep] = true
+	preds[id] = append(preds[id], dep)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
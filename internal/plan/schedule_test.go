@@ -0,0 +1,163 @@
+package plan
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnalyzeScheduleCriticalPathAndWaves(t *testing.T) {
+	// done -> A(30) -> C(10) -> E(5)
+	//         B(60) -> D(20) -/
+	var t0 time.Time
+	done := wi("done", t0)
+	done.Status = StatusDone
+	g := graphOf(done)
+	for _, task := range []struct {
+		id       string
+		estimate int
+		deps     []string
+	}{
+		{"A", 30, []string{"done"}},
+		{"B", 60, nil},
+		{"C", 10, []string{"A"}},
+		{"D", 20, []string{"B"}},
+		{"E", 5, []string{"C", "D"}},
+	} {
+		it := wi(task.id, t0)
+		it.EstimateMinutes = intPtr(task.estimate)
+		it.Deps = append(it.Deps, task.deps...)
+		g.Items[task.id] = it
+	}
+
+	s := AnalyzeSchedule(g, nil)
+	if !reflect.DeepEqual(s.CriticalPath, []string{"B", "D", "E"}) {
+		t.Fatalf("critical path = %v", s.CriticalPath)
+	}
+	if s.Duration != 85*time.Minute {
+		t.Fatalf("duration = %v, want 85m", s.Duration)
+	}
+	if len(s.Waves) != 3 || !reflect.DeepEqual(s.Waves[0].TaskIDs, []string{"A", "B"}) || !reflect.DeepEqual(s.Waves[1].TaskIDs, []string{"C", "D"}) {
+		t.Fatalf("waves = %+v", s.Waves)
+	}
+	if s.WaveDuration != 60*time.Minute+20*time.Minute+5*time.Minute || s.MaxParallelism != 2 {
+		t.Fatalf("waveDuration = %v maxParallelism = %d", s.WaveDuration, s.MaxParallelism)
+	}
+
+	slack := map[string]time.Duration{}
+	for _, task := range s.Tasks {
+		slack[task.ID] = task.Slack
+		if task.Critical != (task.Slack == 0) {
+			t.Fatalf("task %s critical = %v with slack %v", task.ID, task.Critical, task.Slack)
+		}
+	}
+	if slack["A"] != 40*time.Minute || slack["C"] != 40*time.Minute || slack["B"] != 0 || slack["E"] != 0 {
+		t.Fatalf("slack = %v", slack)
+	}
+}
+
+func TestAnalyzeScheduleDurationSources(t *testing.T) {
+	var t0 time.Time
+	estimated := wi("estimated", t0)
+	estimated.EstimateMinutes = intPtr(45)
+	g := graphOf(estimated, wi("ran", t0), wi("new", t0))
+	history := map[string]time.Duration{"ran": 20 * time.Minute, "other": 10 * time.Minute}
+
+	sources := map[string]DurationSource{}
+	durations := map[string]time.Duration{}
+	for _, task := range AnalyzeSchedule(g, history).Tasks {
+		sources[task.ID] = task.Source
+		durations[task.ID] = task.Duration
+	}
+	want := map[string]DurationSource{"estimated": DurationFromEstimate, "ran": DurationFromHistory, "new": DurationFromAverage}
+	if !reflect.DeepEqual(sources, want) {
+		t.Fatalf("sources = %v, want %v", sources, want)
+	}
+	if durations["estimated"] != 45*time.Minute || durations["ran"] != 20*time.Minute || durations["new"] != 15*time.Minute {
+		t.Fatalf("durations = %v", durations)
+	}
+
+	for _, task := range AnalyzeSchedule(g, nil).Tasks {
+		if task.ID == "new" && task.Source != DurationUnknown {
+			t.Fatalf("source = %q, want unknown without history", task.Source)
+		}
+	}
+}
+
+func TestAnalyzeScheduleParentDepsAndUnschedulable(t *testing.T) {
+	var t0 time.Time
+	parent := wi("feature", t0)
+	parent.ChildIDs = []string{"f1", "f2"}
+	f1 := wi("f1", t0)
+	f1.ParentID = &parent.ID
+	f1.EstimateMinutes = intPtr(10)
+	f2 := wi("f2", t0)
+	f2.ParentID = &parent.ID
+	f2.EstimateMinutes = intPtr(10)
+	f2.Status = StatusDone
+	skipped := wi("skipped", t0)
+	skipped.Status = StatusSkipped
+
+	g := graphOf(parent, f1, f2, skipped)
+	for id, dep := range map[string]string{"after": "feature", "stuck": "skipped", "behind": "stuck"} {
+		it := wi(id, t0)
+		it.EstimateMinutes = intPtr(5)
+		it.Deps = []string{dep}
+		g.Items[id] = it
+	}
+
+	s := AnalyzeSchedule(g, nil)
+	if !reflect.DeepEqual(s.CriticalPath, []string{"f1", "after"}) {
+		t.Fatalf("critical path = %v", s.CriticalPath)
+	}
+	want := []UnschedulableTask{
+		{ID: "behind", BlockedBy: []string{"stuck"}},
+		{ID: "stuck", BlockedBy: []string{"skipped"}},
+	}
+	if !reflect.DeepEqual(s.Unschedulable, want) {
+		t.Fatalf("unschedulable = %+v", s.Unschedulable)
+	}
+	for _, task := range s.Tasks {
+		if task.ID == "after" && !reflect.DeepEqual(task.Deps, []string{"f1"}) {
+			t.Fatalf("after deps = %v, want the parent's remaining leaf", task.Deps)
+		}
+	}
+}
+
+func TestAnalyzeScheduleExternalWaits(t *testing.T) {
+	var t0 time.Time
+	g := graphOf(wi("free", t0))
+	for id, dep := range map[string]string{
+		"client":  "plan:backend#api",
+		"ui":      "client",
+		"shipped": "plan:backend#done",
+		"orphan":  "plan:backend#gone",
+	} {
+		it := wi(id, t0)
+		it.Deps = []string{dep}
+		g.Items[id] = it
+	}
+	done := wi("done", t0)
+	done.Status = StatusDone
+	g.External = map[string]ExternalRef{
+		"plan:backend#api":  {Item: wi("api", t0)},
+		"plan:backend#done": {Item: done},
+		"plan:backend#gone": {Err: ErrExternalItemNotFound},
+	}
+
+	s := AnalyzeSchedule(g, nil)
+	wantWaiting := []ExternalWaitTask{
+		{ID: "client", WaitingOn: []string{"plan:backend#api"}},
+		{ID: "ui", WaitingOn: []string{"client"}},
+	}
+	if !reflect.DeepEqual(s.WaitingOnExternal, wantWaiting) {
+		t.Fatalf("waiting on external = %+v", s.WaitingOnExternal)
+	}
+	wantBlocked := []UnschedulableTask{{ID: "orphan", BlockedBy: []string{"plan:backend#gone"}}}
+	if !reflect.DeepEqual(s.Unschedulable, wantBlocked) {
+		t.Fatalf("unschedulable = %+v", s.Unschedulable)
+	}
+	if len(s.Waves) != 1 || !reflect.DeepEqual(s.Waves[0].TaskIDs, []string{"free", "shipped"}) {
+		t.Fatalf("waves = %+v", s.Waves)
+	}
+}
@@ -0,0 +1,288 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Split layout: instead of a single plan file, the plan is stored as one
+// file per top-level feature under .blackbird/plan/, plus a small index:
+//
+//	.blackbird/plan/index.json          {"schemaVersion":1,"features":[{"id":..,"file":..}]}
+//	.blackbird/plan/<featureId>.json    WorkGraph holding that feature's subtree
+//
+// The layout is active when the single plan file does not exist and the index
+// does. Load and SaveAtomic handle it transparently for the default plan path,
+// and saves only rewrite feature files whose content changed.
+const (
+	SplitPlanDirName   = ".blackbird/plan"
+	SplitIndexFilename = "index.json"
+)
+
+// SplitIndex lists the feature files that make up a split plan.
+type SplitIndex struct {
+	SchemaVersion int            `json:"schemaVersion"`
+	Features      []SplitFeature `json:"features"`
+}
+
+type SplitFeature struct {
+	ID   string `json:"id"`
+	File string `json:"file"`
+}
+
+// SplitDir returns the split layout directory for a plan path.
+func SplitDir(planPath string) string {
+	return filepath.Join(filepath.Dir(planPath), filepath.FromSlash(SplitPlanDirName))
+}
+
+// IsSplit reports whether planPath resolves to the split layout: the plan file
+// itself is absent and a split index exists next to it.
+func IsSplit(planPath string) bool {
+	switch filepath.Base(planPath) {
+	case DefaultPlanFilename, YAMLPlanFilename, YMLPlanFilename:
+	default:
+		return false
+	}
+	if _, err := os.Stat(planPath); err == nil {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(SplitDir(planPath), SplitIndexFilename))
+	return err == nil
+}
+
+// Exists reports whether a plan exists at planPath in either layout.
+func Exists(planPath string) bool {
+	if _, err := os.Stat(planPath); err == nil {
+		return true
+	}
+	return IsSplit(planPath)
+}
+
+func loadSplit(planPath string) (WorkGraph, error) {
+	return LoadSplitFrom(SplitDir(planPath), func(file string) ([]byte, error) {
+		return os.ReadFile(filepath.Join(SplitDir(planPath), file))
+	})
+}
+
+// LoadSplitFrom assembles a split-layout plan from files returned by read,
+// which is given names relative to the split directory (the index, then each
+// feature file). dir is used in error messages.
+func LoadSplitFrom(dir string, read func(file string) ([]byte, error)) (WorkGraph, error) {
+	indexPath := filepath.Join(dir, SplitIndexFilename)
+	b, err := read(SplitIndexFilename)
+	if err != nil {
+		return WorkGraph{}, fmt.Errorf("read plan index %s: %w", indexPath, err)
+	}
+	index, err := DecodeSplitIndex(indexPath, b)
+	if err != nil {
+		return WorkGraph{}, err
+	}
+	if index.SchemaVersion != SchemaVersion {
+		return WorkGraph{}, fmt.Errorf("parse plan index %s: unsupported schemaVersion %d (expected %d)", indexPath, index.SchemaVersion, SchemaVersion)
+	}
+
+	g := WorkGraph{SchemaVersion: SchemaVersion, Items: map[string]WorkItem{}}
+	owner := map[string]string{}
+	for _, f := range index.Features {
+		path := filepath.Join(dir, f.File)
+		b, err := read(f.File)
+		if err != nil {
+			return WorkGraph{}, fmt.Errorf("read plan file %s: %w", path, err)
+		}
+		part, err := decodePlan(path, b)
+		if err != nil {
+			return WorkGraph{}, err
+		}
+		if part.SchemaVersion != SchemaVersion {
+			return WorkGraph{}, fmt.Errorf("parse plan file %s: unsupported schemaVersion %d (expected %d)", path, part.SchemaVersion, SchemaVersion)
+		}
+		for id, it := range part.Items {
+			if prev, ok := owner[id]; ok {
+				return WorkGraph{}, fmt.Errorf("parse plan file %s: item %q also defined in %s", path, id, prev)
+			}
+			owner[id] = f.File
+			g.Items[id] = it
+		}
+	}
+	return g, nil
+}
+
+func readSplitIndex(path string) (SplitIndex, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return SplitIndex{}, fmt.Errorf("read plan index %s: %w", path, err)
+	}
+	return DecodeSplitIndex(path, b)
+}
+
+// SaveSplit writes g in the split layout next to planPath, creating the layout
+// if needed. Feature files (and the index) are only rewritten when their
+// content changes, so a status update touches just the affected feature.
+func SaveSplit(planPath string, g WorkGraph) error {
+	dir := SplitDir(planPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create plan directory: %w", err)
+	}
+
+	// An unreadable index is simply rewritten; stale feature files are then left alone.
+	var oldFiles []string
+	if old, err := readSplitIndex(filepath.Join(dir, SplitIndexFilename)); err == nil {
+		for _, f := range old.Features {
+			oldFiles = append(oldFiles, f.File)
+		}
+	}
+
+	parts := partitionByFeature(g)
+	featureIDs := make([]string, 0, len(parts))
+	for id := range parts {
+		featureIDs = append(featureIDs, id)
+	}
+	sort.Strings(featureIDs)
+
+	index := SplitIndex{SchemaVersion: SchemaVersion, Features: []SplitFeature{}}
+	usedFiles := map[string]bool{}
+	usedFolded := map[string]bool{}
+	for _, id := range featureIDs {
+		file := splitFeatureFilename(id, usedFolded)
+		usedFiles[file] = true
+		usedFolded[strings.ToLower(file)] = true
+		index.Features = append(index.Features, SplitFeature{ID: id, File: file})
+
+		schema := g.SchemaVersion
+		if schema == 0 {
+			schema = SchemaVersion
+		}
+		b, err := Marshal(WorkGraph{SchemaVersion: schema, Items: parts[id]}, FormatJSON)
+		if err != nil {
+			return err
+		}
+		if err := writeIfChanged(filepath.Join(dir, file), b); err != nil {
+			return err
+		}
+	}
+
+	b, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal plan index: %w", err)
+	}
+	if err := writeIfChanged(filepath.Join(dir, SplitIndexFilename), append(b, '\n')); err != nil {
+		return err
+	}
+
+	for _, file := range oldFiles {
+		if usedFiles[file] || file == SplitIndexFilename || strings.ContainsAny(file, `/\`) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, file)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove stale feature file: %w", err)
+		}
+	}
+	return nil
+}
+
+// RemoveSplit deletes the split layout directory next to planPath.
+func RemoveSplit(planPath string) error {
+	return os.RemoveAll(SplitDir(planPath))
+}
+
+func writeIfChanged(path string, data []byte) error {
+	if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	return WriteFileAtomic(path, data, 0o644)
+}
+
+// partitionByFeature groups items under their top-level ancestor.
+func partitionByFeature(g WorkGraph) map[string]map[string]WorkItem {
+	parts := map[string]map[string]WorkItem{}
+	for id, it := range g.Items {
+		root := featureRoot(g, id)
+		if parts[root] == nil {
+			parts[root] = map[string]WorkItem{}
+		}
+		parts[root][id] = it
+	}
+	return parts
+}
+
+func featureRoot(g WorkGraph, id string) string {
+	seen := map[string]bool{}
+	cur := id
+	for !seen[cur] {
+		seen[cur] = true
+		it, ok := g.Items[cur]
+		if !ok || it.ParentID == nil || *it.ParentID == "" {
+			return cur
+		}
+		if _, ok := g.Items[*it.ParentID]; !ok {
+			return cur
+		}
+		cur = *it.ParentID
+	}
+	return cur
+}
+
+// splitFeatureFilename maps a feature id to a filesystem-safe file name that is
+// unique (case-insensitively, for macOS/Windows) among the lowercased names in used.
+func splitFeatureFilename(id string, used map[string]bool) string {
+	var b strings.Builder
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	base := b.String()
+	if base == "" || strings.EqualFold(base+".json", SplitIndexFilename) {
+		base = "_" + base
+	}
+	name := base + ".json"
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		name = fmt.Sprintf("%s~%d.json", base, n)
+	}
+	return name
+}
+
+// DecodeSplitIndex parses split index data; name is used in error messages.
+func DecodeSplitIndex(name string, b []byte) (SplitIndex, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var index SplitIndex
+	if err := dec.Decode(&index); err != nil {
+		return SplitIndex{}, fmt.Errorf("parse plan index %s: %w", name, err)
+	}
+	return index, nil
+}
+
+// MergeSplitIndex three-way merges feature lists by id, so features added on
+// either branch are kept and features removed on either branch are dropped.
+func MergeSplitIndex(base, ours, theirs SplitIndex) SplitIndex {
+	ids := func(idx SplitIndex) []string {
+		out := make([]string, 0, len(idx.Features))
+		for _, f := range idx.Features {
+			out = append(out, f.ID)
+		}
+		return out
+	}
+	files := map[string]string{}
+	for _, idx := range []SplitIndex{theirs, ours} {
+		for _, f := range idx.Features {
+			files[f.ID] = f.File
+		}
+	}
+	merged := mergeIDSet(ids(base), ids(ours), ids(theirs))
+	sort.Strings(merged)
+	out := SplitIndex{SchemaVersion: SchemaVersion, Features: []SplitFeature{}}
+	for _, id := range merged {
+		out.Features = append(out.Features, SplitFeature{ID: id, File: files[id]})
+	}
+	return out
+}
@@ -0,0 +1,153 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func splitTestGraph(now time.Time) WorkGraph {
+	g := WorkGraph{SchemaVersion: SchemaVersion, Items: map[string]WorkItem{}}
+	for _, id := range []string{"auth", "login", "billing", "invoices", "ops/deploy"} {
+		g.Items[id] = wi(id, now)
+	}
+	for parent, child := range map[string]string{"auth": "login", "billing": "invoices"} {
+		p, c := g.Items[parent], g.Items[child]
+		p.ChildIDs = []string{child}
+		c.ParentID = &parent
+		g.Items[parent], g.Items[child] = p, c
+	}
+	inv := g.Items["invoices"]
+	inv.Deps = []string{"login"}
+	g.Items["invoices"] = inv
+	return g
+}
+
+func TestSplitLayoutRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, DefaultPlanFilename)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	g := splitTestGraph(now)
+
+	if err := SaveSplit(planPath, g); err != nil {
+		t.Fatalf("SaveSplit: %v", err)
+	}
+	if !IsSplit(planPath) || !Exists(planPath) {
+		t.Fatalf("expected split layout to be detected")
+	}
+	for _, name := range []string{"index.json", "auth.json", "billing.json", "ops%2Fdeploy.json"} {
+		if _, err := os.Stat(filepath.Join(SplitDir(planPath), name)); err != nil {
+			t.Fatalf("expected %s: %v", name, err)
+		}
+	}
+
+	got, err := Load(planPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	g.ExternalRoot = ProjectRoot(planPath)
+	if !reflect.DeepEqual(got, g) {
+		t.Fatalf("round trip mismatch:\n got: %#v\nwant: %#v", got, g)
+	}
+}
+
+func TestSplitLayoutStatusUpdateTouchesOnlyAffectedFeature(t *testing.T) {
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, DefaultPlanFilename)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	if err := SaveSplit(planPath, splitTestGraph(now)); err != nil {
+		t.Fatalf("SaveSplit: %v", err)
+	}
+
+	// Backdate every file so a rewrite is detectable by mtime.
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	splitDir := SplitDir(planPath)
+	entries, err := os.ReadDir(splitDir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	for _, e := range entries {
+		if err := os.Chtimes(filepath.Join(splitDir, e.Name()), old, old); err != nil {
+			t.Fatalf("Chtimes: %v", err)
+		}
+	}
+
+	g, err := Load(planPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if err := SetStatus(&g, "login", StatusDone, now.Add(time.Hour)); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if err := SaveAtomic(planPath, g); err != nil {
+		t.Fatalf("SaveAtomic: %v", err)
+	}
+
+	for _, name := range []string{"index.json", "billing.json", "ops%2Fdeploy.json"} {
+		info, err := os.Stat(filepath.Join(splitDir, name))
+		if err != nil {
+			t.Fatalf("stat %s: %v", name, err)
+		}
+		if !info.ModTime().Equal(old) {
+			t.Fatalf("%s was rewritten", name)
+		}
+	}
+	info, err := os.Stat(filepath.Join(splitDir, "auth.json"))
+	if err != nil {
+		t.Fatalf("stat auth.json: %v", err)
+	}
+	if info.ModTime().Equal(old) {
+		t.Fatalf("auth.json was not rewritten")
+	}
+	if _, err := os.Stat(planPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no single-file plan, stat err = %v", err)
+	}
+}
+
+func TestSplitLayoutRemovesDeletedFeatureFiles(t *testing.T) {
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, DefaultPlanFilename)
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	g := splitTestGraph(now)
+	if err := SaveSplit(planPath, g); err != nil {
+		t.Fatalf("SaveSplit: %v", err)
+	}
+
+	delete(g.Items, "ops/deploy")
+	if err := SaveAtomic(planPath, g); err != nil {
+		t.Fatalf("SaveAtomic: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(SplitDir(planPath), "ops%2Fdeploy.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected stale feature file removed, stat err = %v", err)
+	}
+	got, err := Load(planPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(got.Items) != 4 {
+		t.Fatalf("items = %d, want 4", len(got.Items))
+	}
+}
+
+func TestSplitLayoutIgnoredWhenPlanFileExists(t *testing.T) {
+	dir := t.TempDir()
+	planPath := filepath.Join(dir, DefaultPlanFilename)
+	if err := SaveSplit(planPath, splitTestGraph(time.Now().UTC())); err != nil {
+		t.Fatalf("SaveSplit: %v", err)
+	}
+	if err := SaveFile(planPath, NewEmptyWorkGraph()); err != nil {
+		t.Fatalf("SaveFile: %v", err)
+	}
+	if IsSplit(planPath) {
+		t.Fatalf("single plan file should take precedence over split layout")
+	}
+	g, err := Load(planPath)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(g.Items) != 0 {
+		t.Fatalf("expected single-file plan to be loaded, got %d items", len(g.Items))
+	}
+}
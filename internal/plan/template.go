@@ -0,0 +1,318 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Template files live in TemplatesDirName next to the plan (project) and under
+// the home directory (global). A template named "endpoint" is read from
+// endpoint.json, endpoint.yaml or endpoint.yml; project templates shadow
+// global ones with the same name.
+//
+// Strings in a template may contain {{param}} placeholders, which are
+// replaced with the values given at instantiation (or the param's default).
+// Item ids are expanded too, and deps refer to expanded ids: either another
+// item of the template or an item already in the plan.
+const TemplatesDirName = ".blackbird/templates"
+
+var templatePlaceholderRe = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_-]*)\s*\}\}`)
+
+type Template struct {
+	// Name and Path are set from the file the template was read from.
+	Name        string          `json:"-"`
+	Path        string          `json:"-"`
+	Description string          `json:"description,omitempty"`
+	Params      []TemplateParam `json:"params,omitempty"`
+	Items       []TemplateItem  `json:"items"`
+}
+
+type TemplateParam struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Default is used when no value is given; a param without one is required.
+	Default *string `json:"default,omitempty"`
+}
+
+type TemplateItem struct {
+	ID                 string         `json:"id"`
+	Title              string         `json:"title"`
+	Description        string         `json:"description,omitempty"`
+	AcceptanceCriteria []string       `json:"acceptanceCriteria,omitempty"`
+	Prompt             string         `json:"prompt,omitempty"`
+	Deps               []string       `json:"deps,omitempty"`
+	EstimateMinutes    *int           `json:"estimateMinutes,omitempty"`
+	Children           []TemplateItem `json:"children,omitempty"`
+}
+
+// TemplateDirs returns the template directories for the plan at planPath,
+// project first.
+func TemplateDirs(planPath string) []string {
+	dirs := []string{filepath.Join(ProjectRoot(planPath), filepath.FromSlash(TemplatesDirName))}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		dirs = append(dirs, filepath.Join(home, filepath.FromSlash(TemplatesDirName)))
+	}
+	return dirs
+}
+
+// LoadTemplates reads every template in dirs, sorted by name. A template in
+// an earlier dir shadows one with the same name in a later dir; missing dirs
+// are skipped.
+func LoadTemplates(dirs ...string) ([]Template, error) {
+	seen := map[string]bool{}
+	var out []Template
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("read template dir %s: %w", dir, err)
+		}
+		for _, e := range entries {
+			name, ok := templateNameFromFile(e.Name())
+			if e.IsDir() || !ok || seen[name] {
+				continue
+			}
+			t, err := LoadTemplate(filepath.Join(dir, e.Name()))
+			if err != nil {
+				return nil, err
+			}
+			seen[name] = true
+			out = append(out, t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// FindTemplate returns the template called name from the first dir that has it.
+func FindTemplate(name string, dirs ...string) (Template, error) {
+	for _, dir := range dirs {
+		for _, ext := range []string{".json", ".yaml", ".yml"} {
+			path := filepath.Join(dir, name+ext)
+			if _, err := os.Stat(path); err == nil {
+				return LoadTemplate(path)
+			}
+		}
+	}
+	return Template{}, fmt.Errorf("unknown template %q (looked in %s)", name, strings.Join(dirs, ", "))
+}
+
+// LoadTemplate reads and checks one template file.
+func LoadTemplate(path string) (Template, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Template{}, fmt.Errorf("read template %s: %w", path, err)
+	}
+	name, _ := templateNameFromFile(filepath.Base(path))
+	t, err := DecodeTemplate(path, b, FormatForPath(path))
+	if err != nil {
+		return Template{}, err
+	}
+	t.Name = name
+	t.Path = path
+	return t, nil
+}
+
+// DecodeTemplate parses template data in the given format; name is used in
+// error messages.
+func DecodeTemplate(name string, b []byte, format Format) (Template, error) {
+	if format == FormatYAML {
+		var err error
+		b, err = yamlToJSON(b, reflect.TypeOf(Template{}))
+		if err != nil {
+			return Template{}, fmt.Errorf("parse template %s: %w", name, err)
+		}
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var t Template
+	if err := dec.Decode(&t); err != nil {
+		return Template{}, fmt.Errorf("parse template %s: %w", name, err)
+	}
+	if err := dec.Decode(&struct{}{}); err != io.EOF {
+		return Template{}, fmt.Errorf("parse template %s: trailing data", name)
+	}
+	if err := t.check(); err != nil {
+		return Template{}, fmt.Errorf("template %s: %w", name, err)
+	}
+	return t, nil
+}
+
+func templateNameFromFile(file string) (string, bool) {
+	ext := filepath.Ext(file)
+	switch strings.ToLower(ext) {
+	case ".json", ".yaml", ".yml":
+		name := strings.TrimSuffix(file, ext)
+		return name, name != ""
+	}
+	return "", false
+}
+
+// check verifies the template is usable before any params are known: items
+// exist, raw ids are unique, and every placeholder names a declared param.
+func (t Template) check() error {
+	if len(t.Items) == 0 {
+		return errors.New("no items")
+	}
+	declared := map[string]bool{}
+	for _, p := range t.Params {
+		if strings.TrimSpace(p.Name) == "" {
+			return errors.New("param without a name")
+		}
+		if declared[p.Name] {
+			return fmt.Errorf("duplicate param %q", p.Name)
+		}
+		declared[p.Name] = true
+	}
+	checkText := func(s string) error {
+		for _, m := range templatePlaceholderRe.FindAllStringSubmatch(s, -1) {
+			if !declared[m[1]] {
+				return fmt.Errorf("undeclared param %q", m[1])
+			}
+		}
+		return nil
+	}
+	ids := map[string]bool{}
+	var walk func(items []TemplateItem) error
+	walk = func(items []TemplateItem) error {
+		for _, it := range items {
+			if strings.TrimSpace(it.ID) == "" {
+				return fmt.Errorf("item %q has no id", it.Title)
+			}
+			if strings.TrimSpace(it.Title) == "" {
+				return fmt.Errorf("item %q has no title", it.ID)
+			}
+			if ids[it.ID] {
+				return fmt.Errorf("duplicate item id %q", it.ID)
+			}
+			ids[it.ID] = true
+			texts := []string{it.ID, it.Title, it.Description, it.Prompt}
+			texts = append(texts, it.AcceptanceCriteria...)
+			texts = append(texts, it.Deps...)
+			for _, s := range texts {
+				if err := checkText(s); err != nil {
+					return err
+				}
+			}
+			if err := walk(it.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return walk(t.Items)
+}
+
+// ResolveParams merges values with the template's defaults. Unknown names
+// and missing required params are errors.
+func (t Template) ResolveParams(values map[string]string) (map[string]string, error) {
+	out := map[string]string{}
+	declared := map[string]bool{}
+	var missing []string
+	for _, p := range t.Params {
+		declared[p.Name] = true
+		if v, ok := values[p.Name]; ok {
+			out[p.Name] = v
+		} else if p.Default != nil {
+			out[p.Name] = *p.Default
+		} else {
+			missing = append(missing, p.Name)
+		}
+	}
+	var unknown []string
+	for name := range values {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	if len(unknown) != 0 {
+		return nil, fmt.Errorf("template %s has no param %s", t.Name, strings.Join(unknown, ", "))
+	}
+	if len(missing) != 0 {
+		return nil, fmt.Errorf("template %s: missing param %s", t.Name, strings.Join(missing, ", "))
+	}
+	return out, nil
+}
+
+// InstantiateTemplate adds the template's items under parentID (nil for
+// root), top-level items starting at index, then wires their deps. It
+// returns the new ids in template order. g is unchanged on error.
+func InstantiateTemplate(g *WorkGraph, t Template, values map[string]string, parentID *string, index *int, now time.Time) ([]string, error) {
+	params, err := t.ResolveParams(values)
+	if err != nil {
+		return nil, err
+	}
+	expand := func(s string) string {
+		return templatePlaceholderRe.ReplaceAllStringFunc(s, func(m string) string {
+			return params[templatePlaceholderRe.FindStringSubmatch(m)[1]]
+		})
+	}
+	expandAll := func(ss []string) []string {
+		out := make([]string, 0, len(ss))
+		for _, s := range ss {
+			out = append(out, expand(s))
+		}
+		return out
+	}
+
+	work := Clone(*g)
+	var ids []string
+	var deps [][2]string
+	var add func(items []TemplateItem, parent *string, index *int) error
+	add = func(items []TemplateItem, parent *string, index *int) error {
+		for i, ti := range items {
+			id := strings.TrimSpace(expand(ti.ID))
+			it := WorkItem{
+				ID:                 id,
+				Title:              strings.TrimSpace(expand(ti.Title)),
+				Description:        expand(ti.Description),
+				AcceptanceCriteria: expandAll(ti.AcceptanceCriteria),
+				Prompt:             expand(ti.Prompt),
+				Status:             StatusTodo,
+			}
+			if ti.EstimateMinutes != nil {
+				n := *ti.EstimateMinutes
+				it.EstimateMinutes = &n
+			}
+			var idx *int
+			if index != nil {
+				n := *index + i
+				idx = &n
+			}
+			if err := AddItem(&work, it, parent, idx, now); err != nil {
+				return fmt.Errorf("template %s: %w", t.Name, err)
+			}
+			ids = append(ids, id)
+			for _, dep := range expandAll(ti.Deps) {
+				deps = append(deps, [2]string{id, dep})
+			}
+			if err := add(ti.Children, &id, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := add(t.Items, parentID, index); err != nil {
+		return nil, err
+	}
+	for _, d := range deps {
+		if err := AddDep(&work, d[0], d[1], now); err != nil {
+			return nil, fmt.Errorf("template %s: %w", t.Name, err)
+		}
+	}
+	*g = work
+	return ids, nil
+}
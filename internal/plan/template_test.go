@@ -0,0 +1,124 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const endpointTemplateJSON = `{
+  "description": "REST endpoint",
+  "params": [
+    {"name": "name"},
+    {"name": "method", "default": "GET"}
+  ],
+  "items": [
+    {
+      "id": "{{name}}-endpoint",
+      "title": "{{method}} /{{name}}",
+      "children": [
+        {"id": "{{name}}-schema", "title": "Schema for {{name}}", "deps": ["db"]},
+        {"id": "{{name}}-handler", "title": "Handler", "prompt": "Implement {{ method }} /{{name}}.", "deps": ["{{name}}-schema"]},
+        {"id": "{{name}}-tests", "title": "Tests", "acceptanceCriteria": ["covers {{name}}"], "deps": ["{{name}}-handler"]}
+      ]
+    }
+  ]
+}`
+
+func TestInstantiateTemplateBuildsSubtreeWithDeps(t *testing.T) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	tmpl, err := DecodeTemplate("endpoint.json", []byte(endpointTemplateJSON), FormatJSON)
+	if err != nil {
+		t.Fatalf("DecodeTemplate: %v", err)
+	}
+	parent := wi("api", now)
+	db := wi("db", now)
+	db.Status = StatusDone
+	g := graphOf(parent, db)
+
+	ids, err := InstantiateTemplate(&g, tmpl, map[string]string{"name": "users"}, &parent.ID, nil, now)
+	if err != nil {
+		t.Fatalf("InstantiateTemplate: %v", err)
+	}
+	if want := []string{"users-endpoint", "users-schema", "users-handler", "users-tests"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ids = %v, want %v", ids, want)
+	}
+	if errs := Validate(g); len(errs) != 0 {
+		t.Fatalf("Validate: %v", errs)
+	}
+	if got := g.Items["api"].ChildIDs; !reflect.DeepEqual(got, []string{"users-endpoint"}) {
+		t.Fatalf("api children = %v", got)
+	}
+	if got := g.Items["users-endpoint"].Title; got != "GET /users" {
+		t.Fatalf("title = %q", got)
+	}
+	handler := g.Items["users-handler"]
+	if handler.Prompt != "Implement GET /users." || !reflect.DeepEqual(handler.Deps, []string{"users-schema"}) {
+		t.Fatalf("handler = %+v", handler)
+	}
+	if got := g.Items["users-schema"].Deps; !reflect.DeepEqual(got, []string{"db"}) {
+		t.Fatalf("schema deps = %v", got)
+	}
+	if got := g.Items["users-tests"].AcceptanceCriteria; !reflect.DeepEqual(got, []string{"covers users"}) {
+		t.Fatalf("tests ac = %v", got)
+	}
+
+	before := Clone(g)
+	if _, err := InstantiateTemplate(&g, tmpl, map[string]string{"name": "users"}, nil, nil, now); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate id error, got %v", err)
+	}
+	if _, err := InstantiateTemplate(&g, tmpl, map[string]string{}, nil, nil, now); err == nil || !strings.Contains(err.Error(), "missing param name") {
+		t.Fatalf("expected missing param error, got %v", err)
+	}
+	if _, err := InstantiateTemplate(&g, tmpl, map[string]string{"name": "x", "verb": "y"}, nil, nil, now); err == nil || !strings.Contains(err.Error(), "no param verb") {
+		t.Fatalf("expected unknown param error, got %v", err)
+	}
+	if !reflect.DeepEqual(g, before) {
+		t.Fatalf("graph changed by failed instantiation")
+	}
+}
+
+func TestDecodeTemplateRejectsUndeclaredPlaceholder(t *testing.T) {
+	_, err := DecodeTemplate("bad.json", []byte(`{"items": [{"id": "{{nme}}-x", "title": "x"}]}`), FormatJSON)
+	if err == nil || !strings.Contains(err.Error(), `undeclared param "nme"`) {
+		t.Fatalf("expected undeclared param error, got %v", err)
+	}
+}
+
+func TestLoadTemplatesProjectShadowsGlobal(t *testing.T) {
+	project := t.TempDir()
+	global := t.TempDir()
+	write := func(dir, file, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", file, err)
+		}
+	}
+	write(project, "migration.yaml", "description: project migration\nitems:\n  - id: migrate\n    title: Migrate\n")
+	write(global, "migration.json", `{"description": "global migration", "items": [{"id": "m", "title": "M"}]}`)
+	write(global, "endpoint.json", endpointTemplateJSON)
+	write(global, "README.md", "not a template")
+
+	templates, err := LoadTemplates(project, global, filepath.Join(global, "missing"))
+	if err != nil {
+		t.Fatalf("LoadTemplates: %v", err)
+	}
+	var got []string
+	for _, tmpl := range templates {
+		got = append(got, tmpl.Name+": "+tmpl.Description)
+	}
+	if want := []string{"endpoint: REST endpoint", "migration: project migration"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("templates = %v, want %v", got, want)
+	}
+
+	tmpl, err := FindTemplate("migration", project, global)
+	if err != nil || tmpl.Path != filepath.Join(project, "migration.yaml") {
+		t.Fatalf("FindTemplate = %+v, %v", tmpl, err)
+	}
+	if _, err := FindTemplate("nope", project, global); err == nil {
+		t.Fatalf("expected unknown template error")
+	}
+}
@@ -1,15 +1,26 @@
 package plan
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 const (
 	DefaultPlanFilename = "blackbird.plan.json"
+	YAMLPlanFilename    = "blackbird.plan.yaml"
+	YMLPlanFilename     = "blackbird.plan.yml"
 	SchemaVersion       = 1
 )
 
 type WorkGraph struct {
 	SchemaVersion int                 `json:"schemaVersion"`
 	Items         map[string]WorkItem `json:"items"`
+	// External holds the external deps of Items as resolved by Load (see
+	// ResolveExternalDeps), keyed by dep. It is never saved.
+	External map[string]ExternalRef `json:"-"`
+	// ExternalRoot is the project root external deps resolve against; deps
+	// added since Load are resolved against it on demand.
+	ExternalRoot string `json:"-"`
 }
 
 type Status string
@@ -39,6 +50,59 @@ type WorkItem struct {
 	UpdatedAt          time.Time         `json:"updatedAt"`
 	Notes              *string           `json:"notes,omitempty"`
 	DepRationale       map[string]string `json:"depRationale,omitempty"`
+	// Agent overrides the configured agent limits for this item's runs.
+	Agent *AgentOverrides `json:"agent,omitempty"`
+	// EstimateMinutes is the expected duration of the item's work, used by
+	// schedule analysis.
+	EstimateMinutes *int `json:"estimateMinutes,omitempty"`
+	// LintIgnore lists plan-quality rule codes not reported for this item;
+	// "*" suppresses every rule.
+	LintIgnore []string `json:"lintIgnore,omitempty"`
+}
+
+// AgentOverrides replaces agent.executionTimeoutSeconds / agent.maxRetries
+// for one item; nil fields keep the configured value.
+type AgentOverrides struct {
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+	MaxRetries     *int `json:"maxRetries,omitempty"`
+}
+
+func (o *AgentOverrides) clone() *AgentOverrides {
+	if o == nil {
+		return nil
+	}
+	out := &AgentOverrides{}
+	if o.TimeoutSeconds != nil {
+		v := *o.TimeoutSeconds
+		out.TimeoutSeconds = &v
+	}
+	if o.MaxRetries != nil {
+		v := *o.MaxRetries
+		out.MaxRetries = &v
+	}
+	return out
+}
+
+// key renders the overrides for comparison and merge conflict messages.
+func (o *AgentOverrides) key() string {
+	if o == nil {
+		return ""
+	}
+	field := func(v *int) string {
+		if v == nil {
+			return "-"
+		}
+		return fmt.Sprint(*v)
+	}
+	return "timeoutSeconds=" + field(o.TimeoutSeconds) + " maxRetries=" + field(o.MaxRetries)
+}
+
+// estimateKey renders an estimate for comparison and merge conflict messages.
+func estimateKey(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(*v)
 }
 
 func NewEmptyWorkGraph() WorkGraph {
@@ -1,7 +1,9 @@
 package plan
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 type ValidationError struct {
@@ -45,6 +47,9 @@ func Validate(g WorkGraph) []ValidationError {
 		if it.ID != "" && it.ID != key {
 			errs = append(errs, ValidationError{Path: path + ".id", Message: fmt.Sprintf("must match map key %q", key)})
 		}
+		if IsExternalDep(key) {
+			errs = append(errs, ValidationError{Path: path + ".id", Message: fmt.Sprintf("must not start with %q, which marks deps on other plans", ExternalDepPrefix)})
+		}
 
 		if it.Title == "" {
 			errs = append(errs, ValidationError{Path: path + ".title", Message: "required"})
@@ -62,6 +67,22 @@ func Validate(g WorkGraph) []ValidationError {
 		if it.Deps == nil {
 			errs = append(errs, ValidationError{Path: path + ".deps", Message: "required (use [] if none)"})
 		}
+		if it.Agent != nil {
+			if it.Agent.TimeoutSeconds != nil && *it.Agent.TimeoutSeconds < 1 {
+				errs = append(errs, ValidationError{Path: path + ".agent.timeoutSeconds", Message: "must be at least 1"})
+			}
+			if it.Agent.MaxRetries != nil && *it.Agent.MaxRetries < 0 {
+				errs = append(errs, ValidationError{Path: path + ".agent.maxRetries", Message: "must not be negative"})
+			}
+		}
+		if it.EstimateMinutes != nil && *it.EstimateMinutes < 1 {
+			errs = append(errs, ValidationError{Path: path + ".estimateMinutes", Message: "must be at least 1"})
+		}
+		for i, code := range it.LintIgnore {
+			if strings.TrimSpace(code) == "" {
+				errs = append(errs, ValidationError{Path: fmt.Sprintf("%s.lintIgnore[%d]", path, i), Message: "must not be empty"})
+			}
+		}
 
 		if !isValidStatus(it.Status) {
 			errs = append(errs, ValidationError{
@@ -135,6 +156,18 @@ func Validate(g WorkGraph) []ValidationError {
 			}
 			seenDep[depID] = true
 
+			if IsExternalDep(depID) {
+				// Deps that failed to resolve at load are reported by
+				// ExternalDepErrors; only malformed or newly added ones fail here.
+				ref, loaded := lookupExternalRef(g, depID)
+				if loaded {
+					_, ref.Err = ParseExternalDep(depID)
+				}
+				if err := externalDepError(depID, ref.Err); err != nil {
+					errs = append(errs, ValidationError{Path: dpath, Message: err.Error()})
+				}
+				continue
+			}
 			if _, ok := g.Items[depID]; !ok {
 				errs = append(errs, ValidationError{Path: dpath, Message: fmt.Sprintf("unknown dep id %q", depID)})
 			}
@@ -142,7 +175,7 @@ func Validate(g WorkGraph) []ValidationError {
 
 		// If depRationale is present, ensure keys refer to existing deps.
 		for depID := range it.DepRationale {
-			if _, ok := g.Items[depID]; !ok {
+			if _, ok := g.Items[depID]; !ok && !IsExternalDep(depID) {
 				errs = append(errs, ValidationError{Path: path + ".depRationale", Message: fmt.Sprintf("unknown dep id %q", depID)})
 				continue
 			}
@@ -200,6 +233,25 @@ func Validate(g WorkGraph) []ValidationError {
 	return errs
 }
 
+// externalDepError describes why the external dep could not be resolved, or
+// returns nil when err is nil.
+func externalDepError(dep string, err error) error {
+	if err == nil {
+		return nil
+	}
+	d, perr := ParseExternalDep(dep)
+	switch {
+	case perr != nil:
+		return perr
+	case errors.Is(err, ErrPlanNotFound):
+		return fmt.Errorf("unknown plan %q in dep %q", d.Plan, dep)
+	case errors.Is(err, ErrExternalItemNotFound):
+		return fmt.Errorf("unknown dep id %q in plan %q", d.ID, d.Plan)
+	default:
+		return fmt.Errorf("load plan %q for dep %q: %v", d.Plan, dep, err)
+	}
+}
+
 type visitState uint8
 
 const (
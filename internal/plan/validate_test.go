@@ -168,3 +168,54 @@ func TestValidate_DetectsDepCycle(t *testing.T) {
 }
 
 func ptr(s string) *string { return &s }
+
+func TestValidate_RejectsInvalidAgentOverrides(t *testing.T) {
+	now := time.Now()
+	timeout, retries := 0, -1
+	g := WorkGraph{
+		SchemaVersion: SchemaVersion,
+		Items: map[string]WorkItem{
+			"A": {
+				ID:                 "A",
+				Title:              "A",
+				AcceptanceCriteria: []string{},
+				ChildIDs:           []string{},
+				Deps:               []string{},
+				Status:             StatusTodo,
+				Agent:              &AgentOverrides{TimeoutSeconds: &timeout, MaxRetries: &retries},
+				CreatedAt:          now,
+				UpdatedAt:          now,
+			},
+		},
+	}
+
+	errs := Validate(g)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+}
+
+func TestValidate_RejectsEmptyLintIgnoreCodes(t *testing.T) {
+	now := time.Now()
+	g := WorkGraph{
+		SchemaVersion: SchemaVersion,
+		Items: map[string]WorkItem{
+			"A": {
+				ID:                 "A",
+				Title:              "A",
+				AcceptanceCriteria: []string{},
+				ChildIDs:           []string{},
+				Deps:               []string{},
+				Status:             StatusTodo,
+				LintIgnore:         []string{"leaf_prompt_missing", " "},
+				CreatedAt:          now,
+				UpdatedAt:          now,
+			},
+		},
+	}
+
+	errs := Validate(g)
+	if len(errs) != 1 || errs[0].Path != `$.items["A"].lintIgnore[1]` {
+		t.Fatalf("expected one lintIgnore error, got %v", errs)
+	}
+}
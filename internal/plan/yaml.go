@@ -0,0 +1,255 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML plans and templates round-trip through JSON so Load can apply the same
+// strict DisallowUnknownFields decoder to both formats. Parsing and emitting
+// are done by gopkg.in/yaml.v3; this file only converts between its node tree
+// and JSON.
+//
+// Scalars are read according to the Go type of the field they land in, so
+// hand-written YAML like `title: 1` or `id: 2024` yields the strings "1" and
+// "2024" rather than numbers the strict decoder would reject. Multi-document
+// streams are rejected.
+
+// encodeYAML renders v (anything encoding/json can marshal) as block YAML,
+// keeping JSON field order. Multi-line strings become literal block scalars.
+func encodeYAML(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	node, err := jsonToYAMLNode(dec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonToYAMLNode reads one JSON value from dec as a YAML node tree.
+func jsonToYAMLNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if t == '[' {
+			node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := jsonToYAMLNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		if len(node.Content) == 0 {
+			node.Style = yaml.FlowStyle
+		}
+		return node, nil
+	case string:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: t}
+		if strings.Contains(t, "\n") {
+			node.Style = yaml.LiteralStyle
+		}
+		return node, nil
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(t.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: t.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: fmt.Sprint(t)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	default:
+		return nil, fmt.Errorf("unexpected JSON token %v", tok)
+	}
+}
+
+// yamlToJSON converts a YAML document to JSON, reading scalars according to the
+// fields of target (the type the JSON is decoded into).
+func yamlToJSON(data []byte, target reflect.Type) ([]byte, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var doc yaml.Node
+	if err := dec.Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return []byte("null"), nil
+		}
+		return nil, err
+	}
+	var extra yaml.Node
+	if err := dec.Decode(&extra); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", extra.Line)
+	}
+	v, err := yamlNodeValue(doc.Content[0], target)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// yamlNodeValue converts n to a JSON-marshalable value. A nil or interface
+// target leaves YAML's own scalar resolution in place.
+func yamlNodeValue(n *yaml.Node, target reflect.Type) (any, error) {
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	for target != nil && target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+	if target == nil || target.Kind() == reflect.Interface {
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	switch n.Kind {
+	case yaml.ScalarNode:
+		return yamlScalarValue(n, target)
+	case yaml.MappingNode:
+		var fields map[string]reflect.Type
+		switch target.Kind() {
+		case reflect.Struct:
+			fields = jsonFieldTypes(target)
+		case reflect.Map:
+		default:
+			return nil, yamlTypeError(n, target)
+		}
+		out := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i].Value
+			// Unknown struct keys keep YAML's own typing; the strict JSON
+			// decoder reports them.
+			valueType := fields[key]
+			if fields == nil {
+				valueType = target.Elem()
+			}
+			v, err := yamlNodeValue(n.Content[i+1], valueType)
+			if err != nil {
+				return nil, err
+			}
+			out[key] = v
+		}
+		return out, nil
+	case yaml.SequenceNode:
+		if target.Kind() != reflect.Slice && target.Kind() != reflect.Array {
+			return nil, yamlTypeError(n, target)
+		}
+		out := make([]any, 0, len(n.Content))
+		for _, child := range n.Content {
+			v, err := yamlNodeValue(child, target.Elem())
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("yaml: line %d: unsupported node", n.Line)
+	}
+}
+
+func yamlScalarValue(n *yaml.Node, target reflect.Type) (any, error) {
+	if n.ShortTag() == "!!null" {
+		return nil, nil
+	}
+	switch {
+	case target == timeType, target.Kind() == reflect.String:
+		return n.Value, nil
+	case target.Kind() == reflect.Bool:
+		var b bool
+		if n.ShortTag() != "!!bool" || n.Decode(&b) != nil {
+			return nil, yamlTypeError(n, target)
+		}
+		return b, nil
+	case target.Kind() >= reflect.Int && target.Kind() <= reflect.Float64:
+		var f any
+		if (n.ShortTag() != "!!int" && n.ShortTag() != "!!float") || n.Decode(&f) != nil {
+			return nil, yamlTypeError(n, target)
+		}
+		return f, nil
+	default:
+		return nil, yamlTypeError(n, target)
+	}
+}
+
+func yamlTypeError(n *yaml.Node, target reflect.Type) error {
+	want := "a " + target.Kind().String()
+	switch target.Kind() {
+	case reflect.Struct, reflect.Map:
+		want = "a mapping"
+	case reflect.Slice, reflect.Array:
+		want = "a list"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		want = "a number"
+	}
+	value := n.Value
+	if n.Kind != yaml.ScalarNode {
+		value = n.ShortTag()
+	}
+	return fmt.Errorf("yaml: line %d: expected %s, got %q", n.Line, want, value)
+}
+
+// jsonFieldTypes maps the JSON names of t's fields to their types.
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	out := map[string]reflect.Type{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		out[name] = f.Type
+	}
+	return out
+}
@@ -0,0 +1,213 @@
+package plan
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSaveAtomicYAMLRoundTrip(t *testing.T) {
+	now := time.Date(2026, 2, 3, 4, 5, 6, 789, time.UTC)
+	parent := "root"
+	notes := "  leading spaces\nsecond line"
+	g := WorkGraph{
+		SchemaVersion: SchemaVersion,
+		Items: map[string]WorkItem{
+			"root": {
+				ID:                 "root",
+				Title:              "Root: with colon",
+				Description:        "line one\nline two\n",
+				AcceptanceCriteria: []string{},
+				Prompt:             "",
+				ChildIDs:           []string{"2nd"},
+				Deps:               []string{},
+				Status:             StatusTodo,
+				CreatedAt:          now,
+				UpdatedAt:          now,
+			},
+			"2nd": {
+				ID:                 "2nd",
+				Title:              "yes",
+				Description:        "trailing blank lines\n\n\n",
+				AcceptanceCriteria: []string{"- starts with dash", "# hash", "\"quoted\" and 'single'", "tab\tinside", "123"},
+				Prompt:             "Do the thing.\n\n  indented code\n\nDone: ok",
+				ParentID:           &parent,
+				ChildIDs:           []string{},
+				Deps:               []string{},
+				Status:             StatusDone,
+				CreatedAt:          now,
+				UpdatedAt:          now,
+				Notes:              &notes,
+				DepRationale:       map[string]string{"x": "because"},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), YAMLPlanFilename)
+	if err := SaveAtomic(path, g); err != nil {
+		t.Fatalf("SaveAtomic: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.Contains(string(data), "prompt: |-\n      Do the thing.\n") {
+		t.Fatalf("expected multi-line prompt as a block scalar, got:\n%s", data)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v\n%s", err, data)
+	}
+	g.ExternalRoot = ProjectRoot(path)
+	if !reflect.DeepEqual(got, g) {
+		t.Fatalf("round trip mismatch:\n got: %#v\nwant: %#v\nyaml:\n%s", got, g, data)
+	}
+}
+
+func TestLoadHandWrittenYAML(t *testing.T) {
+	src := `# hand-edited plan
+schemaVersion: 1
+items:
+  a:
+    id: a
+    title: First task   # trailing comment
+    description: >
+      Folded text
+      joins lines.
+
+      New paragraph.
+    acceptanceCriteria:
+    - 'it''s done'
+    - "escaped \"quote\""
+    prompt: |
+      Step 1
+      Step 2
+    parentId: ~
+    childIds: []
+    deps: [ ]
+    status: todo
+    createdAt: "2026-01-01T00:00:00Z"
+    updatedAt: 2026-01-01T00:00:00Z
+`
+	path := filepath.Join(t.TempDir(), YAMLPlanFilename)
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	g, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if errs := Validate(g); len(errs) != 0 {
+		t.Fatalf("Validate: %v", errs)
+	}
+	it := g.Items["a"]
+	if it.Title != "First task" {
+		t.Fatalf("title = %q", it.Title)
+	}
+	if want := "Folded text joins lines.\nNew paragraph.\n"; it.Description != want {
+		t.Fatalf("description = %q, want %q", it.Description, want)
+	}
+	if want := []string{"it's done", `escaped "quote"`}; !reflect.DeepEqual(it.AcceptanceCriteria, want) {
+		t.Fatalf("acceptanceCriteria = %#v", it.AcceptanceCriteria)
+	}
+	if it.Prompt != "Step 1\nStep 2\n" {
+		t.Fatalf("prompt = %q", it.Prompt)
+	}
+}
+
+func TestLoadYAMLReadsScalarsByFieldType(t *testing.T) {
+	src := `schemaVersion: 1
+items:
+  "2024":
+    id: 2024
+    title: 1
+    description: a plain scalar
+      spanning two lines
+    acceptanceCriteria: [true, 3.5]
+    prompt: ""
+    childIds: []
+    deps: []
+    status: todo
+    estimateMinutes: 30
+    createdAt: 2026-01-01T00:00:00Z
+    updatedAt: 2026-01-01T00:00:00Z
+`
+	path := filepath.Join(t.TempDir(), YMLPlanFilename)
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	g, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	it := g.Items["2024"]
+	if it.ID != "2024" || it.Title != "1" || it.Description != "a plain scalar spanning two lines" {
+		t.Fatalf("item = %+v", it)
+	}
+	if want := []string{"true", "3.5"}; !reflect.DeepEqual(it.AcceptanceCriteria, want) {
+		t.Fatalf("acceptanceCriteria = %#v", it.AcceptanceCriteria)
+	}
+	if it.EstimateMinutes == nil || *it.EstimateMinutes != 30 {
+		t.Fatalf("estimateMinutes = %v", it.EstimateMinutes)
+	}
+
+	if err := os.WriteFile(path, []byte("schemaVersion: 1\nitems: {}\n---\nschemaVersion: 1\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "multiple documents") {
+		t.Fatalf("expected multi-document error, got %v", err)
+	}
+}
+
+func TestLoadYAMLRejectsUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), YAMLPlanFilename)
+	if err := os.WriteFile(path, []byte("schemaVersion: 1\nitems: {}\nextra: true\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+}
+
+func TestLoadYAMLReportsLineNumbers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), YAMLPlanFilename)
+	if err := os.WriteFile(path, []byte("schemaVersion: 1\nitems:\n  a:\n    id: a\n    childIds: x\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "yaml: line 5: expected a list") {
+		t.Fatalf("expected line-numbered error, got %v", err)
+	}
+}
+
+func TestPlanPathInDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	if got := PlanPathIn(dir); got != filepath.Join(dir, DefaultPlanFilename) {
+		t.Fatalf("default = %q", got)
+	}
+	yamlPath := filepath.Join(dir, YAMLPlanFilename)
+	if err := SaveAtomic(yamlPath, NewEmptyWorkGraph()); err != nil {
+		t.Fatalf("SaveAtomic: %v", err)
+	}
+	if got := PlanPathIn(dir); got != yamlPath {
+		t.Fatalf("with yaml = %q, want %q", got, yamlPath)
+	}
+	ymlDir := t.TempDir()
+	ymlPath := filepath.Join(ymlDir, YMLPlanFilename)
+	if err := SaveAtomic(ymlPath, NewEmptyWorkGraph()); err != nil {
+		t.Fatalf("SaveAtomic: %v", err)
+	}
+	if got := PlanPathIn(ymlDir); got != ymlPath {
+		t.Fatalf("with yml = %q, want %q", got, ymlPath)
+	}
+	jsonPath := filepath.Join(dir, DefaultPlanFilename)
+	if err := SaveAtomic(jsonPath, NewEmptyWorkGraph()); err != nil {
+		t.Fatalf("SaveAtomic: %v", err)
+	}
+	if got := PlanPathIn(dir); got != jsonPath {
+		t.Fatalf("with both = %q, want json %q", got, jsonPath)
+	}
+}
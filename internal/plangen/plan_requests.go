@@ -18,8 +18,11 @@ type Runner func(ctx context.Context, req agent.Request) (agent.Response, agent.
 // PlanResponseResult captures one plan request result.
 // If Questions is non-empty, Plan will be nil.
 type PlanResponseResult struct {
-	Plan        *plan.WorkGraph
-	Questions   []agent.Question
+	Plan      *plan.WorkGraph
+	Questions []agent.Question
+	// Patch holds the ops Plan was built from when the agent answered with a
+	// patch rather than a full plan.
+	Patch       []agent.PatchOp
 	Diagnostics agent.Diagnostics
 }
 
@@ -103,10 +106,14 @@ func responseToPlanResult(base plan.WorkGraph, resp agent.Response, diag agent.D
 		return PlanResponseResult{Diagnostics: diag}, err
 	}
 
-	return PlanResponseResult{
+	result := PlanResponseResult{
 		Plan:        ptrPlan(next),
 		Diagnostics: diag,
-	}, nil
+	}
+	if resp.Plan == nil {
+		result.Patch = append([]agent.PatchOp{}, resp.Patch...)
+	}
+	return result, nil
 }
 
 func ptrPlan(g plan.WorkGraph) *plan.WorkGraph {
@@ -2,7 +2,8 @@ package plangen
 
 import (
 	"context"
-	"path/filepath"
+	"fmt"
+	"regexp"
 
 	"github.com/jbonatakis/blackbird/internal/config"
 	"github.com/jbonatakis/blackbird/internal/plan"
@@ -14,6 +15,11 @@ type QualityGateRefineFunc func(ctx context.Context, changeRequest string, curre
 
 // RunQualityGate applies shared deterministic plan-quality orchestration.
 func RunQualityGate(ctx context.Context, initialPlan plan.WorkGraph, maxAutoRefinePasses int, refine QualityGateRefineFunc) (planquality.QualityGateResult, error) {
+	return RunQualityGateWithRules(ctx, initialPlan, maxAutoRefinePasses, planquality.DefaultRules(), refine)
+}
+
+// RunQualityGateWithRules is RunQualityGate linting with rules.
+func RunQualityGateWithRules(ctx context.Context, initialPlan plan.WorkGraph, maxAutoRefinePasses int, rules planquality.Rules, refine QualityGateRefineFunc) (planquality.QualityGateResult, error) {
 	var refineCallback planquality.AutoRefineFunc
 	if refine != nil {
 		refineCallback = func(input planquality.AutoRefineInput) (plan.WorkGraph, error) {
@@ -23,7 +29,7 @@ func RunQualityGate(ctx context.Context, initialPlan plan.WorkGraph, maxAutoRefi
 			return refine(ctx, input.ChangeRequest, input.Plan)
 		}
 	}
-	return planquality.RunQualityGate(initialPlan, maxAutoRefinePasses, refineCallback)
+	return planquality.RunQualityGateWithRules(initialPlan, maxAutoRefinePasses, rules, refineCallback)
 }
 
 // ResolveMaxAutoRefinePasses loads resolved config and returns planning.maxPlanAutoRefinePasses.
@@ -38,7 +44,74 @@ func ResolveMaxAutoRefinePasses(projectRoot string) int {
 
 // ResolveMaxAutoRefinePassesFromPlanPath resolves max auto-refine passes from a plan file path.
 func ResolveMaxAutoRefinePassesFromPlanPath(planPath string) int {
-	return ResolveMaxAutoRefinePasses(filepath.Dir(planPath))
+	return ResolveMaxAutoRefinePasses(plan.ProjectRoot(planPath))
+}
+
+// QualityRules converts the planning.quality config section into lint rules.
+// Custom rules without a severity default to warning.
+func QualityRules(cfg config.ResolvedPlanQuality) (planquality.Rules, error) {
+	rules := planquality.Rules{
+		Severity:              map[string]planquality.Severity{},
+		DescriptionMinWords:   cfg.DescriptionMinWords,
+		MinAcceptanceCriteria: cfg.MinAcceptanceCriteria,
+		MaxDepth:              cfg.MaxDepth,
+		OrphanLeafMinLeaves:   cfg.OrphanLeafMinLeaves,
+		Phrases:               cfg.Phrases,
+	}
+	for code, severity := range cfg.Rules {
+		rules.Severity[code] = planquality.Severity(severity)
+	}
+	for _, raw := range cfg.Custom {
+		rule := planquality.CustomRule{
+			Code:       raw.Code,
+			Severity:   planquality.Severity(raw.Severity),
+			Field:      raw.Field,
+			Message:    raw.Message,
+			Suggestion: raw.Suggestion,
+		}
+		if rule.Severity == "" {
+			rule.Severity = planquality.SeverityWarning
+		}
+		var err error
+		if rule.Match, err = compileQualityPattern(raw.Code, "match", raw.Match); err != nil {
+			return planquality.Rules{}, err
+		}
+		if rule.Require, err = compileQualityPattern(raw.Code, "require", raw.Require); err != nil {
+			return planquality.Rules{}, err
+		}
+		rules.Custom = append(rules.Custom, rule)
+	}
+	if err := rules.Validate(); err != nil {
+		return planquality.Rules{}, fmt.Errorf("planning.quality: %w", err)
+	}
+	return rules, nil
+}
+
+func compileQualityPattern(code, name, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("planning.quality: custom rule %q: invalid %s pattern: %w", code, name, err)
+	}
+	return re, nil
+}
+
+// ResolveQualityRules loads resolved config and returns the plan-quality lint
+// rules. On config load failure it falls back to defaults; an invalid
+// planning.quality section is returned as an error.
+func ResolveQualityRules(projectRoot string) (planquality.Rules, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return planquality.DefaultRules(), nil
+	}
+	return QualityRules(cfg.Planning.Quality)
+}
+
+// ResolveQualityRulesFromPlanPath resolves plan-quality lint rules from a plan file path.
+func ResolveQualityRulesFromPlanPath(planPath string) (planquality.Rules, error) {
+	return ResolveQualityRules(plan.ProjectRoot(planPath))
 }
 
 func contextErr(ctx context.Context) error {
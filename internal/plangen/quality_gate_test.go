@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/config"
 	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/planquality"
 )
 
 func TestRunQualityGateParityForEquivalentRefineResponses(t *testing.T) {
@@ -127,3 +129,34 @@ func testPlanWithDescription(description string) plan.WorkGraph {
 		},
 	}
 }
+
+func TestQualityRulesRejectsInvalidConfig(t *testing.T) {
+	base := config.DefaultResolvedConfig().Planning.Quality
+	cases := map[string]func(*config.ResolvedPlanQuality){
+		"severity": func(q *config.ResolvedPlanQuality) { q.Rules = map[string]string{"leaf_prompt_missing": "fatal"} },
+		"phrases":  func(q *config.ResolvedPlanQuality) { q.Phrases = map[string][]string{"nope": {"x"}} },
+		"regexp": func(q *config.ResolvedPlanQuality) {
+			q.Custom = []config.RawCustomQualityRule{{Code: "c", Field: "prompt", Match: "(", Message: "m"}}
+		},
+		"field": func(q *config.ResolvedPlanQuality) {
+			q.Custom = []config.RawCustomQualityRule{{Code: "c", Field: "deps", Match: "x", Message: "m"}}
+		},
+	}
+	for name, mutate := range cases {
+		q := base
+		mutate(&q)
+		if _, err := QualityRules(q); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+
+	q := base
+	q.Custom = []config.RawCustomQualityRule{{Code: "needs_ticket", Field: "description", Require: `[A-Z]+-\d+`, Message: "m"}}
+	rules, err := QualityRules(q)
+	if err != nil {
+		t.Fatalf("QualityRules: %v", err)
+	}
+	if len(rules.Custom) != 1 || rules.Custom[0].Severity != planquality.SeverityWarning {
+		t.Fatalf("custom = %+v, want one warning rule", rules.Custom)
+	}
+}
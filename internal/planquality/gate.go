@@ -31,12 +31,17 @@ type QualityGateResult struct {
 
 // RunQualityGate executes lint -> optional refine -> relint passes with a bounded loop.
 func RunQualityGate(initialPlan plan.WorkGraph, maxAutoRefinePasses int, refine AutoRefineFunc) (QualityGateResult, error) {
+	return RunQualityGateWithRules(initialPlan, maxAutoRefinePasses, DefaultRules(), refine)
+}
+
+// RunQualityGateWithRules is RunQualityGate linting with rules.
+func RunQualityGateWithRules(initialPlan plan.WorkGraph, maxAutoRefinePasses int, rules Rules, refine AutoRefineFunc) (QualityGateResult, error) {
 	if maxAutoRefinePasses < 0 {
 		maxAutoRefinePasses = 0
 	}
 
 	currentPlan := plan.Clone(initialPlan)
-	initialFindings := Lint(currentPlan)
+	initialFindings := LintWithRules(currentPlan, rules)
 	finalFindings := append([]PlanQualityFinding(nil), initialFindings...)
 
 	result := QualityGateResult{
@@ -68,7 +73,7 @@ func RunQualityGate(initialPlan plan.WorkGraph, maxAutoRefinePasses int, refine
 		}
 
 		currentPlan = plan.Clone(nextPlan)
-		finalFindings = Lint(currentPlan)
+		finalFindings = LintWithRules(currentPlan, rules)
 		result.FinalPlan = plan.Clone(currentPlan)
 		result.FinalFindings = append([]PlanQualityFinding(nil), finalFindings...)
 		result.AutoRefinePassesRun++
@@ -0,0 +1,422 @@
+package planquality
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+const (
+	RuleRedundantTransitiveDep        = "redundant_transitive_dep"
+	RuleOrphanLeaf                    = "orphan_leaf"
+	RuleParentSingleChild             = "parent_single_child"
+	RuleNestingTooDeep                = "nesting_too_deep"
+	RuleUndeclaredDependencyReference = "undeclared_dependency_reference"
+	RuleDuplicateTitle                = "duplicate_title"
+	RuleParentCriteriaUncovered       = "parent_criteria_uncovered"
+)
+
+const (
+	fieldTitle    = "title"
+	fieldDeps     = "deps"
+	fieldChildIDs = "childIds"
+	fieldParentID = "parentId"
+
+	DefaultMaxDepth            = 4
+	DefaultOrphanLeafMinLeaves = 10
+
+	// nearDuplicateTitleSimilarity is the token-set overlap at which two
+	// titles count as near-duplicates.
+	nearDuplicateTitleSimilarity = 0.8
+	// minReferenceIDLength keeps short IDs such as "A" from matching ordinary
+	// prompt words.
+	minReferenceIDLength = 4
+	// minReferenceTitleWords keeps short, generic titles from matching.
+	minReferenceTitleWords = 3
+)
+
+// coverageStopWords are ignored when comparing parent and child criteria.
+var coverageStopWords = map[string]bool{
+	"that": true, "this": true, "with": true, "from": true, "when": true,
+	"then": true, "than": true, "have": true, "must": true, "should": true,
+	"will": true, "each": true, "into": true, "only": true, "also": true,
+	"they": true, "their": true, "there": true, "been": true, "were": true,
+}
+
+// graphLinter runs the structural checks that look at relationships between
+// items rather than at one leaf's text.
+type graphLinter struct {
+	g         plan.WorkGraph
+	rules     Rules
+	ids       []string
+	reachable map[string]map[string]bool
+}
+
+func (l linter) lintGraph(g plan.WorkGraph) []PlanQualityFinding {
+	gl := graphLinter{g: g, rules: l.rules, reachable: map[string]map[string]bool{}}
+	for id := range g.Items {
+		gl.ids = append(gl.ids, id)
+	}
+	sort.Strings(gl.ids)
+
+	var findings []PlanQualityFinding
+	findings = append(findings, gl.redundantDeps()...)
+	findings = append(findings, gl.orphanLeaves()...)
+	findings = append(findings, gl.singleChildParents()...)
+	findings = append(findings, gl.deepNesting()...)
+	findings = append(findings, gl.undeclaredReferences()...)
+	findings = append(findings, gl.duplicateTitles()...)
+	findings = append(findings, gl.uncoveredParentCriteria()...)
+	return findings
+}
+
+// reach returns every item reachable from id through deps, excluding id.
+func (gl graphLinter) reach(id string) map[string]bool {
+	if out, ok := gl.reachable[id]; ok {
+		return out
+	}
+	out := map[string]bool{}
+	gl.reachable[id] = out
+	var walk func(string)
+	walk = func(cur string) {
+		for _, dep := range gl.g.Items[cur].Deps {
+			if out[dep] || dep == id {
+				continue
+			}
+			out[dep] = true
+			walk(dep)
+		}
+	}
+	walk(id)
+	return out
+}
+
+func (gl graphLinter) redundantDeps() []PlanQualityFinding {
+	var findings []PlanQualityFinding
+	for _, id := range gl.ids {
+		deps := gl.g.Items[id].Deps
+		for _, dep := range deps {
+			for _, via := range deps {
+				if via == dep || !gl.reach(via)[dep] {
+					continue
+				}
+				findings = append(findings, newFinding(
+					SeverityWarning,
+					RuleRedundantTransitiveDep,
+					id,
+					fieldDeps,
+					fmt.Sprintf("Dependency on %q is redundant; it is already reached through %q.", dep, via),
+					"Remove the direct dependency; the transitive one already enforces the order.",
+				))
+				break
+			}
+		}
+	}
+	return findings
+}
+
+func (gl graphLinter) orphanLeaves() []PlanQualityFinding {
+	leaves := LeafTaskIDs(gl.g)
+	if gl.rules.OrphanLeafMinLeaves <= 0 || len(leaves) < gl.rules.OrphanLeafMinLeaves {
+		return nil
+	}
+	depended := map[string]bool{}
+	for _, it := range gl.g.Items {
+		for _, dep := range it.Deps {
+			depended[dep] = true
+		}
+	}
+
+	var findings []PlanQualityFinding
+	for _, id := range leaves {
+		// Execution only checks the leaf's own deps (plan.UnmetDeps), so deps
+		// declared on an ancestor do not order the leaf.
+		if len(gl.g.Items[id].Deps) > 0 || depended[id] {
+			continue
+		}
+		findings = append(findings, newFinding(
+			SeverityWarning,
+			RuleOrphanLeaf,
+			id,
+			fieldDeps,
+			"Leaf task has no dependencies and nothing depends on it.",
+			"Add the dependencies that order this task relative to the rest of the plan, or confirm it is independent.",
+		))
+	}
+	return findings
+}
+
+func (gl graphLinter) singleChildParents() []PlanQualityFinding {
+	var findings []PlanQualityFinding
+	for _, id := range gl.ids {
+		if len(gl.g.Items[id].ChildIDs) != 1 {
+			continue
+		}
+		findings = append(findings, newFinding(
+			SeverityWarning,
+			RuleParentSingleChild,
+			id,
+			fieldChildIDs,
+			"Parent item has a single child.",
+			"Merge the child into the parent or split the work into several children.",
+		))
+	}
+	return findings
+}
+
+// deepNesting reports the items that first exceed MaxDepth, not every item
+// below them.
+func (gl graphLinter) deepNesting() []PlanQualityFinding {
+	if gl.rules.MaxDepth <= 0 {
+		return nil
+	}
+	var findings []PlanQualityFinding
+	for _, id := range gl.ids {
+		depth := len(gl.lineage(id))
+		if depth != gl.rules.MaxDepth+1 {
+			continue
+		}
+		findings = append(findings, newFinding(
+			SeverityWarning,
+			RuleNestingTooDeep,
+			id,
+			fieldParentID,
+			fmt.Sprintf("Item is nested %d levels deep (recommended maximum %d).", depth, gl.rules.MaxDepth),
+			"Flatten the hierarchy by moving this subtree up a level.",
+		))
+	}
+	return findings
+}
+
+func (gl graphLinter) undeclaredReferences() []PlanQualityFinding {
+	var findings []PlanQualityFinding
+	for _, id := range LeafTaskIDs(gl.g) {
+		it := gl.g.Items[id]
+		if strings.TrimSpace(it.Prompt) == "" {
+			continue
+		}
+		// Only the leaf's own transitive deps order it (ancestor deps are not
+		// checked by execution); ancestors and items depending on the leaf
+		// are not missing deps either.
+		declared := map[string]bool{}
+		for dep := range gl.reach(id) {
+			declared[dep] = true
+		}
+		for _, cur := range gl.lineage(id) {
+			declared[cur] = true
+		}
+		tokens := promptTokens(it.Prompt)
+		for _, otherID := range gl.ids {
+			if declared[otherID] || gl.reach(otherID)[id] {
+				continue
+			}
+			other := gl.g.Items[otherID]
+			if !referencesItem(it.Prompt, tokens, other) {
+				continue
+			}
+			findings = append(findings, newFinding(
+				SeverityWarning,
+				RuleUndeclaredDependencyReference,
+				id,
+				fieldPrompt,
+				fmt.Sprintf("Prompt references %q but the task does not depend on it.", otherID),
+				fmt.Sprintf("Add %q to deps if this task needs its output, or remove the reference.", otherID),
+			))
+		}
+	}
+	return findings
+}
+
+func (gl graphLinter) duplicateTitles() []PlanQualityFinding {
+	titles := make(map[string][]string, len(gl.ids))
+	for _, id := range gl.ids {
+		titles[id] = strings.Fields(NormalizeText(gl.g.Items[id].Title))
+	}
+
+	var findings []PlanQualityFinding
+	for j, id := range gl.ids {
+		if len(titles[id]) == 0 {
+			continue
+		}
+		for _, earlier := range gl.ids[:j] {
+			if !similarTitles(titles[earlier], titles[id]) {
+				continue
+			}
+			findings = append(findings, newFinding(
+				SeverityWarning,
+				RuleDuplicateTitle,
+				id,
+				fieldTitle,
+				fmt.Sprintf("Title duplicates the title of %q.", earlier),
+				"Give each item a distinct title, or merge the items if they describe the same work.",
+			))
+			break
+		}
+	}
+	return findings
+}
+
+func (gl graphLinter) uncoveredParentCriteria() []PlanQualityFinding {
+	var findings []PlanQualityFinding
+	for _, id := range gl.ids {
+		it := gl.g.Items[id]
+		criteria := nonEmptyCriteria(it.AcceptanceCriteria)
+		if len(it.ChildIDs) == 0 || len(criteria) == 0 {
+			continue
+		}
+		var childCriteria []map[string]bool
+		for _, desc := range gl.descendants(id) {
+			for _, criterion := range gl.g.Items[desc].AcceptanceCriteria {
+				if terms := coverageTerms(criterion); len(terms) > 0 {
+					childCriteria = append(childCriteria, terms)
+				}
+			}
+		}
+
+		uncovered := 0
+		for _, criterion := range criteria {
+			terms := coverageTerms(criterion)
+			if len(terms) == 0 {
+				continue
+			}
+			if !criterionCovered(terms, childCriteria) {
+				uncovered++
+			}
+		}
+		if uncovered == 0 {
+			continue
+		}
+		findings = append(findings, newFinding(
+			SeverityWarning,
+			RuleParentCriteriaUncovered,
+			id,
+			fieldAcceptanceCriteria,
+			fmt.Sprintf("%d of %d parent acceptance criteria are not covered by any child's acceptance criteria.", uncovered, len(criteria)),
+			"Add child acceptance criteria that verify each parent criterion, or drop parent criteria no child delivers.",
+		))
+	}
+	return findings
+}
+
+// lineage returns id followed by its ancestors, nearest first.
+func (gl graphLinter) lineage(id string) []string {
+	out := []string{id}
+	seen := map[string]bool{id: true}
+	for cur := gl.g.Items[id].ParentID; cur != nil && !seen[*cur]; cur = gl.g.Items[*cur].ParentID {
+		if _, ok := gl.g.Items[*cur]; !ok {
+			break
+		}
+		seen[*cur] = true
+		out = append(out, *cur)
+	}
+	return out
+}
+
+func (gl graphLinter) descendants(id string) []string {
+	var out []string
+	seen := map[string]bool{id: true}
+	var walk func(string)
+	walk = func(cur string) {
+		for _, child := range gl.g.Items[cur].ChildIDs {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			out = append(out, child)
+			walk(child)
+		}
+	}
+	walk(id)
+	return out
+}
+
+// promptTokens splits a prompt into ID-like tokens (letters, digits, '-',
+// '_' and '.'), trimming trailing punctuation.
+func promptTokens(prompt string) map[string]bool {
+	tokens := map[string]bool{}
+	for _, field := range strings.FieldsFunc(prompt, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.'
+	}) {
+		tokens[strings.TrimRight(field, ".-_")] = true
+	}
+	return tokens
+}
+
+func referencesItem(prompt string, tokens map[string]bool, other plan.WorkItem) bool {
+	if len(other.ID) >= minReferenceIDLength && tokens[other.ID] {
+		return true
+	}
+	if len(strings.Fields(NormalizeText(other.Title))) >= minReferenceTitleWords {
+		return ContainsAnyNormalizedPhrase(prompt, []string{other.Title})
+	}
+	return false
+}
+
+// similarTitles reports equal titles, or titles of at least three words whose
+// word sets overlap by nearDuplicateTitleSimilarity or more.
+func similarTitles(a, b []string) bool {
+	if strings.Join(a, " ") == strings.Join(b, " ") {
+		return true
+	}
+	if len(a) < 3 || len(b) < 3 {
+		return false
+	}
+	setA := map[string]bool{}
+	for _, w := range a {
+		setA[w] = true
+	}
+	setB := map[string]bool{}
+	for _, w := range b {
+		setB[w] = true
+	}
+	shared := 0
+	for w := range setA {
+		if setB[w] {
+			shared++
+		}
+	}
+	union := len(setA) + len(setB) - shared
+	return float64(shared)/float64(union) >= nearDuplicateTitleSimilarity
+}
+
+// coverageTerms returns the stemmed significant words of a criterion.
+func coverageTerms(criterion string) map[string]bool {
+	terms := map[string]bool{}
+	for _, w := range strings.Fields(NormalizeText(criterion)) {
+		if len(w) < 4 || coverageStopWords[w] {
+			continue
+		}
+		terms[stem(w)] = true
+	}
+	return terms
+}
+
+// criterionCovered reports whether some child criterion shares at least half
+// of the parent criterion's terms.
+func criterionCovered(terms map[string]bool, childCriteria []map[string]bool) bool {
+	need := (len(terms) + 1) / 2
+	for _, child := range childCriteria {
+		shared := 0
+		for term := range terms {
+			if child[term] {
+				shared++
+			}
+		}
+		if shared >= need {
+			return true
+		}
+	}
+	return false
+}
+
+func stem(w string) string {
+	for _, suffix := range []string{"ing", "ed", "es", "s"} {
+		if base, ok := strings.CutSuffix(w, suffix); ok && len(base) >= 4 {
+			return base
+		}
+	}
+	return w
+}
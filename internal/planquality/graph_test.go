@@ -0,0 +1,185 @@
+package planquality
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestLintGraphRedundantTransitiveDep(t *testing.T) {
+	a, b, c := validLeafTask("task-a"), validLeafTask("task-b"), validLeafTask("task-c")
+	b.Deps = []string{"task-a"}
+	c.Deps = []string{"task-b", "task-a"}
+
+	findings := graphFindings(Lint(graphWithItems(a, b, c)))
+	if got := findingTaskCodePairs(findings); !reflect.DeepEqual(got, []string{"task-c:" + RuleRedundantTransitiveDep}) {
+		t.Fatalf("findings = %v", got)
+	}
+	if findings[0].Field != fieldDeps {
+		t.Fatalf("field = %q, want %q", findings[0].Field, fieldDeps)
+	}
+}
+
+func TestLintGraphOrphanLeavesOnlyInLargePlans(t *testing.T) {
+	items := make([]plan.WorkItem, 0, 4)
+	for i := range 4 {
+		items = append(items, validLeafTask(fmt.Sprintf("task-%d", i)))
+	}
+	items[1].Deps = []string{"task-0"}
+
+	if findings := graphFindings(Lint(graphWithItems(items...))); len(findings) != 0 {
+		t.Fatalf("expected no findings below the leaf threshold, got %v", findingTaskCodePairs(findings))
+	}
+
+	rules := DefaultRules()
+	rules.OrphanLeafMinLeaves = 4
+	got := findingTaskCodePairs(graphFindings(LintWithRules(graphWithItems(items...), rules)))
+	want := []string{"task-2:" + RuleOrphanLeaf, "task-3:" + RuleOrphanLeaf}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestLintGraphIgnoresAncestorDeps(t *testing.T) {
+	schema := validLeafTask("schema")
+	schema.Title = "Define config schema types"
+	parent := validLeafTask("parent")
+	parent.ChildIDs = []string{"loader", "flags"}
+	parent.Deps = []string{"schema"}
+	loader := validLeafTask("loader")
+	loader.ParentID = ptr("parent")
+	loader.Prompt = "Implement the loader using the types from Define config schema types and run `go test ./...` to verify."
+	flags := validLeafTask("flags")
+	flags.ParentID = ptr("parent")
+
+	// The parent's dep on schema does not gate its children, so they are
+	// neither ordered nor declared to depend on it.
+	rules := DefaultRules()
+	rules.OrphanLeafMinLeaves = 1
+	got := findingTaskCodePairs(graphFindings(LintWithRules(graphWithItems(schema, parent, loader, flags), rules)))
+	want := []string{"flags:" + RuleOrphanLeaf, "loader:" + RuleOrphanLeaf, "loader:" + RuleUndeclaredDependencyReference}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestLintGraphHierarchyRules(t *testing.T) {
+	root := validLeafTask("root")
+	root.ChildIDs = []string{"mid"}
+	mid := validLeafTask("mid")
+	mid.ParentID = ptr("root")
+	mid.ChildIDs = []string{"leaf-1", "leaf-2"}
+	leaf1 := validLeafTask("leaf-1")
+	leaf1.ParentID = ptr("mid")
+	leaf2 := validLeafTask("leaf-2")
+	leaf2.ParentID = ptr("mid")
+
+	rules := DefaultRules()
+	rules.MaxDepth = 2
+	got := findingTaskCodePairs(graphFindings(LintWithRules(graphWithItems(root, mid, leaf1, leaf2), rules)))
+	want := []string{
+		"root:" + RuleParentSingleChild,
+		"leaf-1:" + RuleNestingTooDeep,
+		"leaf-2:" + RuleNestingTooDeep,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+}
+
+func TestLintGraphUndeclaredDependencyReference(t *testing.T) {
+	schema := validLeafTask("schema")
+	schema.Title = "Define config schema types"
+	api := validLeafTask("api")
+	api.Prompt = "Implement the loader using the types from Define config schema types and run `go test ./...` to verify."
+	cli := validLeafTask("cli-flags")
+	cli.Prompt = "Implement flags on top of the `api` loader and run `go test ./...` to verify."
+
+	got := findingTaskCodePairs(graphFindings(Lint(graphWithItems(schema, api, cli))))
+	if !reflect.DeepEqual(got, []string{"api:" + RuleUndeclaredDependencyReference}) {
+		t.Fatalf("findings = %v", got)
+	}
+
+	api.Deps = []string{"schema"}
+	if findings := graphFindings(Lint(graphWithItems(schema, api, cli))); len(findings) != 0 {
+		t.Fatalf("expected declared dependency to satisfy the rule, got %v", findingTaskCodePairs(findings))
+	}
+}
+
+func TestLintGraphDuplicateTitles(t *testing.T) {
+	a := validLeafTask("task-a")
+	a.Title = "Add retries to the HTTP client"
+	b := validLeafTask("task-b")
+	b.Title = "Add retries to HTTP client"
+	c := validLeafTask("task-c")
+	c.Title = "Document the HTTP client"
+
+	got := findingTaskCodePairs(graphFindings(Lint(graphWithItems(a, b, c))))
+	if !reflect.DeepEqual(got, []string{"task-b:" + RuleDuplicateTitle}) {
+		t.Fatalf("findings = %v", got)
+	}
+}
+
+func TestLintGraphParentCriteriaUncovered(t *testing.T) {
+	parent := validLeafTask("feature")
+	parent.ChildIDs = []string{"leaf-1", "leaf-2"}
+	parent.AcceptanceCriteria = []string{
+		"Lint returns blocking findings for placeholder descriptions.",
+		"Exports metrics to Prometheus dashboards.",
+	}
+	leaf1 := validLeafTask("leaf-1")
+	leaf1.ParentID = ptr("feature")
+	leaf2 := validLeafTask("leaf-2")
+	leaf2.ParentID = ptr("feature")
+
+	findings := graphFindings(Lint(graphWithItems(parent, leaf1, leaf2)))
+	finding, ok := findingByCode(findings, RuleParentCriteriaUncovered)
+	if !ok || finding.TaskID != "feature" || finding.Message != "1 of 2 parent acceptance criteria are not covered by any child's acceptance criteria." {
+		t.Fatalf("findings = %#v", findings)
+	}
+}
+
+func TestLintGraphFindingsHonorOverridesAndLintIgnore(t *testing.T) {
+	a := validLeafTask("task-a")
+	a.Title = "Same title"
+	b := validLeafTask("task-b")
+	b.Title = "Same title"
+
+	rules := DefaultRules()
+	rules.Severity = map[string]Severity{RuleDuplicateTitle: SeverityBlocking}
+	findings := LintWithRules(graphWithItems(a, b), rules)
+	if !HasBlocking(findings) {
+		t.Fatalf("expected duplicate title raised to blocking, got %#v", findings)
+	}
+
+	b.LintIgnore = []string{RuleDuplicateTitle}
+	if findings := graphFindings(LintWithRules(graphWithItems(a, b), rules)); len(findings) != 0 {
+		t.Fatalf("expected suppressed finding, got %v", findingTaskCodePairs(findings))
+	}
+}
+
+// graphFindings drops leaf text findings so tests only see graph rules.
+func graphFindings(findings []PlanQualityFinding) []PlanQualityFinding {
+	graphCodes := map[string]bool{
+		RuleRedundantTransitiveDep:        true,
+		RuleOrphanLeaf:                    true,
+		RuleParentSingleChild:             true,
+		RuleNestingTooDeep:                true,
+		RuleUndeclaredDependencyReference: true,
+		RuleDuplicateTitle:                true,
+		RuleParentCriteriaUncovered:       true,
+	}
+	out := make([]PlanQualityFinding, 0, len(findings))
+	for _, finding := range findings {
+		if graphCodes[finding.Code] {
+			out = append(out, finding)
+		}
+	}
+	return out
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -1,6 +1,7 @@
 package planquality
 
 import (
+	"regexp"
 	"strings"
 	"unicode"
 
@@ -26,12 +27,13 @@ const (
 	fieldAcceptanceCriteria = "acceptanceCriteria"
 	fieldPrompt             = "prompt"
 
-	descriptionTooThinWordThreshold      = 8
-	recommendedAcceptanceCriteriaMinimum = 2
+	DefaultDescriptionMinWords   = 8
+	DefaultMinAcceptanceCriteria = 2
 )
 
-var (
-	descriptionPlaceholderPhrases = []string{
+// defaultPhrases holds the built-in phrase lists keyed by their Phrases* name.
+var defaultPhrases = map[string][]string{
+	PhrasesDescriptionPlaceholder: {
 		"todo",
 		"tbd",
 		"to be determined",
@@ -39,9 +41,9 @@ var (
 		"implement feature",
 		"fill in later",
 		"coming soon",
-	}
+	},
 
-	promptPlaceholderPhrases = []string{
+	PhrasesPromptPlaceholder: {
 		"todo",
 		"tbd",
 		"do this",
@@ -49,9 +51,9 @@ var (
 		"implement this task",
 		"handle this",
 		"as needed",
-	}
+	},
 
-	implementationDirectionPhrases = []string{
+	PhrasesImplementationDirection: {
 		"implement",
 		"add",
 		"create",
@@ -66,9 +68,9 @@ var (
 		"write",
 		"build",
 		"document",
-	}
+	},
 
-	completionSignalPhrases = []string{
+	PhrasesCompletionSignal: {
 		"done when",
 		"acceptance criteria",
 		"verify",
@@ -78,9 +80,9 @@ var (
 		"passes",
 		"assert",
 		"confirm",
-	}
+	},
 
-	verificationHintPhrases = []string{
+	PhrasesVerificationHint: {
 		"go test",
 		"test",
 		"tests",
@@ -94,9 +96,9 @@ var (
 		"unit test",
 		"integration test",
 		"smoke test",
-	}
+	},
 
-	verifiableCriteriaPhrases = []string{
+	PhrasesVerifiableCriteria: {
 		"go test",
 		"unit test",
 		"integration test",
@@ -122,9 +124,9 @@ var (
 		"command",
 		"json",
 		"http",
-	}
+	},
 
-	vagueLanguagePhrases = []string{
+	PhrasesVagueLanguage: {
 		"improve",
 		"fix",
 		"handle",
@@ -134,9 +136,9 @@ var (
 		"cleanup",
 		"better",
 		"robust",
-	}
+	},
 
-	expectedBehaviorSignalPhrases = []string{
+	PhrasesExpectedBehavior: {
 		"returns",
 		"contains",
 		"creates",
@@ -158,31 +160,80 @@ var (
 		"exit code",
 		"output",
 		"error",
-	}
-)
+	},
+}
 
-// Lint applies deterministic leaf-task quality checks and returns findings in stable order.
+// Lint applies deterministic leaf-task quality checks with DefaultRules and
+// returns findings in stable order.
 func Lint(g plan.WorkGraph) []PlanQualityFinding {
-	findings := make([]PlanQualityFinding, 0)
+	return LintWithRules(g, DefaultRules())
+}
+
+// LintWithRules applies the built-in checks tuned by rules, then the custom
+// rules, to every leaf task, followed by the graph-level checks. Severity
+// overrides and each item's LintIgnore list are applied last.
+func LintWithRules(g plan.WorkGraph, rules Rules) []PlanQualityFinding {
+	l := newLinter(rules)
+	raw := make([]PlanQualityFinding, 0)
 	for _, taskID := range LeafTaskIDs(g) {
 		it, ok := g.Items[taskID]
 		if !ok {
 			continue
 		}
-		findings = append(findings, lintLeafTask(taskID, it)...)
+		raw = append(raw, l.lintLeafTask(taskID, it)...)
+		raw = append(raw, l.lintCustom(taskID, it)...)
+	}
+	raw = append(raw, l.lintGraph(g)...)
+
+	findings := make([]PlanQualityFinding, 0, len(raw))
+	for _, finding := range raw {
+		if severity, ok := rules.Severity[finding.Code]; ok {
+			if severity == SeverityOff {
+				continue
+			}
+			finding.Severity = severity
+		}
+		if ignoresRule(g.Items[finding.TaskID], finding.Code) {
+			continue
+		}
+		findings = append(findings, finding)
 	}
 	return findings
 }
 
-func lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
+// linter holds the thresholds and phrase lists (built-in plus configured
+// extras) for one Lint call.
+type linter struct {
+	rules   Rules
+	phrases map[string][]string
+}
+
+func newLinter(rules Rules) linter {
+	phrases := make(map[string][]string, len(defaultPhrases))
+	for name, list := range defaultPhrases {
+		phrases[name] = append(append([]string{}, list...), rules.Phrases[name]...)
+	}
+	return linter{rules: rules, phrases: phrases}
+}
+
+func ignoresRule(it plan.WorkItem, code string) bool {
+	for _, ignored := range it.LintIgnore {
+		if ignored == "*" || ignored == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (l linter) lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
 	findings := make([]PlanQualityFinding, 0, 8)
 
 	descriptionMissingOrPlaceholder := strings.TrimSpace(it.Description) == "" ||
-		ContainsAnyNormalizedPhrase(it.Description, descriptionPlaceholderPhrases)
+		ContainsAnyNormalizedPhrase(it.Description, l.phrases[PhrasesDescriptionPlaceholder])
 	criteria := nonEmptyCriteria(it.AcceptanceCriteria)
 	criteriaCount := len(criteria)
 	promptMissing := strings.TrimSpace(it.Prompt) == ""
-	promptNotActionable := !promptMissing && isPromptNotActionable(it.Prompt)
+	promptNotActionable := !promptMissing && l.isPromptNotActionable(it.Prompt)
 
 	// Blocking rule order is fixed to keep output deterministic and predictable.
 	if descriptionMissingOrPlaceholder {
@@ -207,7 +258,7 @@ func lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
 		))
 	}
 
-	if criteriaCount > 0 && allCriteriaNonVerifiable(criteria) {
+	if criteriaCount > 0 && l.allCriteriaNonVerifiable(criteria) {
 		findings = append(findings, newFinding(
 			SeverityBlocking,
 			RuleLeafAcceptanceCriteriaNonVerifiable,
@@ -240,7 +291,7 @@ func lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
 		))
 	}
 
-	if !descriptionMissingOrPlaceholder && wordCount(it.Description) < descriptionTooThinWordThreshold {
+	if !descriptionMissingOrPlaceholder && wordCount(it.Description) < l.rules.DescriptionMinWords {
 		findings = append(findings, newFinding(
 			SeverityWarning,
 			RuleLeafDescriptionTooThin,
@@ -251,7 +302,7 @@ func lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
 		))
 	}
 
-	if criteriaCount > 0 && criteriaCount < recommendedAcceptanceCriteriaMinimum {
+	if criteriaCount > 0 && criteriaCount < l.rules.MinAcceptanceCriteria {
 		findings = append(findings, newFinding(
 			SeverityWarning,
 			RuleLeafAcceptanceCriteriaLowCount,
@@ -262,7 +313,7 @@ func lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
 		))
 	}
 
-	if !promptMissing && !hasVerificationHint(it.Prompt) {
+	if !promptMissing && !ContainsAnyNormalizedPhrase(it.Prompt, l.phrases[PhrasesVerificationHint]) {
 		findings = append(findings, newFinding(
 			SeverityWarning,
 			RuleLeafPromptMissingVerificationHint,
@@ -273,7 +324,7 @@ func lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
 		))
 	}
 
-	if hasVagueLanguage(it) {
+	if l.hasVagueLanguage(it) {
 		findings = append(findings, newFinding(
 			SeverityWarning,
 			RuleVagueLanguageDetected,
@@ -287,6 +338,46 @@ func lintLeafTask(taskID string, it plan.WorkItem) []PlanQualityFinding {
 	return findings
 }
 
+// lintCustom evaluates the configured custom rules in declaration order.
+func (l linter) lintCustom(taskID string, it plan.WorkItem) []PlanQualityFinding {
+	var findings []PlanQualityFinding
+	for _, rule := range l.rules.Custom {
+		values := customRuleValues(it, rule.Field)
+		matched := func(re *regexp.Regexp) bool {
+			for _, v := range values {
+				if re.MatchString(v) {
+					return true
+				}
+			}
+			return false
+		}
+		if (rule.Match != nil && matched(rule.Match)) || (rule.Require != nil && !matched(rule.Require)) {
+			findings = append(findings, newFinding(rule.Severity, rule.Code, taskID, rule.Field, rule.Message, rule.Suggestion))
+		}
+	}
+	return findings
+}
+
+func customRuleValues(it plan.WorkItem, field string) []string {
+	switch field {
+	case CustomFieldTitle:
+		return []string{it.Title}
+	case fieldDescription:
+		return []string{it.Description}
+	case fieldPrompt:
+		return []string{it.Prompt}
+	case fieldAcceptanceCriteria:
+		return nonEmptyCriteria(it.AcceptanceCriteria)
+	case CustomFieldNotes:
+		if it.Notes == nil {
+			return []string{""}
+		}
+		return []string{*it.Notes}
+	default:
+		return nil
+	}
+}
+
 func newFinding(
 	severity Severity,
 	code string,
@@ -316,48 +407,44 @@ func nonEmptyCriteria(criteria []string) []string {
 	return out
 }
 
-func allCriteriaNonVerifiable(criteria []string) bool {
+func (l linter) allCriteriaNonVerifiable(criteria []string) bool {
 	for _, criterion := range criteria {
-		if criterionLooksVerifiable(criterion) {
+		if l.criterionLooksVerifiable(criterion) {
 			return false
 		}
 	}
 	return true
 }
 
-func criterionLooksVerifiable(value string) bool {
+func (l linter) criterionLooksVerifiable(value string) bool {
 	if strings.TrimSpace(value) == "" {
 		return false
 	}
 	if strings.Contains(value, "`") || containsDigit(value) {
 		return true
 	}
-	return ContainsAnyNormalizedPhrase(value, verifiableCriteriaPhrases)
+	return ContainsAnyNormalizedPhrase(value, l.phrases[PhrasesVerifiableCriteria])
 }
 
-func isPromptNotActionable(prompt string) bool {
-	if ContainsAnyNormalizedPhrase(prompt, promptPlaceholderPhrases) {
+func (l linter) isPromptNotActionable(prompt string) bool {
+	if ContainsAnyNormalizedPhrase(prompt, l.phrases[PhrasesPromptPlaceholder]) {
 		return true
 	}
-	hasDirection := ContainsAnyNormalizedPhrase(prompt, implementationDirectionPhrases)
-	hasCompletionSignal := ContainsAnyNormalizedPhrase(prompt, completionSignalPhrases)
+	hasDirection := ContainsAnyNormalizedPhrase(prompt, l.phrases[PhrasesImplementationDirection])
+	hasCompletionSignal := ContainsAnyNormalizedPhrase(prompt, l.phrases[PhrasesCompletionSignal])
 	return !hasDirection && !hasCompletionSignal
 }
 
-func hasVerificationHint(prompt string) bool {
-	return ContainsAnyNormalizedPhrase(prompt, verificationHintPhrases)
-}
-
-func hasVagueLanguage(it plan.WorkItem) bool {
+func (l linter) hasVagueLanguage(it plan.WorkItem) bool {
 	texts := make([]string, 0, len(it.AcceptanceCriteria)+2)
 	texts = append(texts, it.Description, it.Prompt)
 	texts = append(texts, it.AcceptanceCriteria...)
 
 	for _, text := range texts {
-		if !ContainsAnyNormalizedPhrase(text, vagueLanguagePhrases) {
+		if !ContainsAnyNormalizedPhrase(text, l.phrases[PhrasesVagueLanguage]) {
 			continue
 		}
-		if hasExpectedBehaviorSignal(text) {
+		if l.hasExpectedBehaviorSignal(text) {
 			continue
 		}
 		return true
@@ -366,11 +453,11 @@ func hasVagueLanguage(it plan.WorkItem) bool {
 	return false
 }
 
-func hasExpectedBehaviorSignal(value string) bool {
+func (l linter) hasExpectedBehaviorSignal(value string) bool {
 	if strings.Contains(value, "`") || containsDigit(value) {
 		return true
 	}
-	return ContainsAnyNormalizedPhrase(value, expectedBehaviorSignalPhrases)
+	return ContainsAnyNormalizedPhrase(value, l.phrases[PhrasesExpectedBehavior])
 }
 
 func wordCount(value string) int {
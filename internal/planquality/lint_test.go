@@ -213,9 +213,14 @@ func TestLintSkipsNonLeafTasks(t *testing.T) {
 	leaf := validLeafTask("leaf")
 
 	findings := Lint(graphWithItems(parent, leaf))
-	if len(findings) != 0 {
+	graph := graphFindings(findings)
+	if len(findings) != len(graph) {
 		t.Fatalf("expected no findings, got %v", findingCodes(findings))
 	}
+	// The graph-level rule still reports the single-child parent.
+	if len(graph) != 1 || graph[0].TaskID != "parent" || graph[0].Code != RuleParentSingleChild {
+		t.Fatalf("expected %q on parent, got %v", RuleParentSingleChild, findingTaskCodePairs(graph))
+	}
 }
 
 func TestLintDeterministicOrderByTaskIDAndRuleOrder(t *testing.T) {
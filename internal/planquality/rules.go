@@ -0,0 +1,169 @@
+package planquality
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// Phrase list names accepted in Rules.Phrases.
+const (
+	PhrasesDescriptionPlaceholder  = "descriptionPlaceholder"
+	PhrasesPromptPlaceholder       = "promptPlaceholder"
+	PhrasesImplementationDirection = "implementationDirection"
+	PhrasesCompletionSignal        = "completionSignal"
+	PhrasesVerificationHint        = "verificationHint"
+	PhrasesVerifiableCriteria      = "verifiableCriteria"
+	PhrasesVagueLanguage           = "vagueLanguage"
+	PhrasesExpectedBehavior        = "expectedBehavior"
+)
+
+// Fields a custom rule can inspect besides description, prompt and
+// acceptanceCriteria.
+const (
+	CustomFieldTitle = fieldTitle
+	CustomFieldNotes = "notes"
+)
+
+// Rules tunes LintWithRules. The zero value is not useful; start from
+// DefaultRules.
+type Rules struct {
+	// Severity overrides the severity of findings by rule code. SeverityOff
+	// drops the rule's findings entirely.
+	Severity map[string]Severity
+	// DescriptionMinWords is the word count below which a leaf description is
+	// reported as too thin.
+	DescriptionMinWords int
+	// MinAcceptanceCriteria is the recommended number of acceptance criteria
+	// per leaf task.
+	MinAcceptanceCriteria int
+	// MaxDepth is the deepest recommended nesting level (top-level items are
+	// level 1). Zero disables the check.
+	MaxDepth int
+	// OrphanLeafMinLeaves is the number of leaf tasks from which leaves
+	// without any dependency edge are reported. Zero disables the check.
+	OrphanLeafMinLeaves int
+	// Phrases extends the built-in phrase lists, keyed by Phrases* name.
+	Phrases map[string][]string
+	// Custom rules run after the built-in rules, in order.
+	Custom []CustomRule
+}
+
+// CustomRule reports a finding when a leaf task field matches Match or does
+// not match Require. For acceptanceCriteria, Match is checked against every
+// criterion and Require is satisfied by any one criterion.
+type CustomRule struct {
+	Code       string
+	Severity   Severity
+	Field      string
+	Match      *regexp.Regexp
+	Require    *regexp.Regexp
+	Message    string
+	Suggestion string
+}
+
+// DefaultRules returns the built-in thresholds with no overrides.
+func DefaultRules() Rules {
+	return Rules{
+		DescriptionMinWords:   DefaultDescriptionMinWords,
+		MinAcceptanceCriteria: DefaultMinAcceptanceCriteria,
+		MaxDepth:              DefaultMaxDepth,
+		OrphanLeafMinLeaves:   DefaultOrphanLeafMinLeaves,
+	}
+}
+
+// BuiltinRuleCodes returns the codes of the built-in rules.
+func BuiltinRuleCodes() []string {
+	return []string{
+		RuleLeafDescriptionMissingOrPlaceholder,
+		RuleLeafAcceptanceCriteriaMissing,
+		RuleLeafAcceptanceCriteriaNonVerifiable,
+		RuleLeafPromptMissing,
+		RuleLeafPromptNotActionable,
+		RuleLeafDescriptionTooThin,
+		RuleLeafAcceptanceCriteriaLowCount,
+		RuleLeafPromptMissingVerificationHint,
+		RuleVagueLanguageDetected,
+		RuleRedundantTransitiveDep,
+		RuleOrphanLeaf,
+		RuleParentSingleChild,
+		RuleNestingTooDeep,
+		RuleUndeclaredDependencyReference,
+		RuleDuplicateTitle,
+		RuleParentCriteriaUncovered,
+	}
+}
+
+// PhraseListNames returns the names accepted in Rules.Phrases, sorted.
+func PhraseListNames() []string {
+	names := make([]string, 0, len(defaultPhrases))
+	for name := range defaultPhrases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsCustomRuleField reports whether field can be inspected by a CustomRule.
+func IsCustomRuleField(field string) bool {
+	switch field {
+	case fieldTitle, fieldDescription, fieldPrompt, fieldAcceptanceCriteria, CustomFieldNotes:
+		return true
+	default:
+		return false
+	}
+}
+
+// Validate reports the first inconsistency in r.
+func (r Rules) Validate() error {
+	for code, severity := range r.Severity {
+		switch severity {
+		case SeverityBlocking, SeverityWarning, SeverityOff:
+		default:
+			return fmt.Errorf("rule %q: invalid severity %q (use blocking, warning or off)", code, severity)
+		}
+	}
+	if r.DescriptionMinWords < 0 {
+		return fmt.Errorf("descriptionMinWords must not be negative")
+	}
+	if r.MinAcceptanceCriteria < 0 {
+		return fmt.Errorf("minAcceptanceCriteria must not be negative")
+	}
+	if r.MaxDepth < 0 {
+		return fmt.Errorf("maxDepth must not be negative")
+	}
+	if r.OrphanLeafMinLeaves < 0 {
+		return fmt.Errorf("orphanLeafMinLeaves must not be negative")
+	}
+	for name := range r.Phrases {
+		if _, ok := defaultPhrases[name]; !ok {
+			return fmt.Errorf("unknown phrase list %q", name)
+		}
+	}
+	seen := map[string]bool{}
+	for _, code := range BuiltinRuleCodes() {
+		seen[code] = true
+	}
+	for i, rule := range r.Custom {
+		if rule.Code == "" {
+			return fmt.Errorf("custom rule %d: code required", i)
+		}
+		if seen[rule.Code] {
+			return fmt.Errorf("custom rule %q: duplicate code", rule.Code)
+		}
+		seen[rule.Code] = true
+		if rule.Severity != SeverityBlocking && rule.Severity != SeverityWarning {
+			return fmt.Errorf("custom rule %q: invalid severity %q (use blocking or warning)", rule.Code, rule.Severity)
+		}
+		if !IsCustomRuleField(rule.Field) {
+			return fmt.Errorf("custom rule %q: unknown field %q", rule.Code, rule.Field)
+		}
+		if rule.Match == nil && rule.Require == nil {
+			return fmt.Errorf("custom rule %q: match or require required", rule.Code)
+		}
+		if rule.Message == "" {
+			return fmt.Errorf("custom rule %q: message required", rule.Code)
+		}
+	}
+	return nil
+}
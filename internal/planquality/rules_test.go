@@ -0,0 +1,127 @@
+package planquality
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestLintWithRulesSeverityOverrides(t *testing.T) {
+	leaf := validLeafTask("leaf")
+	leaf.Prompt = ""
+	leaf.Description = "Add retries."
+
+	rules := DefaultRules()
+	rules.Severity = map[string]Severity{
+		RuleLeafPromptMissing:      SeverityWarning,
+		RuleLeafDescriptionTooThin: SeverityOff,
+	}
+	findings := LintWithRules(graphWithItems(leaf), rules)
+
+	finding, ok := findingByCode(findings, RuleLeafPromptMissing)
+	if !ok || finding.Severity != SeverityWarning {
+		t.Fatalf("expected %q downgraded to warning, got %#v", RuleLeafPromptMissing, findings)
+	}
+	if hasFindingCode(findings, RuleLeafDescriptionTooThin) {
+		t.Fatalf("expected %q to be off, got %v", RuleLeafDescriptionTooThin, findingCodes(findings))
+	}
+	if HasBlocking(findings) {
+		t.Fatalf("expected no blocking findings, got %#v", findings)
+	}
+}
+
+func TestLintWithRulesThresholdsAndPhrases(t *testing.T) {
+	leaf := validLeafTask("leaf")
+	leaf.Description = "Somehow make retries work for the HTTP client layer."
+
+	if codes := findingCodes(Lint(graphWithItems(leaf))); len(codes) != 0 {
+		t.Fatalf("expected default rules to pass, got %v", codes)
+	}
+
+	rules := DefaultRules()
+	rules.DescriptionMinWords = 20
+	rules.MinAcceptanceCriteria = 3
+	rules.Phrases = map[string][]string{PhrasesVagueLanguage: {"somehow"}}
+	findings := LintWithRules(graphWithItems(leaf), rules)
+	for _, code := range []string{RuleLeafDescriptionTooThin, RuleLeafAcceptanceCriteriaLowCount, RuleVagueLanguageDetected} {
+		if !hasFindingCode(findings, code) {
+			t.Fatalf("expected %q, got %v", code, findingCodes(findings))
+		}
+	}
+}
+
+func TestLintWithRulesCustomRules(t *testing.T) {
+	leaf := validLeafTask("leaf")
+	rules := DefaultRules()
+	rules.Custom = []CustomRule{
+		{
+			Code:     "needs_ticket",
+			Severity: SeverityBlocking,
+			Field:    fieldDescription,
+			Require:  regexp.MustCompile(`[A-Z]+-\d+`),
+			Message:  "Description must reference a ticket.",
+		},
+		{
+			Code:     "no_internal_paths",
+			Severity: SeverityWarning,
+			Field:    fieldAcceptanceCriteria,
+			Match:    regexp.MustCompile(`internal/`),
+			Message:  "Criteria should not name internal paths.",
+		},
+	}
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+
+	findings := LintWithRules(graphWithItems(leaf), rules)
+	if got := findingCodes(findings); len(got) != 2 || got[0] != "needs_ticket" || got[1] != "no_internal_paths" {
+		t.Fatalf("findings = %v, want custom rules in order", got)
+	}
+	if !HasBlocking(findings) {
+		t.Fatalf("expected blocking custom finding")
+	}
+
+	leaf.Description += " Tracks BB-42."
+	if hasFindingCode(LintWithRules(graphWithItems(leaf), rules), "needs_ticket") {
+		t.Fatalf("expected require rule to pass once the description matches")
+	}
+}
+
+func TestLintWithRulesHonorsLintIgnore(t *testing.T) {
+	leaf := validLeafTask("leaf")
+	leaf.Prompt = ""
+	leaf.Description = "Add retries."
+	leaf.LintIgnore = []string{RuleLeafPromptMissing}
+
+	findings := Lint(graphWithItems(leaf))
+	if hasFindingCode(findings, RuleLeafPromptMissing) {
+		t.Fatalf("expected %q suppressed, got %v", RuleLeafPromptMissing, findingCodes(findings))
+	}
+	if !hasFindingCode(findings, RuleLeafDescriptionTooThin) {
+		t.Fatalf("expected other rules to still apply, got %v", findingCodes(findings))
+	}
+
+	leaf.LintIgnore = []string{"*"}
+	if findings := Lint(graphWithItems(leaf)); len(findings) != 0 {
+		t.Fatalf("expected all findings suppressed, got %v", findingCodes(findings))
+	}
+}
+
+func TestRulesValidate(t *testing.T) {
+	cases := map[string]Rules{
+		"severity":  {Severity: map[string]Severity{RuleLeafPromptMissing: "fatal"}},
+		"threshold": {DescriptionMinWords: -1},
+		"phrases":   {Phrases: map[string][]string{"unknown": {"x"}}},
+		"duplicate": {Custom: []CustomRule{{Code: RuleLeafPromptMissing, Severity: SeverityWarning, Field: fieldPrompt, Match: regexp.MustCompile("x"), Message: "m"}}},
+		"field":     {Custom: []CustomRule{{Code: "c", Severity: SeverityWarning, Field: "deps", Match: regexp.MustCompile("x"), Message: "m"}}},
+		"pattern":   {Custom: []CustomRule{{Code: "c", Severity: SeverityWarning, Field: fieldPrompt, Message: "m"}}},
+		"off":       {Custom: []CustomRule{{Code: "c", Severity: SeverityOff, Field: fieldPrompt, Match: regexp.MustCompile("x"), Message: "m"}}},
+	}
+	for name, rules := range cases {
+		if err := rules.Validate(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+	if err := DefaultRules().Validate(); err != nil {
+		t.Fatalf("DefaultRules().Validate() = %v", err)
+	}
+}
@@ -11,6 +11,10 @@ var fieldSortOrder = map[string]int{
 	fieldAcceptanceCriteria: 1,
 	fieldPrompt:             2,
 	fieldTask:               3,
+	fieldTitle:              4,
+	fieldDeps:               5,
+	fieldChildIDs:           6,
+	fieldParentID:           7,
 }
 
 // HasBlocking reports whether findings contain at least one blocking issue.
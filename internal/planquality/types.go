@@ -6,6 +6,8 @@ type Severity string
 const (
 	SeverityBlocking Severity = "blocking"
 	SeverityWarning  Severity = "warning"
+	// SeverityOff disables a rule when used as a severity override.
+	SeverityOff Severity = "off"
 )
 
 // PlanQualityFinding captures one deterministic quality issue for a task field.
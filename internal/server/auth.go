@@ -0,0 +1,107 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"mime"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// NewToken returns a random bearer token for one server session.
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// guard rejects requests that did not come from a client holding the session
+// token through the address the server is bound to:
+//
+//   - Host must name the bound address (DNS rebinding).
+//   - Origin, when sent, must be the server's own origin (cross-site pages).
+//   - Authorization must carry the bearer token. EventSource cannot set
+//     headers, so /api/events also accepts ?access_token=.
+//   - POST bodies must be application/json, which browsers cannot send
+//     cross-site without a CORS preflight the server never answers.
+func (s *Server) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeError(w, errorf(http.StatusForbidden, "host %q is not the server address", r.Host))
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !strings.EqualFold(origin, "http://"+r.Host) {
+			writeError(w, errorf(http.StatusForbidden, "cross-origin requests are not allowed"))
+			return
+		}
+		if !s.validToken(requestToken(r)) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, errorf(http.StatusUnauthorized, "missing or invalid token"))
+			return
+		}
+		if r.Method == http.MethodPost {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				writeError(w, errorf(http.StatusUnsupportedMediaType, "Content-Type must be application/json"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		scheme, token, ok := strings.Cut(auth, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+	if r.URL.Path == "/api/events" {
+		return r.URL.Query().Get("access_token")
+	}
+	return ""
+}
+
+// validToken compares in constant time. A server without a token rejects
+// every request.
+func (s *Server) validToken(token string) bool {
+	if s.cfg.Token == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.Token)) == 1
+}
+
+// allowedHost reports whether a Host header names the bound address. A
+// loopback bind also accepts localhost and other loopback IPs; a bind to all
+// interfaces accepts any host on the right port.
+func (s *Server) allowedHost(host string) bool {
+	bindHost, bindPort, err := net.SplitHostPort(s.cfg.Addr)
+	if err != nil {
+		return false
+	}
+	reqHost, reqPort, err := net.SplitHostPort(host)
+	if err != nil || reqPort != bindPort {
+		return false
+	}
+	bindIP := net.ParseIP(bindHost)
+	if bindHost == "" || (bindIP != nil && bindIP.IsUnspecified()) {
+		return true
+	}
+	if strings.EqualFold(reqHost, bindHost) {
+		return true
+	}
+	if bindIP != nil && bindIP.IsLoopback() {
+		if strings.EqualFold(reqHost, "localhost") {
+			return true
+		}
+		reqIP := net.ParseIP(reqHost)
+		return reqIP != nil && reqIP.IsLoopback()
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// event is one server-sent event.
+type event struct {
+	Type string
+	Data []byte
+}
+
+// broker fans events out to /api/events subscribers. Slow subscribers drop
+// events rather than stall execution.
+type broker struct {
+	mu   sync.Mutex
+	subs map[chan event]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{subs: map[chan event]struct{}{}}
+}
+
+func (b *broker) subscribe() chan event {
+	ch := make(chan event, 256)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *broker) unsubscribe(ch chan event) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+}
+
+func (b *broker) publish(typ string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- event{Type: typ, Data: data}:
+		default:
+		}
+	}
+}
+
+// stream returns a writer that publishes agent output as "output" events.
+func (b *broker) stream(name string) io.Writer {
+	return streamWriter{b: b, name: name}
+}
+
+type streamWriter struct {
+	b    *broker
+	name string
+}
+
+func (w streamWriter) Write(p []byte) (int, error) {
+	w.b.publish("output", map[string]string{"stream": w.name, "text": string(p)})
+	return len(p), nil
+}
+
+const sseKeepAlive = 15 * time.Second
+
+// handleEvents streams events as text/event-stream. The current status is sent
+// first so clients can render without a separate request.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, errorf(http.StatusInternalServerError, "streaming unsupported"))
+		return
+	}
+	ch := s.events.subscribe()
+	defer s.events.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if data, err := json.Marshal(s.status()); err == nil {
+		writeEvent(w, event{Type: "status", Data: data})
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ev := <-ch:
+			writeEvent(w, ev)
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+func writeEvent(w io.Writer, ev event) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.Type, ev.Data)
+}
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/execution"
+)
+
+// jobState is reported by /api/status and the "status" event.
+type jobState struct {
+	Running   bool                          `json:"running"`
+	Action    string                        `json:"action,omitempty"`
+	TaskID    string                        `json:"taskId,omitempty"`
+	StartedAt *time.Time                    `json:"startedAt,omitempty"`
+	Stage     execution.ExecutionStageState `json:"stage"`
+	Last      *jobResult                    `json:"last,omitempty"`
+}
+
+// jobResult describes how the last job ended.
+type jobResult struct {
+	Action     string                      `json:"action"`
+	Reason     execution.ExecuteStopReason `json:"reason,omitempty"`
+	TaskID     string                      `json:"taskId,omitempty"`
+	RunID      string                      `json:"runId,omitempty"`
+	RunStatus  execution.RunStatus         `json:"runStatus,omitempty"`
+	Decision   execution.DecisionState     `json:"decision,omitempty"`
+	Error      string                      `json:"error,omitempty"`
+	FinishedAt time.Time                   `json:"finishedAt"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+func (s *Server) status() jobState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.job
+}
+
+// startJob runs fn in the background unless another job is running.
+func (s *Server) startJob(action, taskID string, fn func(ctx context.Context, rt agent.Runtime) jobResult) error {
+	rt, err := s.cfg.NewRuntime()
+	if err != nil {
+		return errorf(http.StatusInternalServerError, "%v", err)
+	}
+
+	s.mu.Lock()
+	if s.job.Running {
+		s.mu.Unlock()
+		return errorf(http.StatusConflict, "%s already running", s.job.Action)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	now := time.Now().UTC()
+	s.job.Running = true
+	s.job.Action = action
+	s.job.TaskID = taskID
+	s.job.StartedAt = &now
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	done := s.done
+	state := s.job
+	s.mu.Unlock()
+	s.events.publish("status", state)
+
+	go func() {
+		defer close(done)
+		defer cancel()
+		res := fn(ctx, rt)
+		res.Action = action
+		res.FinishedAt = time.Now().UTC()
+
+		s.mu.Lock()
+		s.job = jobState{
+			Stage: execution.ExecutionStageState{Stage: execution.ExecutionStageIdle},
+			Last:  &res,
+		}
+		s.cancel = nil
+		state := s.job
+		s.mu.Unlock()
+		s.events.publish("done", res)
+		s.events.publish("status", state)
+		s.events.publish("plan", map[string]string{"reason": action})
+	}()
+	return nil
+}
+
+func (s *Server) setStage(state execution.ExecutionStageState) {
+	s.mu.Lock()
+	s.job.Stage = state
+	if state.TaskID != "" {
+		s.job.TaskID = state.TaskID
+	}
+	s.mu.Unlock()
+	s.events.publish("stage", state)
+}
+
+func (s *Server) onTaskStart(taskID string) {
+	s.events.publish("task_start", map[string]string{"taskId": taskID})
+}
+
+func (s *Server) onTaskFinish(taskID string, record execution.RunRecord, execErr error) {
+	ev := map[string]string{"taskId": taskID, "runId": record.ID, "status": string(record.Status)}
+	if execErr != nil {
+		ev["error"] = execErr.Error()
+	}
+	s.events.publish("task_finish", ev)
+	s.events.publish("plan", map[string]string{"reason": "task_finish", "taskId": taskID})
+}
+
+func (s *Server) controller(rt agent.Runtime) execution.ExecutionController {
+	return execution.ExecutionController{
+		PlanPath:              s.cfg.PlanPath,
+		Runtime:               rt,
+		StopAfterEachTask:     s.cfg.StopAfterEachTask,
+		ParentReviewEnabled:   s.cfg.ParentReviewEnabled,
+		ContinueOnWaitingUser: s.cfg.AutoContinue,
+		Hooks:                 s.cfg.Hooks,
+		Webhooks:              s.cfg.Webhooks,
+		StreamStdout:          s.events.stream("stdout"),
+		StreamStderr:          s.events.stream("stderr"),
+		OnStateChange:         s.setStage,
+		OnParentReview: func(run execution.RunRecord) {
+			s.events.publish("parent_review", run)
+		},
+		OnTaskStart:  s.onTaskStart,
+		OnTaskFinish: s.onTaskFinish,
+	}
+}
+
+func executeResult(res execution.ExecuteResult, err error) jobResult {
+	out := jobResult{Reason: res.Reason, TaskID: res.TaskID}
+	if res.Run != nil {
+		out.RunID = res.Run.ID
+		out.RunStatus = res.Run.Status
+	}
+	if err == nil {
+		err = res.Err
+	}
+	if err != nil {
+		out.Error = err.Error()
+	}
+	return out
+}
+
+func (s *Server) handleExecute(w http.ResponseWriter, r *http.Request) {
+	if err := decodeBody(r, &struct{}{}); err != nil {
+		writeError(w, err)
+		return
+	}
+	if _, err := s.loadPlan(); err != nil {
+		writeError(w, err)
+		return
+	}
+	err := s.startJob("execute", "", func(ctx context.Context, rt agent.Runtime) jobResult {
+		return executeResult(s.controller(rt).Execute(ctx))
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.status())
+}
+
+// handleResume resumes a task with answers to its open questions, explicit
+// feedback, or (with neither, i.e. {}) its pending parent-review feedback.
+func (s *Server) handleResume(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Answers  []agent.Answer `json:"answers"`
+		Feedback string         `json:"feedback"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	_, it, err := s.loadItem(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	resolved, err := execution.ResolveResumeFeedbackSource(s.baseDir(), it.ID, body.Feedback, body.Answers)
+	if err != nil {
+		writeError(w, errorf(http.StatusBadRequest, "%v", err))
+		return
+	}
+	if !resolved.UsesFeedback() && len(body.Answers) == 0 {
+		writeError(w, errorf(http.StatusBadRequest, "resume %s requires answers or feedback (no pending parent-review feedback)", it.ID))
+		return
+	}
+
+	taskID := it.ID
+	err = s.startJob("resume", taskID, func(ctx context.Context, rt agent.Runtime) jobResult {
+		s.onTaskStart(taskID)
+		record, err := execution.RunResume(ctx, execution.ResumeConfig{
+			PlanPath:     s.cfg.PlanPath,
+			TaskID:       taskID,
+			Answers:      body.Answers,
+			Feedback:     body.Feedback,
+			Runtime:      rt,
+			Hooks:        s.cfg.Hooks,
+			Webhooks:     s.cfg.Webhooks,
+			StreamStdout: s.events.stream("stdout"),
+			StreamStderr: s.events.stream("stderr"),
+		})
+		s.onTaskFinish(taskID, record, err)
+		res := jobResult{TaskID: taskID, RunID: record.ID, RunStatus: record.Status}
+		if err != nil {
+			res.Error = err.Error()
+			return res
+		}
+		if s.cfg.AutoContinue && len(body.Answers) > 0 && ctx.Err() == nil {
+			return executeResult(s.controller(rt).Execute(ctx))
+		}
+		return res
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.status())
+}
+
+// handleDecision resolves a review checkpoint (execution.stopAfterEachTask).
+// Approving with continue runs execution onward as part of the same job.
+func (s *Server) handleDecision(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TaskID   string `json:"taskId"`
+		RunID    string `json:"runId"`
+		Action   string `json:"action"`
+		Feedback string `json:"feedback"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	action := execution.DecisionState(strings.TrimSpace(body.Action))
+	switch action {
+	case execution.DecisionStateApprovedContinue, execution.DecisionStateApprovedQuit,
+		execution.DecisionStateChangesRequested, execution.DecisionStateRejected:
+	default:
+		writeError(w, errorf(http.StatusBadRequest, "invalid action %q", body.Action))
+		return
+	}
+	if strings.TrimSpace(body.TaskID) == "" || strings.TrimSpace(body.RunID) == "" {
+		writeError(w, errorf(http.StatusBadRequest, "taskId and runId required"))
+		return
+	}
+
+	err := s.startJob("decision", body.TaskID, func(ctx context.Context, rt agent.Runtime) jobResult {
+		result, err := s.controller(rt).ResolveDecision(ctx, execution.DecisionRequest{
+			TaskID:   body.TaskID,
+			RunID:    body.RunID,
+			Action:   action,
+			Feedback: body.Feedback,
+		})
+		res := jobResult{TaskID: body.TaskID, RunID: result.Run.ID, RunStatus: result.Run.Status, Decision: result.Action}
+		if result.Next != nil {
+			next := executeResult(*result.Next, err)
+			next.Decision = result.Action
+			return next
+		}
+		if err != nil {
+			res.Error = err.Error()
+		}
+		return res
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.status())
+}
+
+func (s *Server) handleCancel(w http.ResponseWriter, r *http.Request) {
+	if err := decodeBody(r, &struct{}{}); err != nil {
+		writeError(w, err)
+		return
+	}
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel == nil {
+		writeError(w, errorf(http.StatusConflict, "nothing running"))
+		return
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+	case <-r.Context().Done():
+	}
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+// Shutdown cancels any running job and waits for it to stop.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for running job: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,426 @@
+// Package server exposes the plan, run records and execution actions over a
+// local HTTP/JSON API with server-sent events for live output.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/webhook"
+)
+
+// Config configures a Server.
+type Config struct {
+	PlanPath string
+	// Addr is the address the server is bound to; requests must name it in
+	// their Host header.
+	Addr string
+	// Token is the bearer token every request must carry. An empty token
+	// rejects all requests.
+	Token string
+	// NewRuntime builds the agent runtime for execute/resume; defaults to
+	// agent.NewRuntimeForPlan(PlanPath).
+	NewRuntime func() (agent.Runtime, error)
+	// StopAfterEachTask and ParentReviewEnabled mirror the execution config.
+	StopAfterEachTask   bool
+	ParentReviewEnabled bool
+	// AutoContinue keeps execute running past waiting tasks and continues
+	// execution after a resume with answers.
+	AutoContinue bool
+	// Hooks are the lifecycle hooks run around each task.
+	Hooks execution.Hooks
+	// Webhooks receives execution event notifications; nil disables them.
+	Webhooks *webhook.Dispatcher
+}
+
+// Server serves the API. Only one execute/resume/decision job runs at a time.
+type Server struct {
+	cfg    Config
+	events *broker
+
+	mu  sync.Mutex
+	job jobState
+	// cancel stops the running job, if any.
+	cancel func()
+	// done is closed when the running job finishes.
+	done chan struct{}
+}
+
+func New(cfg Config) *Server {
+	if cfg.NewRuntime == nil {
+		planPath := cfg.PlanPath
+		cfg.NewRuntime = func() (agent.Runtime, error) { return agent.NewRuntimeForPlan(planPath) }
+	}
+	return &Server{
+		cfg:    cfg,
+		events: newBroker(),
+		job:    jobState{Stage: execution.ExecutionStageState{Stage: execution.ExecutionStageIdle}},
+	}
+}
+
+func (s *Server) baseDir() string {
+	return filepath.Dir(s.cfg.PlanPath)
+}
+
+// Handler returns the API routes, behind the token, Host/Origin and
+// content-type checks of guard.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/plan", s.handlePlan)
+	mux.HandleFunc("GET /api/items", s.handleItems)
+	mux.HandleFunc("GET /api/items/{id}", s.handleItem)
+	mux.HandleFunc("POST /api/items/{id}/status", s.handleSetStatus)
+	mux.HandleFunc("GET /api/items/{id}/runs", s.handleRuns)
+	mux.HandleFunc("GET /api/items/{id}/runs/{runId}", s.handleRun)
+	mux.HandleFunc("GET /api/items/{id}/questions", s.handleItemQuestions)
+	mux.HandleFunc("POST /api/items/{id}/resume", s.handleResume)
+	mux.HandleFunc("GET /api/questions", s.handleQuestions)
+	mux.HandleFunc("GET /api/feedback", s.handleFeedback)
+	mux.HandleFunc("POST /api/execute", s.handleExecute)
+	mux.HandleFunc("POST /api/decisions", s.handleDecision)
+	mux.HandleFunc("POST /api/cancel", s.handleCancel)
+	mux.HandleFunc("GET /api/status", s.handleStatus)
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+	return s.guard(mux)
+}
+
+// Item is an item with its derived readiness, as returned by /api/items.
+type Item struct {
+	plan.WorkItem
+	Readiness plan.Readiness `json:"readiness"`
+}
+
+type itemDetail struct {
+	Item       Item     `json:"item"`
+	Dependents []string `json:"dependents"`
+}
+
+type taskQuestions struct {
+	TaskID    string           `json:"taskId"`
+	RunID     string           `json:"runId"`
+	Questions []agent.Question `json:"questions"`
+}
+
+type taskFeedback struct {
+	TaskID  string                                `json:"taskId"`
+	Pending execution.PendingParentReviewFeedback `json:"pending"`
+}
+
+// apiError carries an HTTP status for writeError.
+type apiError struct {
+	status int
+	msg    string
+}
+
+func (e apiError) Error() string { return e.msg }
+
+func errorf(status int, format string, args ...any) error {
+	return apiError{status: status, msg: fmt.Sprintf(format, args...)}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var ae apiError
+	if errors.As(err, &ae) {
+		status = ae.status
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// decodeBody decodes a JSON request body into v. The body is required; send
+// {} for actions without parameters.
+func decodeBody(r *http.Request, v any) error {
+	if r.Body == nil {
+		return errorf(http.StatusBadRequest, "request body required")
+	}
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errorf(http.StatusBadRequest, "request body required")
+		}
+		return errorf(http.StatusBadRequest, "invalid request body: %v", err)
+	}
+	return nil
+}
+
+func (s *Server) loadPlan() (plan.WorkGraph, error) {
+	g, err := plan.Load(s.cfg.PlanPath)
+	if err != nil {
+		if errors.Is(err, plan.ErrPlanNotFound) {
+			return plan.WorkGraph{}, errorf(http.StatusNotFound, "plan file not found: %s", s.cfg.PlanPath)
+		}
+		return plan.WorkGraph{}, err
+	}
+	if errs := plan.Validate(g); len(errs) != 0 {
+		return plan.WorkGraph{}, errorf(http.StatusUnprocessableEntity, "plan is invalid (run `blackbird validate`): %s", s.cfg.PlanPath)
+	}
+	return g, nil
+}
+
+func (s *Server) loadItem(id string) (plan.WorkGraph, plan.WorkItem, error) {
+	g, err := s.loadPlan()
+	if err != nil {
+		return plan.WorkGraph{}, plan.WorkItem{}, err
+	}
+	it, ok := g.Items[id]
+	if !ok {
+		return plan.WorkGraph{}, plan.WorkItem{}, errorf(http.StatusNotFound, "unknown id %q", id)
+	}
+	return g, it, nil
+}
+
+func (s *Server) handlePlan(w http.ResponseWriter, r *http.Request) {
+	g, err := s.loadPlan()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+// handleItems lists items sorted by id. Filters: ?status=<status>, ?ready=true.
+func (s *Server) handleItems(w http.ResponseWriter, r *http.Request) {
+	g, err := s.loadPlan()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var statusFilter *plan.Status
+	if v := r.URL.Query().Get("status"); v != "" {
+		st, ok := plan.ParseStatus(v)
+		if !ok {
+			writeError(w, errorf(http.StatusBadRequest, "invalid status %q", v))
+			return
+		}
+		statusFilter = &st
+	}
+	readyOnly := r.URL.Query().Get("ready") == "true"
+
+	ids := make([]string, 0, len(g.Items))
+	for id := range g.Items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	items := []Item{}
+	for _, id := range ids {
+		it := g.Items[id]
+		if statusFilter != nil && it.Status != *statusFilter {
+			continue
+		}
+		readiness := plan.ItemReadiness(g, it)
+		if readyOnly && !readiness.Actionable {
+			continue
+		}
+		items = append(items, Item{WorkItem: it, Readiness: readiness})
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) handleItem(w http.ResponseWriter, r *http.Request) {
+	g, it, err := s.loadItem(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	dependents := plan.Dependents(g, it.ID)
+	if dependents == nil {
+		dependents = []string{}
+	}
+	writeJSON(w, http.StatusOK, itemDetail{
+		Item:       Item{WorkItem: it, Readiness: plan.ItemReadiness(g, it)},
+		Dependents: dependents,
+	})
+}
+
+func (s *Server) handleSetStatus(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Status string `json:"status"`
+	}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	st, ok := plan.ParseStatus(body.Status)
+	if !ok {
+		writeError(w, errorf(http.StatusBadRequest, "invalid status %q", body.Status))
+		return
+	}
+	g, it, err := s.loadItem(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := plan.SetStatus(&g, it.ID, st, time.Now().UTC()); err != nil {
+		writeError(w, errorf(http.StatusBadRequest, "%v", err))
+		return
+	}
+	if err := plan.SaveWithHistory(s.cfg.PlanPath, g, fmt.Sprintf("set-status %s %s", it.ID, st)); err != nil {
+		writeError(w, fmt.Errorf("write plan file: %w", err))
+		return
+	}
+	s.events.publish("plan", map[string]string{"reason": "set-status", "taskId": it.ID})
+	updated := g.Items[it.ID]
+	writeJSON(w, http.StatusOK, Item{WorkItem: updated, Readiness: plan.ItemReadiness(g, updated)})
+}
+
+func (s *Server) handleRuns(w http.ResponseWriter, r *http.Request) {
+	_, it, err := s.loadItem(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	records, err := execution.ListRuns(s.baseDir(), it.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if records == nil {
+		records = []execution.RunRecord{}
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+func (s *Server) handleRun(w http.ResponseWriter, r *http.Request) {
+	_, it, err := s.loadItem(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	record, err := execution.LoadRun(s.baseDir(), it.ID, r.PathValue("runId"))
+	if err != nil {
+		writeError(w, errorf(http.StatusNotFound, "%v", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (s *Server) handleItemQuestions(w http.ResponseWriter, r *http.Request) {
+	_, it, err := s.loadItem(r.PathValue("id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	q, err := s.pendingQuestions(it.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if q == nil {
+		writeError(w, errorf(http.StatusNotFound, "no waiting run for %s", it.ID))
+		return
+	}
+	writeJSON(w, http.StatusOK, q)
+}
+
+// handleQuestions lists open questions for every waiting_user task.
+func (s *Server) handleQuestions(w http.ResponseWriter, r *http.Request) {
+	g, err := s.loadPlan()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	out := []taskQuestions{}
+	for _, id := range sortedIDs(g) {
+		if g.Items[id].Status != plan.StatusWaitingUser {
+			continue
+		}
+		q, err := s.pendingQuestions(id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if q != nil {
+			out = append(out, *q)
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) pendingQuestions(taskID string) (*taskQuestions, error) {
+	runs, err := execution.ListRuns(s.baseDir(), taskID)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(runs) - 1; i >= 0; i-- {
+		if runs[i].Status != execution.RunStatusWaitingUser {
+			continue
+		}
+		waiting, err := execution.LoadRunOutput(s.baseDir(), runs[i])
+		if err != nil {
+			return nil, err
+		}
+		questions, err := execution.ParseQuestions(waiting.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		if questions == nil {
+			questions = []agent.Question{}
+		}
+		return &taskQuestions{TaskID: taskID, RunID: runs[i].ID, Questions: questions}, nil
+	}
+	return nil, nil
+}
+
+// handleFeedback lists pending parent-review feedback awaiting a resume.
+func (s *Server) handleFeedback(w http.ResponseWriter, r *http.Request) {
+	g, err := s.loadPlan()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	out := []taskFeedback{}
+	for _, id := range sortedIDs(g) {
+		pending, err := execution.LoadPendingParentReviewFeedback(s.baseDir(), id)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if pending != nil {
+			out = append(out, taskFeedback{TaskID: id, Pending: *pending})
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func sortedIDs(g plan.WorkGraph) []string {
+	ids := make([]string, 0, len(g.Items))
+	for id := range g.Items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// IsLoopback reports whether addr binds only to a loopback interface.
+func IsLoopback(addr string) bool {
+	host := addr
+	if i := strings.LastIndex(addr, ":"); i >= 0 {
+		host = addr[:i]
+	}
+	host = strings.Trim(host, "[]")
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return strings.HasPrefix(host, "127.")
+}
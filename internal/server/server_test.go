@@ -0,0 +1,214 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+const testToken = "test-token"
+
+func newTestServer(t *testing.T, rt agent.Runtime) (*Server, *httptest.Server, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, plan.DefaultPlanFilename)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := plan.WorkItem{ID: "a", Title: "a", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo, CreatedAt: now, UpdatedAt: now}
+	b := a
+	b.ID, b.Title, b.Deps = "b", "b", []string{"a"}
+	g := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"a": a, "b": b}}
+	if err := plan.SaveAtomic(path, g); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	ts := httptest.NewUnstartedServer(nil)
+	srv := New(Config{
+		PlanPath:   path,
+		Addr:       ts.Listener.Addr().String(),
+		Token:      testToken,
+		NewRuntime: func() (agent.Runtime, error) { return rt, nil },
+	})
+	ts.Config.Handler = srv.Handler()
+	ts.Start()
+	t.Cleanup(ts.Close)
+	return srv, ts, path
+}
+
+func doJSON(t *testing.T, method, url, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("decode %s %s: %v", method, url, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestItemsAndSetStatus(t *testing.T) {
+	_, ts, path := newTestServer(t, agent.Runtime{})
+
+	var items []Item
+	if code := doJSON(t, "GET", ts.URL+"/api/items?ready=true", "", &items); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if len(items) != 1 || items[0].ID != "a" || !items[0].Readiness.Actionable {
+		t.Fatalf("ready items = %+v", items)
+	}
+
+	var errBody map[string]string
+	if code := doJSON(t, "GET", ts.URL+"/api/items/missing", "", &errBody); code != http.StatusNotFound || errBody["error"] == "" {
+		t.Fatalf("missing item: code %d body %v", code, errBody)
+	}
+	if code := doJSON(t, "POST", ts.URL+"/api/items/a/status", `{"status":"nope"}`, nil); code != http.StatusBadRequest {
+		t.Fatalf("invalid status code = %d", code)
+	}
+
+	var updated Item
+	if code := doJSON(t, "POST", ts.URL+"/api/items/a/status", `{"status":"done"}`, &updated); code != http.StatusOK {
+		t.Fatalf("set status code = %d", code)
+	}
+	if updated.Status != plan.StatusDone {
+		t.Fatalf("updated = %+v", updated)
+	}
+	g, err := plan.Load(path)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if g.Items["a"].Status != plan.StatusDone {
+		t.Fatalf("status not persisted")
+	}
+
+	var detail itemDetail
+	if code := doJSON(t, "GET", ts.URL+"/api/items/b", "", &detail); code != http.StatusOK {
+		t.Fatalf("item code = %d", code)
+	}
+	if !detail.Item.Readiness.Actionable {
+		t.Fatalf("expected b to be ready once a is done: %+v", detail.Item.Readiness)
+	}
+}
+
+func TestExecuteConflictAndCancel(t *testing.T) {
+	rt := agent.Runtime{Provider: "test", Command: "exec sleep 30", UseShell: true, Timeout: time.Minute}
+	srv, ts, _ := newTestServer(t, rt)
+	t.Cleanup(func() { _ = srv.Shutdown(t.Context()) })
+
+	var state jobState
+	if code := doJSON(t, "POST", ts.URL+"/api/execute", "{}", &state); code != http.StatusAccepted || !state.Running {
+		t.Fatalf("execute: code %d state %+v", code, state)
+	}
+	if code := doJSON(t, "POST", ts.URL+"/api/execute", "{}", nil); code != http.StatusConflict {
+		t.Fatalf("second execute code = %d, want 409", code)
+	}
+	if code := doJSON(t, "POST", ts.URL+"/api/cancel", "{}", &state); code != http.StatusOK {
+		t.Fatalf("cancel code = %d", code)
+	}
+	if state.Running || state.Last == nil || state.Last.Action != "execute" {
+		t.Fatalf("state after cancel = %+v", state)
+	}
+}
+
+func TestEventsStreamStartsWithStatus(t *testing.T) {
+	srv, ts, _ := newTestServer(t, agent.Runtime{})
+
+	resp, err := http.Get(ts.URL + "/api/events?access_token=" + testToken)
+	if err != nil {
+		t.Fatalf("get events: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type = %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() (string, string) {
+		var typ, data string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("read event: %v", err)
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				typ = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				data = strings.TrimPrefix(line, "data: ")
+			case line == "" && typ != "":
+				return typ, data
+			}
+		}
+	}
+	if typ, data := readEvent(); typ != "status" || !strings.Contains(data, `"running":false`) {
+		t.Fatalf("first event = %s %s", typ, data)
+	}
+
+	srv.events.stream("stdout").Write([]byte("hello"))
+	if typ, data := readEvent(); typ != "output" || !strings.Contains(data, `"text":"hello"`) {
+		t.Fatalf("output event = %s %s", typ, data)
+	}
+}
+
+func TestHandlerRejectsUnauthenticatedAndCrossSiteRequests(t *testing.T) {
+	_, ts, _ := newTestServer(t, agent.Runtime{})
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		header map[string]string
+		want   int
+	}{
+		{"no token", "GET", "/api/plan", "", map[string]string{"Authorization": ""}, http.StatusUnauthorized},
+		{"wrong token", "GET", "/api/plan", "", map[string]string{"Authorization": "Bearer nope"}, http.StatusUnauthorized},
+		{"query token off events", "GET", "/api/plan?access_token=" + testToken, "", map[string]string{"Authorization": ""}, http.StatusUnauthorized},
+		{"foreign origin", "POST", "/api/execute", "{}", map[string]string{"Origin": "http://evil.example"}, http.StatusForbidden},
+		{"rebound host", "GET", "/api/plan", "", map[string]string{"Host": "evil.example" + ts.URL[strings.LastIndex(ts.URL, ":"):]}, http.StatusForbidden},
+		{"form post", "POST", "/api/execute", "{}", map[string]string{"Content-Type": "text/plain"}, http.StatusUnsupportedMediaType},
+		{"empty body", "POST", "/api/execute", "", map[string]string{"Content-Type": "application/json"}, http.StatusBadRequest},
+		{"same origin", "GET", "/api/plan", "", map[string]string{"Origin": ts.URL}, http.StatusOK},
+		{"localhost alias", "GET", "/api/plan", "", map[string]string{"Host": "localhost" + ts.URL[strings.LastIndex(ts.URL, ":"):]}, http.StatusOK},
+	}
+	for _, tc := range cases {
+		req, err := http.NewRequest(tc.method, ts.URL+tc.path, strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatalf("%s: new request: %v", tc.name, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		req.Header.Set("Content-Type", "application/json")
+		for k, v := range tc.header {
+			if k == "Host" {
+				req.Host = v
+				continue
+			}
+			req.Header.Set(k, v)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Fatalf("%s: code = %d, want %d", tc.name, resp.StatusCode, tc.want)
+		}
+	}
+}
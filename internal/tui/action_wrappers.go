@@ -11,12 +11,25 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/config"
 	"github.com/jbonatakis/blackbird/internal/execution"
 	"github.com/jbonatakis/blackbird/internal/plan"
 	"github.com/jbonatakis/blackbird/internal/plangen"
 	"github.com/jbonatakis/blackbird/internal/planquality"
+	"github.com/jbonatakis/blackbird/internal/webhook"
 )
 
+// loadExecutionHooks reads the lifecycle hooks and webhooks configured for the
+// current plan.
+func loadExecutionHooks(planPath string) (execution.Hooks, *webhook.Dispatcher) {
+	baseDir := filepath.Dir(planPath)
+	cfg, err := config.LoadConfig(plan.ProjectRoot(planPath))
+	if err != nil {
+		cfg = config.DefaultResolvedConfig()
+	}
+	return execution.HooksFromConfig(cfg.Hooks), webhook.New(cfg.Webhooks, baseDir)
+}
+
 type PlanActionComplete struct {
 	Action  string
 	Success bool
@@ -40,24 +53,27 @@ type DecisionActionComplete struct {
 }
 
 type PlanGenerateInMemoryResult struct {
-	Success   bool
-	Plan      *plan.WorkGraph
+	Success bool
+	Plan    *plan.WorkGraph
+	// Patch is set for refines answered with a patch, so each op can be reviewed.
+	Patch     []agent.PatchOp
 	Quality   *PlanReviewQualitySummary
 	Questions []agent.Question
 	Err       error
 }
 
-func ExecuteCmd() tea.Cmd {
+func ExecuteCmd(planPath string) tea.Cmd {
 	ctx := context.Background()
-	return ExecuteCmdWithContext(ctx)
+	return ExecuteCmdWithContext(ctx, planPath)
 }
 
-func ExecuteCmdWithContext(ctx context.Context) tea.Cmd {
-	return ExecuteCmdWithContextAndStream(ctx, nil, nil, nil, nil, nil, nil, false, false)
+func ExecuteCmdWithContext(ctx context.Context, planPath string) tea.Cmd {
+	return ExecuteCmdWithContextAndStream(ctx, planPath, nil, nil, nil, nil, nil, nil, false, false, false)
 }
 
 func ExecuteCmdWithContextAndStream(
 	ctx context.Context,
+	planPath string,
 	stdout io.Writer,
 	stderr io.Writer,
 	liveOutput chan liveOutputMsg,
@@ -66,6 +82,7 @@ func ExecuteCmdWithContextAndStream(
 	liveParentReviewAck chan struct{},
 	stopAfterEachTask bool,
 	parentReviewEnabled bool,
+	continueOnWaitingUser bool,
 ) tea.Cmd {
 	return func() tea.Msg {
 		if liveOutput != nil {
@@ -77,18 +94,22 @@ func ExecuteCmdWithContextAndStream(
 		if liveParentReview != nil {
 			defer close(liveParentReview)
 		}
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return ExecuteActionComplete{Action: "execute", Success: false, Err: err}
 		}
 
+		hooks, webhooks := loadExecutionHooks(planPath)
 		result, runErr := execution.RunExecute(ctx, execution.ExecuteConfig{
-			PlanPath:            plan.PlanPath(),
-			Runtime:             runtime,
-			StopAfterEachTask:   stopAfterEachTask,
-			ParentReviewEnabled: parentReviewEnabled,
-			StreamStdout:        stdout,
-			StreamStderr:        stderr,
+			PlanPath:              planPath,
+			Runtime:               runtime,
+			StopAfterEachTask:     stopAfterEachTask,
+			ParentReviewEnabled:   parentReviewEnabled,
+			ContinueOnWaitingUser: continueOnWaitingUser,
+			Hooks:                 hooks,
+			Webhooks:              webhooks,
+			StreamStdout:          stdout,
+			StreamStderr:          stderr,
 			OnStateChange: func(state execution.ExecutionStageState) {
 				if liveStage == nil {
 					return
@@ -126,35 +147,38 @@ func ExecuteCmdWithContextAndStream(
 	}
 }
 
-func ResumeCmd(taskID string, answers []agent.Answer) tea.Cmd {
+func ResumeCmd(planPath string, taskID string, answers []agent.Answer) tea.Cmd {
 	ctx := context.Background()
-	return ResumeCmdWithContext(ctx, taskID, answers)
+	return ResumeCmdWithContext(ctx, planPath, taskID, answers)
 }
 
-func ResumeCmdWithContext(ctx context.Context, taskID string, answers []agent.Answer) tea.Cmd {
-	return ResumeCmdWithContextAndStream(ctx, taskID, answers, nil, nil, nil)
+func ResumeCmdWithContext(ctx context.Context, planPath string, taskID string, answers []agent.Answer) tea.Cmd {
+	return ResumeCmdWithContextAndStream(ctx, planPath, taskID, answers, nil, nil, nil)
 }
 
-func ResumeCmdWithContextAndStream(ctx context.Context, taskID string, answers []agent.Answer, stdout io.Writer, stderr io.Writer, liveOutput chan liveOutputMsg) tea.Cmd {
+func ResumeCmdWithContextAndStream(ctx context.Context, planPath string, taskID string, answers []agent.Answer, stdout io.Writer, stderr io.Writer, liveOutput chan liveOutputMsg) tea.Cmd {
 	return func() tea.Msg {
 		if liveOutput != nil {
 			defer close(liveOutput)
 		}
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return ExecuteActionComplete{Action: "resume", Success: false, Err: err}
 		}
 
-		baseDir := filepath.Dir(plan.PlanPath())
+		baseDir := filepath.Dir(planPath)
 		if _, err := execution.ResolveResumeFeedbackSource(baseDir, taskID, "", answers); err != nil {
 			return ExecuteActionComplete{Action: "resume", Success: false, Err: err}
 		}
 
+		hooks, webhooks := loadExecutionHooks(planPath)
 		record, runErr := execution.RunResume(ctx, execution.ResumeConfig{
-			PlanPath:     plan.PlanPath(),
+			PlanPath:     planPath,
 			TaskID:       taskID,
 			Answers:      answers,
 			Runtime:      runtime,
+			Hooks:        hooks,
+			Webhooks:     webhooks,
 			StreamStdout: stdout,
 			StreamStderr: stderr,
 		})
@@ -177,18 +201,19 @@ type ResumePendingParentFeedbackTarget struct {
 	Feedback string
 }
 
-func ResumePendingParentFeedbackCmd(taskID string) tea.Cmd {
+func ResumePendingParentFeedbackCmd(planPath string, taskID string) tea.Cmd {
 	ctx := context.Background()
-	return ResumePendingParentFeedbackCmdWithContext(ctx, taskID)
+	return ResumePendingParentFeedbackCmdWithContext(ctx, planPath, taskID)
 }
 
-func ResumePendingParentFeedbackCmdWithContext(ctx context.Context, taskID string) tea.Cmd {
-	return ResumePendingParentFeedbackCmdWithContextAndStream(ctx, taskID, nil, nil, nil)
+func ResumePendingParentFeedbackCmdWithContext(ctx context.Context, planPath string, taskID string) tea.Cmd {
+	return ResumePendingParentFeedbackCmdWithContextAndStream(ctx, planPath, taskID, nil, nil, nil)
 }
 
-func ResumePendingParentFeedbackCmdWithContextAndStream(ctx context.Context, taskID string, stdout io.Writer, stderr io.Writer, liveOutput chan liveOutputMsg) tea.Cmd {
+func ResumePendingParentFeedbackCmdWithContextAndStream(ctx context.Context, planPath string, taskID string, stdout io.Writer, stderr io.Writer, liveOutput chan liveOutputMsg) tea.Cmd {
 	return ResumePendingParentFeedbackTargetCmdWithContextAndStream(
 		ctx,
+		planPath,
 		ResumePendingParentFeedbackTarget{TaskID: taskID},
 		stdout,
 		stderr,
@@ -196,17 +221,18 @@ func ResumePendingParentFeedbackCmdWithContextAndStream(ctx context.Context, tas
 	)
 }
 
-func ResumePendingParentFeedbackTargetCmd(target ResumePendingParentFeedbackTarget) tea.Cmd {
+func ResumePendingParentFeedbackTargetCmd(planPath string, target ResumePendingParentFeedbackTarget) tea.Cmd {
 	ctx := context.Background()
-	return ResumePendingParentFeedbackTargetCmdWithContext(ctx, target)
+	return ResumePendingParentFeedbackTargetCmdWithContext(ctx, planPath, target)
 }
 
-func ResumePendingParentFeedbackTargetCmdWithContext(ctx context.Context, target ResumePendingParentFeedbackTarget) tea.Cmd {
-	return ResumePendingParentFeedbackTargetCmdWithContextAndStream(ctx, target, nil, nil, nil)
+func ResumePendingParentFeedbackTargetCmdWithContext(ctx context.Context, planPath string, target ResumePendingParentFeedbackTarget) tea.Cmd {
+	return ResumePendingParentFeedbackTargetCmdWithContextAndStream(ctx, planPath, target, nil, nil, nil)
 }
 
 func ResumePendingParentFeedbackTargetCmdWithContextAndStream(
 	ctx context.Context,
+	planPath string,
 	target ResumePendingParentFeedbackTarget,
 	stdout io.Writer,
 	stderr io.Writer,
@@ -216,13 +242,14 @@ func ResumePendingParentFeedbackTargetCmdWithContextAndStream(
 		if liveOutput != nil {
 			defer close(liveOutput)
 		}
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return ExecuteActionComplete{Action: "resume", Success: false, Err: err}
 		}
 
 		record, runErr := runPendingParentFeedbackResumeTaskWithFeedback(
 			ctx,
+			planPath,
 			runtime,
 			target.TaskID,
 			target.Feedback,
@@ -243,37 +270,39 @@ func ResumePendingParentFeedbackTargetCmdWithContextAndStream(
 	}
 }
 
-func ResumePendingParentFeedbackTargetsCmd(taskIDs []string) tea.Cmd {
+func ResumePendingParentFeedbackTargetsCmd(planPath string, taskIDs []string) tea.Cmd {
 	ctx := context.Background()
-	return ResumePendingParentFeedbackTargetsCmdWithContext(ctx, taskIDs)
+	return ResumePendingParentFeedbackTargetsCmdWithContext(ctx, planPath, taskIDs)
 }
 
-func ResumePendingParentFeedbackTargetsCmdWithContext(ctx context.Context, taskIDs []string) tea.Cmd {
-	return ResumePendingParentFeedbackTargetsCmdWithContextAndStream(ctx, taskIDs, nil, nil, nil)
+func ResumePendingParentFeedbackTargetsCmdWithContext(ctx context.Context, planPath string, taskIDs []string) tea.Cmd {
+	return ResumePendingParentFeedbackTargetsCmdWithContextAndStream(ctx, planPath, taskIDs, nil, nil, nil)
 }
 
-func ResumePendingParentFeedbackTargetsCmdWithContextAndStream(ctx context.Context, taskIDs []string, stdout io.Writer, stderr io.Writer, liveOutput chan liveOutputMsg) tea.Cmd {
+func ResumePendingParentFeedbackTargetsCmdWithContextAndStream(ctx context.Context, planPath string, taskIDs []string, stdout io.Writer, stderr io.Writer, liveOutput chan liveOutputMsg) tea.Cmd {
 	targets := make([]ResumePendingParentFeedbackTarget, 0, len(taskIDs))
 	for _, taskID := range taskIDs {
 		targets = append(targets, ResumePendingParentFeedbackTarget{TaskID: taskID})
 	}
-	return ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(ctx, targets, stdout, stderr, liveOutput)
+	return ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(ctx, planPath, targets, stdout, stderr, liveOutput)
 }
 
-func ResumePendingParentFeedbackTargetsWithFeedbackCmd(targets []ResumePendingParentFeedbackTarget) tea.Cmd {
+func ResumePendingParentFeedbackTargetsWithFeedbackCmd(planPath string, targets []ResumePendingParentFeedbackTarget) tea.Cmd {
 	ctx := context.Background()
-	return ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContext(ctx, targets)
+	return ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContext(ctx, planPath, targets)
 }
 
 func ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContext(
 	ctx context.Context,
+	planPath string,
 	targets []ResumePendingParentFeedbackTarget,
 ) tea.Cmd {
-	return ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(ctx, targets, nil, nil, nil)
+	return ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(ctx, planPath, targets, nil, nil, nil)
 }
 
 func ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(
 	ctx context.Context,
+	planPath string,
 	targets []ResumePendingParentFeedbackTarget,
 	stdout io.Writer,
 	stderr io.Writer,
@@ -291,7 +320,7 @@ func ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(
 			}
 		}
 
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return ExecuteActionComplete{Action: "resume", Success: false, Err: err}
 		}
@@ -300,6 +329,7 @@ func ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(
 		for _, target := range normalizedTargets {
 			record, runErr := runPendingParentFeedbackResumeTaskWithFeedback(
 				ctx,
+				planPath,
 				runtime,
 				target.TaskID,
 				target.Feedback,
@@ -321,12 +351,59 @@ func ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(
 	}
 }
 
-func runPendingParentFeedbackResumeTask(ctx context.Context, runtime agent.Runtime, taskID string, stdout io.Writer, stderr io.Writer) (execution.RunRecord, error) {
-	return runPendingParentFeedbackResumeTaskWithFeedback(ctx, runtime, taskID, "", stdout, stderr)
+// ResumeAnsweredCmdWithContextAndStream resumes the answered inbox tasks one
+// after another.
+func ResumeAnsweredCmdWithContextAndStream(
+	ctx context.Context,
+	planPath string,
+	sets []execution.AnswerSet,
+	stdout io.Writer,
+	stderr io.Writer,
+	liveOutput chan liveOutputMsg,
+) tea.Cmd {
+	return func() tea.Msg {
+		if liveOutput != nil {
+			defer close(liveOutput)
+		}
+		runtime, err := agent.NewRuntimeForPlan(planPath)
+		if err != nil {
+			return ExecuteActionComplete{Action: "resume", Success: false, Err: err}
+		}
+
+		hooks, webhooks := loadExecutionHooks(planPath)
+		lines := make([]string, 0, len(sets))
+		err = execution.ResumeAnswered(ctx, execution.ResumeConfig{
+			PlanPath:     planPath,
+			Runtime:      runtime,
+			Hooks:        hooks,
+			Webhooks:     webhooks,
+			StreamStdout: stdout,
+			StreamStderr: stderr,
+		}, sets, func(taskID string, record execution.RunRecord, runErr error) {
+			if runErr != nil && record.ID == "" {
+				lines = append(lines, summarizeResumeError(taskID, runErr))
+				return
+			}
+			lines = append(lines, summarizeResumeRecord(record, taskID))
+		})
+		if err != nil {
+			return ExecuteActionComplete{Action: "resume", Err: err, Output: strings.Join(lines, "\n")}
+		}
+		return ExecuteActionComplete{
+			Action:  "resume",
+			Success: true,
+			Output:  strings.Join(lines, "\n"),
+		}
+	}
+}
+
+func runPendingParentFeedbackResumeTask(ctx context.Context, planPath string, runtime agent.Runtime, taskID string, stdout io.Writer, stderr io.Writer) (execution.RunRecord, error) {
+	return runPendingParentFeedbackResumeTaskWithFeedback(ctx, planPath, runtime, taskID, "", stdout, stderr)
 }
 
 func runPendingParentFeedbackResumeTaskWithFeedback(
 	ctx context.Context,
+	planPath string,
 	runtime agent.Runtime,
 	taskID string,
 	feedback string,
@@ -338,7 +415,6 @@ func runPendingParentFeedbackResumeTaskWithFeedback(
 		return execution.RunRecord{}, fmt.Errorf("task id required")
 	}
 
-	planPath := plan.PlanPath()
 	baseDir := filepath.Dir(planPath)
 	resolvedFeedback, err := execution.ResolveResumeFeedbackSource(baseDir, taskID, "", nil)
 	if err != nil {
@@ -360,10 +436,13 @@ func runPendingParentFeedbackResumeTaskWithFeedback(
 		}
 	}
 
+	hooks, webhooks := loadExecutionHooks(planPath)
 	return execution.RunResume(ctx, execution.ResumeConfig{
 		PlanPath:     planPath,
 		TaskID:       taskID,
 		Runtime:      runtime,
+		Hooks:        hooks,
+		Webhooks:     webhooks,
 		StreamStdout: stdout,
 		StreamStderr: stderr,
 	})
@@ -371,21 +450,25 @@ func runPendingParentFeedbackResumeTaskWithFeedback(
 
 func ResolveDecisionCmdWithContext(
 	ctx context.Context,
+	planPath string,
 	taskID string,
 	runID string,
 	action execution.DecisionState,
 	feedback string,
 	stopAfterEachTask bool,
 	parentReviewEnabled bool,
+	continueOnWaitingUser bool,
 ) tea.Cmd {
 	return ResolveDecisionCmdWithContextAndStream(
 		ctx,
+		planPath,
 		taskID,
 		runID,
 		action,
 		feedback,
 		stopAfterEachTask,
 		parentReviewEnabled,
+		continueOnWaitingUser,
 		nil,
 		nil,
 		nil,
@@ -395,12 +478,14 @@ func ResolveDecisionCmdWithContext(
 
 func ResolveDecisionCmdWithContextAndStream(
 	ctx context.Context,
+	planPath string,
 	taskID string,
 	runID string,
 	action execution.DecisionState,
 	feedback string,
 	stopAfterEachTask bool,
 	parentReviewEnabled bool,
+	continueOnWaitingUser bool,
 	stdout io.Writer,
 	stderr io.Writer,
 	liveOutput chan liveOutputMsg,
@@ -413,18 +498,22 @@ func ResolveDecisionCmdWithContextAndStream(
 		if liveStage != nil {
 			defer close(liveStage)
 		}
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return DecisionActionComplete{Action: action, Err: err}
 		}
 
+		hooks, webhooks := loadExecutionHooks(planPath)
 		controller := execution.ExecutionController{
-			PlanPath:            plan.PlanPath(),
-			Runtime:             runtime,
-			StopAfterEachTask:   stopAfterEachTask,
-			ParentReviewEnabled: parentReviewEnabled,
-			StreamStdout:        stdout,
-			StreamStderr:        stderr,
+			PlanPath:              planPath,
+			Runtime:               runtime,
+			StopAfterEachTask:     stopAfterEachTask,
+			ParentReviewEnabled:   parentReviewEnabled,
+			ContinueOnWaitingUser: continueOnWaitingUser,
+			Hooks:                 hooks,
+			Webhooks:              webhooks,
+			StreamStdout:          stdout,
+			StreamStderr:          stderr,
 			OnStateChange: func(state execution.ExecutionStageState) {
 				if liveStage == nil {
 					return
@@ -447,7 +536,7 @@ func ResolveDecisionCmdWithContextAndStream(
 	}
 }
 
-func SetStatusCmd(id string, status string) tea.Cmd {
+func SetStatusCmd(planPath string, id string, status string) tea.Cmd {
 	return func() tea.Msg {
 		s, ok := plan.ParseStatus(status)
 		if !ok {
@@ -456,13 +545,12 @@ func SetStatusCmd(id string, status string) tea.Cmd {
 				Err:    fmt.Errorf("invalid status %q", status),
 			}
 		}
-		path := plan.PlanPath()
-		g, err := plan.Load(path)
+		g, err := plan.Load(planPath)
 		if err != nil {
 			if errors.Is(err, plan.ErrPlanNotFound) {
 				return ExecuteActionComplete{
 					Action: "set-status",
-					Err:    fmt.Errorf("plan file not found: %s (run `blackbird init`)", path),
+					Err:    fmt.Errorf("plan file not found: %s (run `blackbird init`)", planPath),
 				}
 			}
 			return ExecuteActionComplete{Action: "set-status", Err: err}
@@ -470,7 +558,7 @@ func SetStatusCmd(id string, status string) tea.Cmd {
 		if errs := plan.Validate(g); len(errs) != 0 {
 			return ExecuteActionComplete{
 				Action: "set-status",
-				Err:    fmt.Errorf("plan is invalid (run `blackbird validate`): %s", path),
+				Err:    fmt.Errorf("plan is invalid (run `blackbird validate`): %s", planPath),
 			}
 		}
 
@@ -478,7 +566,7 @@ func SetStatusCmd(id string, status string) tea.Cmd {
 		if err := plan.SetStatus(&g, id, s, now); err != nil {
 			return ExecuteActionComplete{Action: "set-status", Err: err}
 		}
-		if err := plan.SaveAtomic(path, g); err != nil {
+		if err := plan.SaveWithHistory(planPath, g, fmt.Sprintf("set-status %s %s", id, s)); err != nil {
 			return ExecuteActionComplete{Action: "set-status", Err: fmt.Errorf("write plan file: %w", err)}
 		}
 		return ExecuteActionComplete{
@@ -489,11 +577,39 @@ func SetStatusCmd(id string, status string) tea.Cmd {
 	}
 }
 
+// HistoryStepCmd undoes (or, with redo set, redoes) the last journaled plan
+// save. Like the CLI without --force, it refuses when the plan changed outside
+// the history.
+func HistoryStepCmd(planPath string, redo bool) tea.Cmd {
+	return func() tea.Msg {
+		action, step := "undo", plan.Undo
+		if redo {
+			action, step = "redo", plan.Redo
+		}
+		entry, err := step(planPath, false)
+		if err != nil {
+			var conflict plan.HistoryConflictError
+			if errors.As(err, &conflict) {
+				err = fmt.Errorf("%w; run `blackbird %s --force` to discard the unrecorded changes", err, action)
+			}
+			return PlanActionComplete{Action: action, Err: err}
+		}
+		return PlanActionComplete{
+			Action:  action,
+			Success: true,
+			Output:  fmt.Sprintf("%s #%d: %s", action, entry.ID, entry.Label),
+		}
+	}
+}
+
 func summarizeExecuteResult(result execution.ExecuteResult) string {
 	switch result.Reason {
 	case execution.ExecuteReasonCompleted:
 		return "no ready tasks remaining"
 	case execution.ExecuteReasonWaitingUser:
+		if len(result.Waiting) > 1 {
+			return strings.Join(result.Waiting, ", ") + " are waiting for user input"
+		}
 		if result.TaskID != "" {
 			return result.TaskID + " is waiting for user input"
 		}
@@ -595,9 +711,9 @@ func normalizeResumeTargetsWithFeedback(targets []ResumePendingParentFeedbackTar
 // If the agent asks questions, they are stored in the result for TUI display but the execution
 // stops (does not auto-answer). The caller can then display questions to the user and call
 // GeneratePlanInMemoryWithAnswers to continue with answers.
-func GeneratePlanInMemory(ctx context.Context, description string, constraints []string, granularity string) tea.Cmd {
+func GeneratePlanInMemory(ctx context.Context, planPath string, description string, constraints []string, granularity string) tea.Cmd {
 	return func() tea.Msg {
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return PlanGenerateInMemoryResult{Success: false, Err: err}
 		}
@@ -627,7 +743,7 @@ func GeneratePlanInMemory(ctx context.Context, description string, constraints [
 		}
 
 		resultPlan := plan.Clone(*generateResult.Plan)
-		qualityResult, err := runGeneratedPlanQualityGate(ctx, runtime, requestMeta, resultPlan)
+		qualityResult, err := runGeneratedPlanQualityGate(ctx, planPath, runtime, requestMeta, resultPlan)
 		if err != nil {
 			return PlanGenerateInMemoryResult{Success: false, Err: err}
 		}
@@ -643,9 +759,9 @@ func GeneratePlanInMemory(ctx context.Context, description string, constraints [
 
 // GeneratePlanInMemoryWithAnswers continues plan generation after answering agent questions.
 // It takes the original request parameters plus the answers to questions that were asked.
-func GeneratePlanInMemoryWithAnswers(ctx context.Context, description string, constraints []string, granularity string, answers []agent.Answer) tea.Cmd {
+func GeneratePlanInMemoryWithAnswers(ctx context.Context, planPath string, description string, constraints []string, granularity string, answers []agent.Answer) tea.Cmd {
 	return func() tea.Msg {
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return PlanGenerateInMemoryResult{Success: false, Err: err}
 		}
@@ -676,7 +792,7 @@ func GeneratePlanInMemoryWithAnswers(ctx context.Context, description string, co
 		}
 
 		resultPlan := plan.Clone(*generateResult.Plan)
-		qualityResult, err := runGeneratedPlanQualityGate(ctx, runtime, requestMeta, resultPlan)
+		qualityResult, err := runGeneratedPlanQualityGate(ctx, planPath, runtime, requestMeta, resultPlan)
 		if err != nil {
 			return PlanGenerateInMemoryResult{Success: false, Err: err}
 		}
@@ -691,9 +807,9 @@ func GeneratePlanInMemoryWithAnswers(ctx context.Context, description string, co
 }
 
 // RefinePlanInMemory refines an existing plan with a change request
-func RefinePlanInMemory(ctx context.Context, changeRequest string, currentPlan plan.WorkGraph) tea.Cmd {
+func RefinePlanInMemory(ctx context.Context, planPath string, changeRequest string, currentPlan plan.WorkGraph) tea.Cmd {
 	return func() tea.Msg {
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return PlanGenerateInMemoryResult{Success: false, Err: err}
 		}
@@ -724,14 +840,15 @@ func RefinePlanInMemory(ctx context.Context, changeRequest string, currentPlan p
 		return PlanGenerateInMemoryResult{
 			Success: true,
 			Plan:    refineResult.Plan,
+			Patch:   refineResult.Patch,
 		}
 	}
 }
 
 // RefinePlanInMemoryWithAnswers continues plan refinement after answering questions.
-func RefinePlanInMemoryWithAnswers(ctx context.Context, changeRequest string, currentPlan plan.WorkGraph, answers []agent.Answer) tea.Cmd {
+func RefinePlanInMemoryWithAnswers(ctx context.Context, planPath string, changeRequest string, currentPlan plan.WorkGraph, answers []agent.Answer) tea.Cmd {
 	return func() tea.Msg {
-		runtime, err := agent.NewRuntimeFromEnv()
+		runtime, err := agent.NewRuntimeForPlan(planPath)
 		if err != nil {
 			return PlanGenerateInMemoryResult{Success: false, Err: err}
 		}
@@ -763,12 +880,13 @@ func RefinePlanInMemoryWithAnswers(ctx context.Context, changeRequest string, cu
 		return PlanGenerateInMemoryResult{
 			Success: true,
 			Plan:    refineResult.Plan,
+			Patch:   refineResult.Patch,
 		}
 	}
 }
 
 // ContinuePlanGenerationWithAnswers continues plan generation after answering questions
-func ContinuePlanGenerationWithAnswers(description string, constraints []string, granularity string, answers []agent.Answer, questionRound int) tea.Cmd {
+func ContinuePlanGenerationWithAnswers(planPath string, description string, constraints []string, granularity string, answers []agent.Answer, questionRound int) tea.Cmd {
 	// Check if we've exceeded max question rounds
 	if questionRound >= agent.MaxPlanQuestionRounds {
 		return func() tea.Msg {
@@ -779,11 +897,11 @@ func ContinuePlanGenerationWithAnswers(description string, constraints []string,
 		}
 	}
 
-	return GeneratePlanInMemoryWithAnswers(context.Background(), description, constraints, granularity, answers)
+	return GeneratePlanInMemoryWithAnswers(context.Background(), planPath, description, constraints, granularity, answers)
 }
 
 // ContinuePlanRefineWithAnswers continues plan refinement after answering questions.
-func ContinuePlanRefineWithAnswers(changeRequest string, currentPlan plan.WorkGraph, answers []agent.Answer, questionRound int) tea.Cmd {
+func ContinuePlanRefineWithAnswers(planPath string, changeRequest string, currentPlan plan.WorkGraph, answers []agent.Answer, questionRound int) tea.Cmd {
 	if questionRound >= agent.MaxPlanQuestionRounds {
 		return func() tea.Msg {
 			return PlanGenerateInMemoryResult{
@@ -793,7 +911,7 @@ func ContinuePlanRefineWithAnswers(changeRequest string, currentPlan plan.WorkGr
 		}
 	}
 
-	return RefinePlanInMemoryWithAnswers(context.Background(), changeRequest, currentPlan, answers)
+	return RefinePlanInMemoryWithAnswers(context.Background(), planPath, changeRequest, currentPlan, answers)
 }
 
 // trimNonEmpty removes empty strings from a slice after trimming whitespace
@@ -807,9 +925,13 @@ func trimNonEmpty(in []string) []string {
 	return out
 }
 
-func runGeneratedPlanQualityGate(ctx context.Context, runtime agent.Runtime, requestMeta agent.RequestMetadata, generated plan.WorkGraph) (planquality.QualityGateResult, error) {
-	maxPasses := plangen.ResolveMaxAutoRefinePassesFromPlanPath(plan.PlanPath())
-	return plangen.RunQualityGate(ctx, generated, maxPasses, func(refineCtx context.Context, changeRequest string, currentPlan plan.WorkGraph) (plan.WorkGraph, error) {
+func runGeneratedPlanQualityGate(ctx context.Context, planPath string, runtime agent.Runtime, requestMeta agent.RequestMetadata, generated plan.WorkGraph) (planquality.QualityGateResult, error) {
+	maxPasses := plangen.ResolveMaxAutoRefinePassesFromPlanPath(planPath)
+	rules, err := plangen.ResolveQualityRulesFromPlanPath(planPath)
+	if err != nil {
+		return planquality.QualityGateResult{}, err
+	}
+	return plangen.RunQualityGateWithRules(ctx, generated, maxPasses, rules, func(refineCtx context.Context, changeRequest string, currentPlan plan.WorkGraph) (plan.WorkGraph, error) {
 		refineResult, err := plangen.Refine(refineCtx, runtime.Run, plangen.RefineInput{
 			ChangeRequest: changeRequest,
 			CurrentPlan:   currentPlan,
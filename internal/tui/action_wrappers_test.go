@@ -36,7 +36,7 @@ func TestExecuteCmdWithContextRunsInProcess(t *testing.T) {
 		t.Fatalf("save plan: %v", err)
 	}
 
-	cmd := ExecuteCmdWithContext(context.Background())
+	cmd := ExecuteCmdWithContext(context.Background(), plan.PlanPath())
 	msg := cmd()
 	complete, ok := msg.(ExecuteActionComplete)
 	if !ok {
@@ -97,7 +97,7 @@ func TestResumeCmdWithContextRunsInProcess(t *testing.T) {
 		t.Fatalf("SaveRun: %v", err)
 	}
 
-	cmd := ResumeCmdWithContext(context.Background(), "task", []agent.Answer{{
+	cmd := ResumeCmdWithContext(context.Background(), plan.PlanPath(), "task", []agent.Answer{{
 		ID:    "q1",
 		Value: "answer",
 	}})
@@ -156,7 +156,7 @@ func TestResumeCmdWithContextRejectsAnswersWhenPendingFeedbackExists(t *testing.
 		t.Fatalf("UpsertPendingParentReviewFeedback: %v", err)
 	}
 
-	cmd := ResumeCmdWithContext(context.Background(), "task", []agent.Answer{{
+	cmd := ResumeCmdWithContext(context.Background(), plan.PlanPath(), "task", []agent.Answer{{
 		ID:    "q1",
 		Value: "answer",
 	}})
@@ -201,7 +201,7 @@ func TestResumePendingParentFeedbackCmdWithContextRunsInProcess(t *testing.T) {
 	now := time.Date(2026, 2, 9, 9, 0, 0, 0, time.UTC)
 	saveResumeFeedbackFixture(t, tempDir, "task", now)
 
-	cmd := ResumePendingParentFeedbackCmdWithContext(context.Background(), "task")
+	cmd := ResumePendingParentFeedbackCmdWithContext(context.Background(), plan.PlanPath(), "task")
 	msg := cmd()
 	complete, ok := msg.(ExecuteActionComplete)
 	if !ok {
@@ -253,6 +253,7 @@ func TestResumePendingParentFeedbackTargetCmdWithContextUsesProvidedFeedback(t *
 
 	cmd := ResumePendingParentFeedbackTargetCmdWithContext(
 		context.Background(),
+		plan.PlanPath(),
 		ResumePendingParentFeedbackTarget{
 			TaskID:   "task",
 			Feedback: "  apply targeted fix for task  ",
@@ -348,6 +349,7 @@ func TestResumePendingParentFeedbackTargetsCmdWithContextReportsPerTaskResults(t
 
 	cmd := ResumePendingParentFeedbackTargetsCmdWithContext(
 		context.Background(),
+		plan.PlanPath(),
 		[]string{" task-a ", "task-b", "task-a"},
 	)
 	msg := cmd()
@@ -406,6 +408,7 @@ func TestResumePendingParentFeedbackTargetsWithFeedbackCmdUsesPerTaskFeedback(t
 
 	cmd := ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContext(
 		context.Background(),
+		plan.PlanPath(),
 		[]ResumePendingParentFeedbackTarget{
 			{TaskID: "task-a", Feedback: "fix task-a precision and retry"},
 			{TaskID: "task-b", Feedback: "fix task-b retry loop and retry"},
@@ -514,6 +517,7 @@ func TestResponseToPlanNormalizesFullPlanTimestamps(t *testing.T) {
 
 func TestContinuePlanGenerationWithAnswersTooManyRounds(t *testing.T) {
 	cmd := ContinuePlanGenerationWithAnswers(
+		plan.PlanPath(),
 		"project",
 		nil,
 		"",
@@ -536,6 +540,7 @@ func TestContinuePlanGenerationWithAnswersTooManyRounds(t *testing.T) {
 
 func TestContinuePlanRefineWithAnswersTooManyRounds(t *testing.T) {
 	cmd := ContinuePlanRefineWithAnswers(
+		plan.PlanPath(),
 		"update plan",
 		plan.NewEmptyWorkGraph(),
 		[]agent.Answer{{ID: "q1", Value: "answer"}},
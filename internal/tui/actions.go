@@ -145,7 +145,7 @@ func HandleSetStatusKey(m Model, key string) (Model, tea.Cmd) {
 	m.actionInProgress = true
 	m.actionName = fmt.Sprintf("Setting status to %s...", status)
 
-	return m, tea.Batch(SetStatusCmd(taskID, string(status)), spinnerTickCmd())
+	return m, tea.Batch(SetStatusCmd(m.planPath(), taskID, string(status)), spinnerTickCmd())
 }
 
 // CanResume returns true if the selected task can be resumed via either pending
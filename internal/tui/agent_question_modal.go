@@ -199,11 +199,12 @@ func HandleAgentQuestionKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.pendingResumeTask = ""
 			m.actionInProgress = true
 			m.actionName = "Resuming..."
+			m.continueAfterResume = m.config.Execution.AutoContinue
 			ctx, cancel := context.WithCancel(context.Background())
 			m.actionCancel = cancel
 			streamCh, stdout, stderr := m.startLiveOutput()
 			return m, tea.Batch(
-				ResumeCmdWithContextAndStream(ctx, taskID, answers, stdout, stderr, streamCh),
+				ResumeCmdWithContextAndStream(ctx, m.planPath(), taskID, answers, stdout, stderr, streamCh),
 				listenLiveOutputCmd(streamCh),
 				spinnerTickCmd(),
 			)
@@ -223,6 +224,7 @@ func HandleAgentQuestionKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 		if m.pendingPlanRequest.kind == PendingPlanRefine {
 			return m, tea.Batch(
 				ContinuePlanRefineWithAnswers(
+					m.planPath(),
 					m.pendingPlanRequest.changeRequest,
 					m.pendingPlanRequest.basePlan,
 					answers,
@@ -235,6 +237,7 @@ func HandleAgentQuestionKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 		// Continue generation with answers
 		return m, tea.Batch(
 			ContinuePlanGenerationWithAnswers(
+				m.planPath(),
 				m.pendingPlanRequest.description,
 				m.pendingPlanRequest.constraints,
 				m.pendingPlanRequest.granularity,
@@ -17,18 +17,19 @@ type AgentSelectionSaved struct {
 
 func (m Model) LoadAgentSelection() tea.Cmd {
 	return func() tea.Msg {
-		selection, err := agent.LoadAgentSelection(agent.AgentSelectionPath())
+		selection, err := agent.LoadAgentSelection(agent.AgentSelectionPathFor(m.planPath()))
 		return AgentSelectionLoaded{Selection: selection, Err: err}
 	}
 }
 
-func SaveAgentSelectionCmd(selectedAgent string) tea.Cmd {
+func SaveAgentSelectionCmd(planPath string, selectedAgent string) tea.Cmd {
+	path := agent.AgentSelectionPathFor(planPath)
 	return func() tea.Msg {
-		err := agent.SaveAgentSelection(agent.AgentSelectionPath(), selectedAgent)
+		err := agent.SaveAgentSelection(path, selectedAgent)
 		if err != nil {
 			return AgentSelectionSaved{Err: err}
 		}
-		selection, loadErr := agent.LoadAgentSelection(agent.AgentSelectionPath())
+		selection, loadErr := agent.LoadAgentSelection(path)
 		if loadErr != nil {
 			return AgentSelectionSaved{Selection: selection, Err: loadErr}
 		}
@@ -115,7 +115,7 @@ func HandleAgentSelectionKey(m Model, key string) (Model, tea.Cmd) {
 		}
 		selected := agent.AgentRegistry[idx]
 		m.actionMode = ActionModeNone
-		return m, SaveAgentSelectionCmd(string(selected.ID))
+		return m, SaveAgentSelectionCmd(m.planPath(), string(selected.ID))
 	default:
 		return m, nil
 	}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
 )
 
 func TestRenderAgentSelectionModal(t *testing.T) {
@@ -115,7 +116,7 @@ func TestSaveAgentSelectionCmd(t *testing.T) {
 		t.Fatalf("Chdir() error = %v", err)
 	}
 
-	msg := SaveAgentSelectionCmd("codex")()
+	msg := SaveAgentSelectionCmd(plan.PlanPath(), "codex")()
 	selectionMsg, ok := msg.(AgentSelectionSaved)
 	if !ok {
 		t.Fatalf("expected AgentSelectionSaved, got %T", msg)
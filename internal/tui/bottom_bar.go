@@ -71,6 +71,8 @@ func shouldTrimActions(model Model, width int, actions []string, agent string, r
 
 func trimBottomBarActions(actions []string, width int, agent string, readyCount int, blockedCount int) []string {
 	priorities := []string{
+		"[z]undo",
+		"[i]nbox",
 		"[f]ilter",
 		"[t]ab",
 		"[s]et-status",
@@ -140,9 +142,27 @@ func actionHints(model Model, readyCount int) []string {
 	if model.actionMode == ActionModeParentReview {
 		return []string{"[↑/↓]navigate", "[1-4]select", "[enter]confirm", "[esc]back", "[ctrl+c]quit"}
 	}
+	if model.actionMode == ActionModePatchReview {
+		return []string{"[↑/↓]navigate", "[space]toggle", "[a/n]all", "[enter]apply", "[esc]discard", "[ctrl+c]quit"}
+	}
+	if model.actionMode == ActionModeTemplate {
+		if model.templateForm != nil && model.templateForm.chosen != nil {
+			return []string{"[tab]next", "[enter]add", "[esc]back", "[ctrl+c]quit"}
+		}
+		return []string{"[↑/↓]select", "[enter]choose", "[esc]cancel", "[ctrl+c]quit"}
+	}
+	if model.actionMode == ActionModeSwitchPlan {
+		return []string{"[↑/↓]move", "[enter]select", "[esc]cancel", "[ctrl+c]quit"}
+	}
 	if model.actionMode == ActionModeSelectAgent {
 		return []string{"[↑/↓]move", "[enter]select", "[esc]cancel", "[ctrl+c]quit"}
 	}
+	if model.actionMode == ActionModeQuestionInbox {
+		if model.questionInbox != nil && model.questionInbox.question != nil {
+			return []string{"[enter]confirm", "[esc]back", "[ctrl+c]quit"}
+		}
+		return []string{"[↑/↓]select", "[enter]answer", "[r]esume", "[esc]close", "[ctrl+c]quit"}
+	}
 	if model.actionInProgress {
 		return []string{"[ctrl+c]quit"}
 	}
@@ -157,6 +177,7 @@ func actionHints(model Model, readyCount int) []string {
 			"[e]xecute",
 			"[s]ettings",
 			"[c]hange",
+			"[p]lan",
 			"[ctrl+c]quit",
 		}
 		if agentIsFromEnv() {
@@ -169,6 +190,9 @@ func actionHints(model Model, readyCount int) []string {
 		if !model.canExecute() {
 			actions = removeAction(actions, "[e]xecute")
 		}
+		if model.planExists && hasWaitingTasks(model.plan) {
+			actions = append(actions[:len(actions)-1], "[i]nbox", "[ctrl+c]quit")
+		}
 		return actions
 	}
 	actions = []string{
@@ -177,6 +201,8 @@ func actionHints(model Model, readyCount int) []string {
 		"[r]efine",
 		"[e]xecute",
 		"[s]et-status",
+		"[a]dd",
+		"[z]undo",
 		"[t]ab",
 		"[f]ilter",
 		"[ctrl+c]quit",
@@ -211,6 +237,9 @@ func actionHints(model Model, readyCount int) []string {
 			actions = append([]string{"[u]resume"}, actions...)
 		}
 	}
+	if hasWaitingTasks(model.plan) {
+		actions = append(actions[:len(actions)-1], "[i]nbox", "[ctrl+c]quit")
+	}
 	return actions
 }
 
@@ -35,7 +35,7 @@ func TestBottomBarHomeHints(t *testing.T) {
 	}
 
 	out := RenderBottomBar(model)
-	expected := []string{"[g]enerate", "[v]iew", "[r]efine", "[e]xecute", "[s]ettings", "[c]hange", "[ctrl+c]quit"}
+	expected := []string{"[g]enerate", "[v]iew", "[r]efine", "[e]xecute", "[s]ettings", "[c]hange", "[p]lan", "[ctrl+c]quit"}
 	for _, hint := range expected {
 		if !strings.Contains(out, hint) {
 			t.Fatalf("expected home hint %q in bottom bar, got %q", hint, out)
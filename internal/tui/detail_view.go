@@ -57,6 +57,14 @@ func RenderDetailView(model Model) string {
 		b.WriteString(mutedStyle.Render("(none)") + "\n\n")
 	} else {
 		for _, depID := range it.Deps {
+			if plan.IsExternalDep(depID) {
+				if _, dep, err := plan.ResolveExternalDep(model.plan, depID); err == nil {
+					b.WriteString(fmt.Sprintf("- %s [%s] %s (external)\n", depID, dep.Status, dep.Title))
+				} else {
+					b.WriteString(fmt.Sprintf("- %s [unknown] (external)\n", depID))
+				}
+				continue
+			}
 			dep, ok := model.plan.Items[depID]
 			if !ok {
 				b.WriteString(fmt.Sprintf("- %s [unknown]\n", depID))
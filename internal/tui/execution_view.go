@@ -51,8 +51,9 @@ func RenderExecutionView(model Model) string {
 		writeLogExcerpt(&b, "stderr", model.liveStderr, mutedStyle)
 		b.WriteString("\n")
 	} else if active != nil {
-		writeLogExcerpt(&b, "stdout", active.Stdout, mutedStyle)
-		writeLogExcerpt(&b, "stderr", active.Stderr, mutedStyle)
+		stdout, stderr := active.OutputTail()
+		writeLogExcerpt(&b, "stdout", stdout, mutedStyle)
+		writeLogExcerpt(&b, "stderr", stderr, mutedStyle)
 		b.WriteString("\n")
 	} else {
 		b.WriteString(mutedStyle.Render("(no logs)"))
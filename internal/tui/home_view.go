@@ -165,7 +165,8 @@ func RenderHomeView(m Model) string {
 	}
 	lines = append(lines, statusLines...)
 
-	lines = append(lines, "", mutedStyle.Render(fmt.Sprintf("Agent: %s", agentLabel(m))))
+	lines = append(lines, "", mutedStyle.Render(fmt.Sprintf("Plan: %s", planLabel(m))))
+	lines = append(lines, mutedStyle.Render(fmt.Sprintf("Agent: %s", agentLabel(m))))
 	if m.agentSelectionErr != "" {
 		lines = append(lines, warnStyle.Render(fmt.Sprintf("Agent config warning: %s", m.agentSelectionErr)))
 	}
@@ -187,6 +188,7 @@ func RenderHomeView(m Model) string {
 		renderActionLine("[e]", "Execute", m.canExecute() && !inProgress, shortcutStyle, actionStyle, mutedStyle),
 		renderActionLine("[s]", "Settings", !inProgress, shortcutStyle, actionStyle, mutedStyle),
 		renderActionLine("[c]", "Change agent", !inProgress, shortcutStyle, actionStyle, mutedStyle),
+		renderActionLine("[p]", "Switch plan", !inProgress, shortcutStyle, actionStyle, mutedStyle),
 		renderActionLine("[ctrl+c]", "Quit", true, shortcutStyle, actionStyle, mutedStyle),
 	)
 
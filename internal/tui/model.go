@@ -28,6 +28,10 @@ const (
 	ActionModeSelectAgent
 	ActionModeReviewCheckpoint
 	ActionModeParentReview
+	ActionModeQuestionInbox
+	ActionModePatchReview
+	ActionModeTemplate
+	ActionModeSwitchPlan
 )
 
 type ActivePane int
@@ -110,14 +114,23 @@ type Model struct {
 	reviewCheckpointForm           *ReviewCheckpointForm
 	parentReviewForm               *ParentReviewForm
 	parentReviewResumeState        *ParentReviewForm
+	questionInbox                  *QuestionInboxForm
+	patchReviewForm                *PatchReviewForm
+	templateForm                   *TemplateForm
 	pendingPlanRequest             PendingPlanRequest
 	pendingResumeTask              string
-	agentSelection                 agent.AgentSelection
-	agentSelectionErr              string
-	agentSelectionHighlight        int // index into agent.AgentRegistry when modal is open
-	projectRoot                    string
-	config                         config.ResolvedConfig
-	settings                       SettingsState
+	// continueAfterResume starts execute once the running answer resume
+	// completes (execution.autoContinue).
+	continueAfterResume     bool
+	agentSelection          agent.AgentSelection
+	agentSelectionErr       string
+	agentSelectionHighlight int // index into agent.AgentRegistry when modal is open
+	planChoices             []plan.NamedPlan
+	planChoiceHighlight     int // index into planChoices when the plan switcher is open
+	projectRoot             string
+	planRef                 string // --plan name or path; empty for the default plan
+	config                  config.ResolvedConfig
+	settings                SettingsState
 }
 
 func NewModel(g plan.WorkGraph) Model {
@@ -192,6 +205,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.parentReviewForm != nil {
 			m.parentReviewForm.SetSize(typed.Width, typed.Height)
 		}
+		if m.questionInbox != nil {
+			m.questionInbox.SetSize(typed.Width, typed.Height)
+		}
+		if m.patchReviewForm != nil {
+			m.patchReviewForm.SetSize(typed.Width, typed.Height)
+		}
+		if m.templateForm != nil {
+			m.templateForm.SetSize(typed.Width, typed.Height)
+		}
 
 		return m, nil
 	case spinnerTickMsg:
@@ -220,14 +242,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.actionMode = ActionModeAgentQuestion
 			return m, nil
 		} else if typed.Plan != nil {
+			if m.pendingPlanRequest.kind == PendingPlanRefine && len(typed.Patch) > 0 {
+				form := NewPatchReviewForm(m.pendingPlanRequest.basePlan, typed.Patch, time.Now().UTC())
+				form.SetSize(m.windowWidth, m.windowHeight)
+				m.patchReviewForm = &form
+				m.actionMode = ActionModePatchReview
+				return m, nil
+			}
 			if m.pendingPlanRequest.kind == PendingPlanRefine {
 				m.plan = *typed.Plan
 				m.ensureSelectionVisible()
 				m.pendingPlanRequest = PendingPlanRequest{}
-				return m, SavePlanCmdWithAction(m.plan, "plan refine", "Refined plan saved")
+				return m, SavePlanCmdWithAction(m.planPath(), m.plan, "plan refine", "Refined plan saved")
 			}
 			// Success - show plan review modal
-			form := NewPlanReviewForm(*typed.Plan, m.pendingPlanRequest.questionRound)
+			form := NewPlanReviewForm(m.planPath(), *typed.Plan, m.pendingPlanRequest.questionRound)
 			if typed.Quality != nil {
 				form.SetQualitySummary(*typed.Quality)
 			}
@@ -323,10 +352,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 		}
+		continueExecute := false
 		if typed.Action == "resume" {
 			m.parentReviewResumeState = nil
+			continueExecute = m.continueAfterResume && typed.Err == nil
+			m.continueAfterResume = false
 		}
 		m = m.showNextQueuedParentReview()
+		if continueExecute && m.actionMode == ActionModeNone {
+			// execution.autoContinue: pick up the ready queue after answering.
+			return m.startExecuteAction(true)
+		}
 		if typed.Action == "execute" || typed.Action == "resume" || typed.Action == "set-status" {
 			return m, tea.Batch(m.LoadRunData(), m.LoadPlanData())
 		}
@@ -636,6 +672,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return HandleAgentSelectionKey(m, typed.String())
 			}
 		}
+		if m.actionMode == ActionModePatchReview {
+			switch typed.String() {
+			case "ctrl+c":
+				m = cancelRunningAction(m)
+				return m, tea.Quit
+			default:
+				return HandlePatchReviewKey(m, typed)
+			}
+		}
+		if m.actionMode == ActionModeSwitchPlan {
+			switch typed.String() {
+			case "ctrl+c":
+				m = cancelRunningAction(m)
+				return m, tea.Quit
+			default:
+				return HandlePlanSwitcherKey(m, typed.String())
+			}
+		}
+		if m.actionMode == ActionModeTemplate {
+			switch typed.String() {
+			case "ctrl+c":
+				m = cancelRunningAction(m)
+				return m, tea.Quit
+			default:
+				return HandleTemplateKey(m, typed)
+			}
+		}
+		if m.actionMode == ActionModeQuestionInbox {
+			switch typed.String() {
+			case "ctrl+c":
+				m = cancelRunningAction(m)
+				return m, tea.Quit
+			default:
+				return HandleQuestionInboxKey(m, typed)
+			}
+		}
 		// Clear action output on any key press (after reading)
 		if m.actionOutput != nil && !m.actionInProgress {
 			m.actionOutput = nil
@@ -694,6 +766,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.settings = NewSettingsState(m.projectRoot, m.config)
 				m.viewMode = ViewModeSettings
 				return m, nil
+			case "i":
+				return m.openQuestionInbox()
+			case "p":
+				return m.openPlanSwitcher()
 			default:
 				return m, nil
 			}
@@ -825,6 +901,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.actionMode = ActionModeSetStatus
 			m.pendingStatusID = m.selectedID
 			return m, nil
+		case "a":
+			return m.openTemplatePicker()
+		case "z", "Z":
+			if m.actionMode != ActionModeNone || m.actionInProgress {
+				return m, nil
+			}
+			return m, HistoryStepCmd(m.planPath(), key == "Z")
+		case "i":
+			return m.openQuestionInbox()
 		case "u":
 			if m.actionMode != ActionModeNone || m.actionInProgress {
 				return m, nil
@@ -835,7 +920,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if hasPendingParentFeedbackForTask(m, m.selectedID) {
 				return m.startFeedbackResumeAction([]string{m.selectedID})
 			}
-			questions, err := execution.ParseQuestionsFromLatestWaitingRun(plan.PlanPath(), m.plan, m.selectedID)
+			questions, err := execution.ParseQuestionsFromLatestWaitingRun(m.planPath(), m.plan, m.selectedID)
 			if err != nil {
 				m.actionOutput = &ActionOutput{
 					Message: fmt.Sprintf("Resume failed: %v", err),
@@ -912,6 +997,7 @@ func (m Model) startExecuteAction(includeRefresh bool) (Model, tea.Cmd) {
 	cmds := []tea.Cmd{
 		ExecuteCmdWithContextAndStream(
 			ctx,
+			m.planPath(),
 			stdout,
 			stderr,
 			streamCh,
@@ -920,6 +1006,7 @@ func (m Model) startExecuteAction(includeRefresh bool) (Model, tea.Cmd) {
 			parentReviewAckCh,
 			m.config.Execution.StopAfterEachTask,
 			m.config.Execution.ParentReviewEnabled,
+			m.config.Execution.AutoContinue,
 		),
 		listenLiveOutputCmd(streamCh),
 		listenExecutionStageCmd(stageCh),
@@ -955,9 +1042,9 @@ func (m Model) startFeedbackResumeAction(taskIDs []string) (Model, tea.Cmd) {
 
 	var resumeCmd tea.Cmd
 	if len(normalizedTaskIDs) == 1 {
-		resumeCmd = ResumePendingParentFeedbackCmdWithContextAndStream(ctx, normalizedTaskIDs[0], stdout, stderr, streamCh)
+		resumeCmd = ResumePendingParentFeedbackCmdWithContextAndStream(ctx, m.planPath(), normalizedTaskIDs[0], stdout, stderr, streamCh)
 	} else {
-		resumeCmd = ResumePendingParentFeedbackTargetsCmdWithContextAndStream(ctx, normalizedTaskIDs, stdout, stderr, streamCh)
+		resumeCmd = ResumePendingParentFeedbackTargetsCmdWithContextAndStream(ctx, m.planPath(), normalizedTaskIDs, stdout, stderr, streamCh)
 	}
 
 	return m, tea.Batch(
@@ -994,6 +1081,7 @@ func (m Model) startParentReviewResumeAction(
 	if len(normalizedTargets) == 1 {
 		resumeCmd = ResumePendingParentFeedbackTargetCmdWithContextAndStream(
 			ctx,
+			m.planPath(),
 			normalizedTargets[0],
 			stdout,
 			stderr,
@@ -1002,6 +1090,7 @@ func (m Model) startParentReviewResumeAction(
 	} else {
 		resumeCmd = ResumePendingParentFeedbackTargetsWithFeedbackCmdWithContextAndStream(
 			ctx,
+			m.planPath(),
 			normalizedTargets,
 			stdout,
 			stderr,
@@ -1035,6 +1124,7 @@ func cancelRunningAction(m Model) Model {
 		m.actionCancel()
 		m.actionCancel = nil
 	}
+	m.continueAfterResume = false
 	return m
 }
 
@@ -1173,6 +1263,50 @@ func (m Model) View() string {
 		}
 	}
 
+	// Overlay patch review modal if active
+	if m.actionMode == ActionModePatchReview && m.patchReviewForm != nil {
+		modal := RenderPatchReviewModal(modalModel, *m.patchReviewForm)
+		if modal != "" {
+			content = modal
+			if banner != "" {
+				content = banner + "\n" + content
+			}
+		}
+	}
+
+	// Overlay plan switcher if active
+	if m.actionMode == ActionModeSwitchPlan {
+		modal := RenderPlanSwitcherModal(modalModel)
+		if modal != "" {
+			content = modal
+			if banner != "" {
+				content = banner + "\n" + content
+			}
+		}
+	}
+
+	// Overlay template modal if active
+	if m.actionMode == ActionModeTemplate && m.templateForm != nil {
+		modal := RenderTemplateModal(modalModel, *m.templateForm)
+		if modal != "" {
+			content = modal
+			if banner != "" {
+				content = banner + "\n" + content
+			}
+		}
+	}
+
+	// Overlay question inbox if active
+	if m.actionMode == ActionModeQuestionInbox && m.questionInbox != nil {
+		modal := RenderQuestionInboxModal(modalModel, *m.questionInbox)
+		if modal != "" {
+			content = modal
+			if banner != "" {
+				content = banner + "\n" + content
+			}
+		}
+	}
+
 	// Overlay agent selection modal if active
 	if m.actionMode == ActionModeSelectAgent {
 		modal := RenderAgentSelectionModal(modalModel)
@@ -562,3 +562,37 @@ func testExecuteActionCompleteParentReviewPassRun(runID string) execution.RunRec
 		},
 	}
 }
+
+func TestExecuteActionCompleteAnswerResumeAutoContinueStartsExecute(t *testing.T) {
+	model := Model{
+		viewMode:            ViewModeMain,
+		planExists:          true,
+		actionInProgress:    true,
+		actionName:          "Resuming...",
+		actionCancel:        func() {},
+		continueAfterResume: true,
+	}
+
+	updatedModel, cmd := model.Update(ExecuteActionComplete{
+		Action:  "resume",
+		Success: true,
+		Output:  "completed task-7",
+	})
+	updated := updatedModel.(Model)
+	if cmd == nil {
+		t.Fatalf("expected execute command")
+	}
+	if !updated.actionInProgress || updated.actionName != "Executing..." {
+		t.Fatalf("expected execute to start, got inProgress=%v name=%q", updated.actionInProgress, updated.actionName)
+	}
+	if updated.continueAfterResume {
+		t.Fatalf("expected continueAfterResume to clear")
+	}
+	cancelRunningAction(updated)
+
+	failed := Model{actionInProgress: true, continueAfterResume: true}
+	updatedModel, _ = failed.Update(ExecuteActionComplete{Action: "resume", Err: errors.New("boom")})
+	if got := updatedModel.(Model); got.actionInProgress || got.continueAfterResume {
+		t.Fatalf("expected failed resume not to continue execution")
+	}
+}
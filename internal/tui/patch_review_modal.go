@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// PatchReviewForm lists the ops of a refine patch so each can be accepted or
+// rejected before the partial patch is applied to the base plan.
+type PatchReviewForm struct {
+	base     plan.WorkGraph
+	ops      []agent.PatchOp
+	reviews  []agent.OpReview
+	accepted []bool
+	selected int
+	err      string
+	now      time.Time
+	width    int
+	height   int
+}
+
+// NewPatchReviewForm starts with every op that applies accepted.
+func NewPatchReviewForm(base plan.WorkGraph, ops []agent.PatchOp, now time.Time) PatchReviewForm {
+	reviews := agent.ReviewPatch(base, ops, now)
+	accepted := make([]bool, len(ops))
+	for i, review := range reviews {
+		accepted[i] = review.Err == nil
+	}
+	return PatchReviewForm{
+		base:     plan.Clone(base),
+		ops:      ops,
+		reviews:  reviews,
+		accepted: accepted,
+		now:      now,
+		width:    90,
+		height:   30,
+	}
+}
+
+func (f *PatchReviewForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+// AcceptedCount returns how many ops are currently accepted.
+func (f PatchReviewForm) AcceptedCount() int {
+	count := 0
+	for _, ok := range f.accepted {
+		if ok {
+			count++
+		}
+	}
+	return count
+}
+
+func (f *PatchReviewForm) setAll(accepted bool) {
+	for i := range f.accepted {
+		f.accepted[i] = accepted
+	}
+	f.refresh()
+}
+
+// refresh re-reviews every op against the accepted ops before it and
+// unchecks the ops that no longer apply.
+func (f *PatchReviewForm) refresh() {
+	f.reviews = agent.ReviewSelectedOps(f.base, f.ops, f.accepted, f.now)
+	for i, review := range f.reviews {
+		if review.Err != nil {
+			f.accepted[i] = false
+		}
+	}
+}
+
+// HandlePatchReviewKey handles key presses while the patch review is open.
+func HandlePatchReviewKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.patchReviewForm == nil {
+		m.actionMode = ActionModeNone
+		return m, nil
+	}
+	form := *m.patchReviewForm
+
+	switch msg.String() {
+	case "esc":
+		m.actionMode = ActionModeNone
+		m.patchReviewForm = nil
+		m.pendingPlanRequest = PendingPlanRequest{}
+		m.actionOutput = &ActionOutput{Message: "Plan refine discarded", IsError: false}
+		return m, nil
+	case "up", "k":
+		if form.selected > 0 {
+			form.selected--
+		}
+	case "down", "j":
+		if form.selected < len(form.reviews)-1 {
+			form.selected++
+		}
+	case " ", "x":
+		if len(form.reviews) > 0 && form.reviews[form.selected].Err == nil {
+			form.accepted[form.selected] = !form.accepted[form.selected]
+			form.refresh()
+		}
+		form.err = ""
+	case "a":
+		form.setAll(true)
+		form.err = ""
+	case "n":
+		form.setAll(false)
+		form.err = ""
+	case "enter":
+		if form.AcceptedCount() == 0 {
+			m.actionMode = ActionModeNone
+			m.patchReviewForm = nil
+			m.pendingPlanRequest = PendingPlanRequest{}
+			m.actionOutput = &ActionOutput{Message: "No operations accepted; plan unchanged", IsError: false}
+			return m, nil
+		}
+		next, err := agent.ApplySelectedOps(form.base, form.ops, form.accepted, form.now)
+		if err != nil {
+			form.err = err.Error()
+			m.patchReviewForm = &form
+			return m, nil
+		}
+		message := fmt.Sprintf("Refined plan saved (%d of %d operations)", form.AcceptedCount(), len(form.ops))
+		m.actionMode = ActionModeNone
+		m.patchReviewForm = nil
+		m.pendingPlanRequest = PendingPlanRequest{}
+		m.plan = next
+		m.ensureSelectionVisible()
+		return m, SavePlanCmdWithAction(m.planPath(), m.plan, "plan refine", message)
+	}
+	m.patchReviewForm = &form
+	return m, nil
+}
+
+// RenderPatchReviewModal renders the op list and the changes of the selected op.
+func RenderPatchReviewModal(m Model, form PatchReviewForm) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	selectedStyle := textStyle.Copy().Background(lipgloss.Color("69")).Bold(true)
+	removedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	addedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+
+	lines := []string{
+		titleStyle.Render("Review refine operations"),
+		labelStyle.Render(fmt.Sprintf("%d of %d operation(s) accepted", form.AcceptedCount(), len(form.ops))),
+		"",
+	}
+	for i, review := range form.reviews {
+		box := "[ ] "
+		if form.accepted[i] {
+			box = "[x] "
+		}
+		if review.Err != nil {
+			box = "[!] "
+		}
+		row := box + review.Summary()
+		if i == form.selected {
+			lines = append(lines, selectedStyle.Render(row))
+		} else {
+			lines = append(lines, textStyle.Render(row))
+		}
+	}
+
+	if len(form.reviews) > 0 {
+		review := form.reviews[form.selected]
+		lines = append(lines, "")
+		if review.Err != nil {
+			lines = append(lines, removedStyle.Render("Does not apply: "+review.Err.Error()))
+		}
+		for _, change := range review.Changes {
+			lines = append(lines, labelStyle.Render(change.Field+":"))
+			switch {
+			case change.Reordered:
+				lines = append(lines, textStyle.Render("  reordered"))
+			case len(change.Lines) > 0:
+				for _, line := range change.Lines {
+					style := textStyle
+					switch line.Op {
+					case plan.LineRemoved:
+						style = removedStyle
+					case plan.LineAdded:
+						style = addedStyle
+					}
+					lines = append(lines, style.Render("  "+string(line.Op)+" "+line.Text))
+				}
+			case len(change.Removed) > 0 || len(change.Added) > 0:
+				for _, line := range change.Removed {
+					lines = append(lines, removedStyle.Render("  - "+line))
+				}
+				for _, line := range change.Added {
+					lines = append(lines, addedStyle.Render("  + "+line))
+				}
+			default:
+				if change.Before != "" {
+					lines = append(lines, removedStyle.Render("  - "+change.Before))
+				}
+				if change.After != "" {
+					lines = append(lines, addedStyle.Render("  + "+change.After))
+				}
+			}
+		}
+		for _, line := range review.Rationale {
+			lines = append(lines, labelStyle.Render("why: "+line))
+		}
+	}
+	if form.err != "" {
+		lines = append(lines, "", removedStyle.Render(form.err))
+	}
+	lines = append(lines, "", labelStyle.Render("↑/↓: select • Space: toggle • a/n: accept/reject all • Enter: apply • ESC: discard"))
+
+	modalWidth := form.width
+	if modalWidth > 100 {
+		modalWidth = 100
+	}
+	if modalWidth < 50 {
+		modalWidth = 50
+	}
+	if m.windowWidth > 0 && m.windowWidth < modalWidth+4 {
+		modalWidth = m.windowWidth - 4
+	}
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("214")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	if m.windowHeight > 0 {
+		topPadding := (m.windowHeight - lipgloss.Height(modal)) / 2
+		if topPadding > 0 {
+			return lipgloss.NewStyle().PaddingTop(topPadding).Render(modal)
+		}
+	}
+	return modal
+}
@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func patchReviewTestModel() (Model, []agent.PatchOp) {
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	item := func(id string) plan.WorkItem {
+		return plan.WorkItem{ID: id, Title: "Task " + id, AcceptanceCriteria: []string{}, Prompt: "do it", ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo, CreatedAt: now, UpdatedAt: now}
+	}
+	base := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"a": item("a"), "b": item("b")}}
+	renamed := item("a")
+	renamed.Title = "Task a, renamed"
+	ops := []agent.PatchOp{
+		{Op: agent.PatchUpdate, ID: "a", Item: &renamed, Rationale: "clearer"},
+		{Op: agent.PatchAddDep, ID: "b", DepID: "a"},
+	}
+	m := Model{
+		plan:               base,
+		planExists:         true,
+		actionInProgress:   true,
+		pendingPlanRequest: PendingPlanRequest{kind: PendingPlanRefine, basePlan: plan.Clone(base)},
+	}
+	return m, ops
+}
+
+func TestPatchReviewAppliesOnlyAcceptedOps(t *testing.T) {
+	m, ops := patchReviewTestModel()
+	refined := plan.Clone(m.plan)
+	updated, _ := m.Update(PlanGenerateInMemoryResult{Success: true, Plan: &refined, Patch: ops})
+	m = updated.(Model)
+	if m.actionMode != ActionModePatchReview || m.patchReviewForm == nil {
+		t.Fatalf("expected patch review modal, got mode %v", m.actionMode)
+	}
+	if got := m.patchReviewForm.AcceptedCount(); got != 2 {
+		t.Fatalf("accepted = %d, want all ops accepted initially", got)
+	}
+
+	view := RenderPatchReviewModal(m, *m.patchReviewForm)
+	for _, want := range []string{`[x] update a "Task a, renamed"`, "- Task a", "+ Task a, renamed", "why: clearer"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	m, _ = HandlePatchReviewKey(m, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	m, cmd := HandlePatchReviewKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.actionMode != ActionModeNone || m.patchReviewForm != nil {
+		t.Fatalf("expected save after apply, mode %v", m.actionMode)
+	}
+	if m.plan.Items["a"].Title != "Task a" || len(m.plan.Items["b"].Deps) != 1 {
+		t.Fatalf("plan = %+v", m.plan.Items)
+	}
+}
+
+func TestPatchReviewEscDiscardsRefine(t *testing.T) {
+	m, ops := patchReviewTestModel()
+	form := NewPatchReviewForm(m.plan, ops, time.Now())
+	m.patchReviewForm = &form
+	m.actionMode = ActionModePatchReview
+
+	m, cmd := HandlePatchReviewKey(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd != nil || m.actionMode != ActionModeNone || m.patchReviewForm != nil {
+		t.Fatalf("expected modal closed without save")
+	}
+	if m.plan.Items["a"].Title != "Task a" || len(m.plan.Items["b"].Deps) != 0 {
+		t.Fatalf("plan changed: %+v", m.plan.Items)
+	}
+}
+
+func TestPatchReviewUnchecksOpsOnRejectedAdds(t *testing.T) {
+	m, _ := patchReviewTestModel()
+	added := plan.WorkItem{ID: "c", Title: "Task c", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo}
+	ops := []agent.PatchOp{
+		{Op: agent.PatchAdd, Item: &added},
+		{Op: agent.PatchAddDep, ID: "c", DepID: "a"},
+	}
+	form := NewPatchReviewForm(m.plan, ops, time.Now())
+	m.patchReviewForm = &form
+	m.actionMode = ActionModePatchReview
+
+	m, _ = HandlePatchReviewKey(m, tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if got := m.patchReviewForm.AcceptedCount(); got != 0 {
+		t.Fatalf("accepted = %d, want the add_dep unchecked with its add", got)
+	}
+	if view := RenderPatchReviewModal(m, *m.patchReviewForm); !strings.Contains(view, "[!] add_dep c -> a") {
+		t.Fatalf("expected the add_dep marked as not applying:\n%s", view)
+	}
+}
@@ -513,7 +513,7 @@ func HandlePlanGenerateKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 
 		// Use the in-memory generation with the form inputs
 		return m, tea.Batch(
-			GeneratePlanInMemory(context.Background(), description, constraints, granularity),
+			GeneratePlanInMemory(context.Background(), m.planPath(), description, constraints, granularity),
 			spinnerTickCmd(),
 		)
 	}
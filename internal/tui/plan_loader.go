@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -22,10 +21,7 @@ type planDataRefreshMsg struct{}
 
 func (m Model) LoadPlanData() tea.Cmd {
 	return func() tea.Msg {
-		path := plan.PlanPath()
-		if m.projectRoot != "" {
-			path = filepath.Join(m.projectRoot, plan.DefaultPlanFilename)
-		}
+		path := m.planPath()
 		g, err := plan.Load(path)
 		if err != nil {
 			if errors.Is(err, plan.ErrPlanNotFound) || os.IsNotExist(err) {
@@ -37,7 +37,7 @@ func TestRefinePlanInMemoryRunsInProcess(t *testing.T) {
 		},
 	}
 
-	cmd := RefinePlanInMemory(context.Background(), "Change the plan", base)
+	cmd := RefinePlanInMemory(context.Background(), plan.PlanPath(), "Change the plan", base)
 	msg := cmd()
 	result, ok := msg.(PlanGenerateInMemoryResult)
 	if !ok {
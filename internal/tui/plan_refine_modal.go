@@ -264,7 +264,7 @@ func HandlePlanRefineKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 		m.actionName = "Refining plan..."
 
 		return m, tea.Batch(
-			RefinePlanInMemory(context.Background(), changeRequest, basePlan),
+			RefinePlanInMemory(context.Background(), m.planPath(), changeRequest, basePlan),
 			spinnerTickCmd(),
 		)
 	}
@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/jbonatakis/blackbird/internal/agent"
 	"github.com/jbonatakis/blackbird/internal/plan"
+	"github.com/jbonatakis/blackbird/internal/plangen"
 	"github.com/jbonatakis/blackbird/internal/planquality"
 )
 
@@ -52,7 +53,7 @@ type PlanReviewForm struct {
 }
 
 // NewPlanReviewForm creates a new plan review form
-func NewPlanReviewForm(generatedPlan plan.WorkGraph, revisionCount int) PlanReviewForm {
+func NewPlanReviewForm(planPath string, generatedPlan plan.WorkGraph, revisionCount int) PlanReviewForm {
 	// Initialize revision textarea
 	revisionTA := textarea.New()
 	revisionTA.Placeholder = "Enter revision request (describe desired changes)..."
@@ -64,7 +65,7 @@ func NewPlanReviewForm(generatedPlan plan.WorkGraph, revisionCount int) PlanRevi
 	form := PlanReviewForm{
 		mode:             ReviewModeChooseAction,
 		plan:             generatedPlan,
-		qualitySummary:   defaultPlanReviewQualitySummary(generatedPlan),
+		qualitySummary:   defaultPlanReviewQualitySummary(planPath, generatedPlan),
 		selectedAction:   planReviewActionAccept,
 		revisionTextarea: revisionTA,
 		width:            80,
@@ -214,8 +215,14 @@ func (f *PlanReviewForm) SetQualitySummary(summary PlanReviewQualitySummary) {
 	f.resetDefaultSelection()
 }
 
-func defaultPlanReviewQualitySummary(generatedPlan plan.WorkGraph) PlanReviewQualitySummary {
-	findings := planquality.Lint(generatedPlan)
+func defaultPlanReviewQualitySummary(planPath string, generatedPlan plan.WorkGraph) PlanReviewQualitySummary {
+	// An invalid planning.quality section is reported by the quality gate;
+	// the review summary falls back to the built-in rules.
+	rules, err := plangen.ResolveQualityRulesFromPlanPath(planPath)
+	if err != nil {
+		rules = planquality.DefaultRules()
+	}
+	findings := planquality.LintWithRules(generatedPlan, rules)
 	return planReviewQualitySummaryFromFindings(findings, findings, 0)
 }
 
@@ -335,7 +342,7 @@ func HandlePlanReviewKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.pendingPlanRequest.questionRound = revisionCount
 
 			return m, tea.Batch(
-				RefinePlanInMemory(context.Background(), revisionRequest, currentPlan),
+				RefinePlanInMemory(context.Background(), m.planPath(), revisionRequest, currentPlan),
 				spinnerTickCmd(),
 			)
 		case "esc":
@@ -380,7 +387,7 @@ func acceptPlan(m Model) (Model, tea.Cmd) {
 	}
 
 	// Save plan to disk
-	return m, SavePlanCmd(m.plan)
+	return m, SavePlanCmd(m.planPath(), m.plan)
 }
 
 func acceptPlanAnyway(m Model) (Model, tea.Cmd) {
@@ -400,6 +407,7 @@ func acceptPlanAnyway(m Model) (Model, tea.Cmd) {
 	}
 
 	return m, SavePlanCmdWithAction(
+		m.planPath(),
 		m.plan,
 		"save plan override",
 		"WARNING: blocking findings were overridden; saved plan anyway",
@@ -575,14 +583,13 @@ func RenderPlanReviewModal(m Model, form PlanReviewForm) string {
 }
 
 // SavePlanCmd saves the plan to disk
-func SavePlanCmd(g plan.WorkGraph) tea.Cmd {
-	return SavePlanCmdWithAction(g, "save plan", "")
+func SavePlanCmd(path string, g plan.WorkGraph) tea.Cmd {
+	return SavePlanCmdWithAction(path, g, "save plan", "")
 }
 
-func SavePlanCmdWithAction(g plan.WorkGraph, action string, message string) tea.Cmd {
+func SavePlanCmdWithAction(path string, g plan.WorkGraph, action string, message string) tea.Cmd {
 	return func() tea.Msg {
-		path := plan.PlanPath()
-		if err := plan.SaveAtomic(path, g); err != nil {
+		if err := plan.SaveWithHistory(path, g, action); err != nil {
 			return PlanActionComplete{
 				Action:  action,
 				Success: false,
@@ -14,7 +14,7 @@ import (
 // TestPlanReviewFormCreation tests that a new plan review form is created correctly
 func TestPlanReviewFormCreation(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 
 	if form.mode != ReviewModeChooseAction {
 		t.Errorf("Expected initial mode to be ReviewModeChooseAction, got %v", form.mode)
@@ -39,7 +39,7 @@ func TestPlanReviewFormCreation(t *testing.T) {
 
 func TestRenderPlanReviewModalQualitySummaryNoFindings(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 0,
 		InitialWarningCount:  0,
@@ -76,7 +76,7 @@ func TestRenderPlanReviewModalQualitySummaryNoFindings(t *testing.T) {
 
 func TestRenderPlanReviewModalQualitySummaryBlockingAfterAutoRefine(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 2,
 		InitialWarningCount:  1,
@@ -188,7 +188,7 @@ func TestBuildPlanReviewQualitySummaryDeterministicCountsAndKeyFindings(t *testi
 
 func TestRenderPlanReviewModalQualitySummaryPluralPassesNoBlockingRemain(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 2,
 		InitialWarningCount:  1,
@@ -227,7 +227,7 @@ func TestRenderPlanReviewModalQualitySummaryPluralPassesNoBlockingRemain(t *test
 // TestPlanReviewNavigationUpDown tests navigation between actions
 func TestPlanReviewNavigationUpDown(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 0,
 		InitialWarningCount:  0,
@@ -280,7 +280,7 @@ func TestPlanReviewNavigationUpDown(t *testing.T) {
 // TestPlanReviewQuickSelect tests number key quick selection
 func TestPlanReviewQuickSelect(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 0,
 		InitialWarningCount:  0,
@@ -309,7 +309,7 @@ func TestPlanReviewQuickSelect(t *testing.T) {
 
 func TestPlanReviewBlockingDefaultAction(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 1,
 		InitialWarningCount:  0,
@@ -331,7 +331,7 @@ func TestPlanReviewBlockingDefaultAction(t *testing.T) {
 func TestPlanReviewRevisionLimitBlocking(t *testing.T) {
 	testPlan := createTestPlan()
 	// Create form with revision count at the limit
-	form := NewPlanReviewForm(testPlan, agent.MaxPlanGenerateRevisions)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, agent.MaxPlanGenerateRevisions)
 
 	if form.CanRevise() {
 		t.Error("Expected CanRevise to return false when at revision limit")
@@ -347,7 +347,7 @@ func TestPlanReviewRevisionLimitBlocking(t *testing.T) {
 
 func TestPlanReviewBlockingDefaultsToRejectWhenRevisionLimitReached(t *testing.T) {
 	testPlan := createTestPlan()
-	form := NewPlanReviewForm(testPlan, agent.MaxPlanGenerateRevisions)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, agent.MaxPlanGenerateRevisions)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 1,
 		InitialWarningCount:  0,
@@ -368,7 +368,7 @@ func TestPlanReviewRevisionMode(t *testing.T) {
 	m.windowHeight = 30
 
 	// Create review form and set it on model
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 1,
 		InitialWarningCount:  0,
@@ -399,7 +399,7 @@ func TestPlanReviewAccept(t *testing.T) {
 	m.windowHeight = 30
 
 	// Create review form with Accept selected
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 0,
 		InitialWarningCount:  0,
@@ -441,7 +441,7 @@ func TestPlanReviewReject(t *testing.T) {
 	newPlan := createTestPlanWithDifferentItems()
 
 	// Create review form with Reject selected
-	form := NewPlanReviewForm(newPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), newPlan, 0)
 	form.selectedAction = planReviewActionReject
 	m.planReviewForm = &form
 	m.actionMode = ActionModePlanReview
@@ -464,6 +464,7 @@ func TestPlanReviewReject(t *testing.T) {
 }
 
 func TestPlanReviewAcceptAnywayWithBlocking(t *testing.T) {
+	t.Chdir(t.TempDir())
 	testPlan := createTestPlan()
 	m := NewModel(plan.NewEmptyWorkGraph())
 	m.windowWidth = 100
@@ -471,7 +472,7 @@ func TestPlanReviewAcceptAnywayWithBlocking(t *testing.T) {
 	m.planExists = false
 	m.viewMode = ViewModeHome
 
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.SetQualitySummary(PlanReviewQualitySummary{
 		InitialBlockingCount: 2,
 		InitialWarningCount:  1,
@@ -515,7 +516,7 @@ func TestPlanReviewRevisionPromptValidation(t *testing.T) {
 	m.windowHeight = 30
 
 	// Create review form in revision prompt mode
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.mode = ReviewModeRevisionPrompt
 	m.planReviewForm = &form
 	m.actionMode = ActionModePlanReview
@@ -537,7 +538,7 @@ func TestPlanReviewEscapeFromRevisionPrompt(t *testing.T) {
 	m.windowHeight = 30
 
 	// Create review form in revision prompt mode
-	form := NewPlanReviewForm(testPlan, 0)
+	form := NewPlanReviewForm(plan.PlanPath(), testPlan, 0)
 	form.mode = ReviewModeRevisionPrompt
 	m.planReviewForm = &form
 	m.actionMode = ActionModePlanReview
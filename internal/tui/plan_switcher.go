@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// planPath returns the path of the plan the TUI is showing: planRef (from
+// --plan or BLACKBIRD_PLAN at startup, then the plan switcher) resolved
+// against the project root. Commands take this path rather than reading the
+// environment, so a switch never changes the plan under a running command.
+func (m Model) planPath() string {
+	if m.projectRoot != "" {
+		return plan.ResolvePlanPath(m.projectRoot, m.planRef)
+	}
+	return plan.PlanPath()
+}
+
+// planLabel names the current plan for display.
+func planLabel(m Model) string {
+	if m.planRef == "" {
+		return "(default)"
+	}
+	return m.planRef
+}
+
+// openPlanSwitcher lists the project's plans, highlighting the current one.
+func (m Model) openPlanSwitcher() (Model, tea.Cmd) {
+	if m.actionMode != ActionModeNone || m.actionInProgress {
+		return m, nil
+	}
+	root := m.projectRoot
+	if root == "" {
+		root = plan.ProjectRoot(m.planPath())
+	}
+	plans, err := plan.ListPlans(root)
+	if err != nil {
+		m.actionOutput = &ActionOutput{Message: fmt.Sprintf("List plans failed: %v", err), IsError: true}
+		return m, nil
+	}
+	if len(plans) == 0 {
+		m.actionOutput = &ActionOutput{Message: "No plans found", IsError: false}
+		return m, nil
+	}
+	m.planChoices = plans
+	m.planChoiceHighlight = 0
+	current := filepath.Clean(m.planPath())
+	for i, p := range plans {
+		if filepath.Clean(p.Path) == current {
+			m.planChoiceHighlight = i
+		}
+	}
+	m.actionMode = ActionModeSwitchPlan
+	return m, nil
+}
+
+// RenderPlanSwitcherModal renders the plan switcher modal.
+func RenderPlanSwitcherModal(m Model) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("69"))
+	itemStyle := lipgloss.NewStyle().Padding(0, 2)
+	highlightStyle := itemStyle.Copy().Foreground(lipgloss.Color("46")).Background(lipgloss.Color("236"))
+	mutedStyle := itemStyle.Copy().Foreground(lipgloss.Color("240"))
+
+	lines := []string{
+		titleStyle.Render("Switch plan:"),
+		fmt.Sprintf("Current: %s", planLabel(m)),
+		"",
+	}
+	current := filepath.Clean(m.planPath())
+	for i, p := range m.planChoices {
+		line := p.Name
+		if line == "" {
+			line = "(default)"
+		}
+		if filepath.Clean(p.Path) == current {
+			line += " (current)"
+		}
+		if i == m.planChoiceHighlight {
+			lines = append(lines, highlightStyle.Render("  "+line))
+		} else {
+			lines = append(lines, itemStyle.Render("  "+line))
+		}
+	}
+	lines = append(lines, "", mutedStyle.Render("[↑/↓] move  [enter] select  [esc] cancel"))
+
+	modalWidth := 50
+	if m.windowWidth > 0 && m.windowWidth < modalWidth+4 {
+		modalWidth = m.windowWidth - 4
+	}
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("69")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	if m.windowHeight > 0 {
+		topPadding := (m.windowHeight - lipgloss.Height(modal)) / 2
+		if topPadding > 0 {
+			return lipgloss.NewStyle().PaddingTop(topPadding).Render(modal)
+		}
+	}
+	return modal
+}
+
+// HandlePlanSwitcherKey handles key presses in plan switcher mode. Selecting a
+// plan makes it the model's plan, so saves, runs and agent selection follow,
+// and reloads the plan and its agent selection.
+func HandlePlanSwitcherKey(m Model, key string) (Model, tea.Cmd) {
+	n := len(m.planChoices)
+	switch key {
+	case "esc":
+		m.actionMode = ActionModeNone
+		m.planChoices = nil
+		return m, nil
+	case "up", "k":
+		if n > 0 {
+			m.planChoiceHighlight = (m.planChoiceHighlight - 1 + n) % n
+		}
+		return m, nil
+	case "down", "j":
+		if n > 0 {
+			m.planChoiceHighlight = (m.planChoiceHighlight + 1) % n
+		}
+		return m, nil
+	case "enter", " ":
+		if n == 0 {
+			m.actionMode = ActionModeNone
+			return m, nil
+		}
+		selected := m.planChoices[m.planChoiceHighlight]
+		m.actionMode = ActionModeNone
+		m.planChoices = nil
+		m.planRef = selected.Name
+		m.plan = plan.NewEmptyWorkGraph()
+		m.planExists = false
+		m.planValidationErr = ""
+		m.selectedID = ""
+		m.runData = map[string]execution.RunRecord{}
+		m.pendingParentFeedback = map[string]execution.PendingParentReviewFeedback{}
+		m.actionOutput = &ActionOutput{Message: fmt.Sprintf("Switched to plan %s", planLabel(m)), IsError: false}
+		return m, tea.Batch(m.LoadPlanData(), m.LoadAgentSelection())
+	default:
+		return m, nil
+	}
+}
@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestPlanSwitcherSelectsNamedPlan(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv(plan.PlanEnvVar, "")
+	if err := plan.SaveAtomic(filepath.Join(root, plan.DefaultPlanFilename), plan.NewEmptyWorkGraph()); err != nil {
+		t.Fatalf("save default plan: %v", err)
+	}
+	g := plan.NewEmptyWorkGraph()
+	namedPath := filepath.Join(plan.NamedPlanDir(root, "migration"), plan.DefaultPlanFilename)
+	if err := plan.SaveAtomic(namedPath, g); err != nil {
+		t.Fatalf("save named plan: %v", err)
+	}
+
+	m := Model{viewMode: ViewModeHome, projectRoot: root, planExists: true}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m = updated.(Model)
+	if m.actionMode != ActionModeSwitchPlan || len(m.planChoices) != 2 {
+		t.Fatalf("expected plan switcher with 2 plans, got mode %v, %v", m.actionMode, m.planChoices)
+	}
+	if view := RenderPlanSwitcherModal(m); !strings.Contains(view, "(default) (current)") || !strings.Contains(view, "migration") {
+		t.Fatalf("unexpected switcher view:\n%s", view)
+	}
+
+	m, _ = HandlePlanSwitcherKey(m, "down")
+	m, cmd := HandlePlanSwitcherKey(m, "enter")
+	if cmd == nil || m.actionMode != ActionModeNone {
+		t.Fatalf("expected reload after switch, mode %v", m.actionMode)
+	}
+	if got := os.Getenv(plan.PlanEnvVar); got != "" {
+		t.Fatalf("switching plans changed %s to %q", plan.PlanEnvVar, got)
+	}
+	if got := m.planPath(); got != namedPath {
+		t.Fatalf("planPath = %q, want %q", got, namedPath)
+	}
+	if view := RenderHomeView(m); !strings.Contains(view, "Plan: migration") {
+		t.Fatalf("home view missing plan label:\n%s", view)
+	}
+}
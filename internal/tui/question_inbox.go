@@ -0,0 +1,254 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jbonatakis/blackbird/internal/agent"
+	"github.com/jbonatakis/blackbird/internal/execution"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// QuestionInboxForm lists the pending questions of every waiting_user task.
+// Answers are collected per task and resumed together.
+type QuestionInboxForm struct {
+	entries  []execution.PendingQuestions
+	selected int
+	answers  map[string][]agent.Answer
+	// question is the answer form for the selected task while it is open.
+	question *AgentQuestionForm
+	now      time.Time
+	width    int
+	height   int
+}
+
+func NewQuestionInboxForm(entries []execution.PendingQuestions, now time.Time) QuestionInboxForm {
+	return QuestionInboxForm{
+		entries: entries,
+		answers: map[string][]agent.Answer{},
+		now:     now,
+		width:   90,
+		height:  30,
+	}
+}
+
+func (f *QuestionInboxForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+	if f.question != nil {
+		f.question.SetSize(width, height)
+	}
+}
+
+// AnswerSets returns the answered tasks in inbox order.
+func (f QuestionInboxForm) AnswerSets() []execution.AnswerSet {
+	sets := make([]execution.AnswerSet, 0, len(f.answers))
+	for _, entry := range f.entries {
+		if answers, ok := f.answers[entry.TaskID]; ok {
+			sets = append(sets, execution.AnswerSet{TaskID: entry.TaskID, Answers: answers})
+		}
+	}
+	return sets
+}
+
+// openQuestionInbox loads pending questions and shows the inbox.
+func (m Model) openQuestionInbox() (Model, tea.Cmd) {
+	if m.actionMode != ActionModeNone || m.actionInProgress || !m.planExists {
+		return m, nil
+	}
+	entries, err := execution.ListPendingQuestions(m.planPath(), m.plan)
+	if err != nil {
+		m.actionOutput = &ActionOutput{Message: fmt.Sprintf("Questions failed: %v", err), IsError: true}
+		return m, nil
+	}
+	if len(entries) == 0 {
+		m.actionOutput = &ActionOutput{Message: "No pending questions", IsError: false}
+		return m, nil
+	}
+	form := NewQuestionInboxForm(entries, time.Now())
+	form.SetSize(m.windowWidth, m.windowHeight)
+	m.questionInbox = &form
+	m.actionMode = ActionModeQuestionInbox
+	return m, nil
+}
+
+// HandleQuestionInboxKey handles key presses while the inbox is open.
+func HandleQuestionInboxKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.questionInbox == nil {
+		m.actionMode = ActionModeNone
+		return m, nil
+	}
+	form := *m.questionInbox
+
+	if form.question != nil {
+		if msg.String() == "esc" {
+			form.question = nil
+			m.questionInbox = &form
+			return m, nil
+		}
+		updated, cmd := form.question.Update(msg)
+		if updated.IsComplete() {
+			form.answers[form.entries[form.selected].TaskID] = updated.GetAnswers()
+			form.question = nil
+			form.selectNextUnanswered()
+		} else {
+			form.question = &updated
+		}
+		m.questionInbox = &form
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.actionMode = ActionModeNone
+		m.questionInbox = nil
+		return m, nil
+	case "up", "k":
+		if form.selected > 0 {
+			form.selected--
+		}
+	case "down", "j":
+		if form.selected < len(form.entries)-1 {
+			form.selected++
+		}
+	case "enter":
+		question := NewAgentQuestionForm(form.entries[form.selected].Questions)
+		question.SetSize(form.width, form.height)
+		form.question = &question
+	case "x":
+		delete(form.answers, form.entries[form.selected].TaskID)
+	case "r":
+		sets := form.AnswerSets()
+		if len(sets) == 0 {
+			return m, nil
+		}
+		m.actionMode = ActionModeNone
+		m.questionInbox = nil
+		return m.startAnsweredResumeAction(sets)
+	}
+	m.questionInbox = &form
+	return m, nil
+}
+
+func (f *QuestionInboxForm) selectNextUnanswered() {
+	for offset := 1; offset <= len(f.entries); offset++ {
+		idx := (f.selected + offset) % len(f.entries)
+		if _, ok := f.answers[f.entries[idx].TaskID]; !ok {
+			f.selected = idx
+			return
+		}
+	}
+}
+
+func (m Model) startAnsweredResumeAction(sets []execution.AnswerSet) (Model, tea.Cmd) {
+	m.actionInProgress = true
+	m.actionName = "Resuming..."
+	m.continueAfterResume = m.config.Execution.AutoContinue
+	m.executionState = execution.ExecutionStageState{Stage: execution.ExecutionStageIdle}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.actionCancel = cancel
+	streamCh, stdout, stderr := m.startLiveOutput()
+	return m, tea.Batch(
+		ResumeAnsweredCmdWithContextAndStream(ctx, m.planPath(), sets, stdout, stderr, streamCh),
+		listenLiveOutputCmd(streamCh),
+		spinnerTickCmd(),
+	)
+}
+
+// RenderQuestionInboxModal renders the inbox, or the answer form when open.
+func RenderQuestionInboxModal(m Model, form QuestionInboxForm) string {
+	if form.question != nil {
+		return RenderAgentQuestionModal(m, *form.question)
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	selectedStyle := textStyle.Copy().Background(lipgloss.Color("69")).Bold(true)
+	answeredStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("46"))
+
+	lines := []string{
+		titleStyle.Render("Pending questions"),
+		labelStyle.Render(fmt.Sprintf("%d waiting task(s), %d answered", len(form.entries), len(form.answers))),
+		"",
+	}
+	for i, entry := range form.entries {
+		marker := "  "
+		if _, ok := form.answers[entry.TaskID]; ok {
+			marker = answeredStyle.Render("✓ ")
+		}
+		header := fmt.Sprintf("%s  %s  (%s ago)", entry.TaskID, entry.TaskTitle, formatInboxAge(form.now.Sub(entry.AskedAt)))
+		if i == form.selected {
+			lines = append(lines, marker+selectedStyle.Render(header))
+		} else {
+			lines = append(lines, marker+textStyle.Render(header))
+		}
+		for _, q := range entry.Questions {
+			prompt := "    " + q.Prompt
+			if len(q.Options) > 0 {
+				prompt += " [" + strings.Join(q.Options, " / ") + "]"
+			}
+			lines = append(lines, labelStyle.Render(prompt))
+		}
+		if answers, ok := form.answers[entry.TaskID]; ok {
+			values := make([]string, 0, len(answers))
+			for _, a := range answers {
+				values = append(values, a.Value)
+			}
+			lines = append(lines, answeredStyle.Render("    → "+strings.Join(values, "; ")))
+		}
+	}
+	lines = append(lines, "", labelStyle.Render("↑/↓: select • Enter: answer • x: clear answer • r: resume answered • ESC: close"))
+
+	modalWidth := form.width
+	if modalWidth > 100 {
+		modalWidth = 100
+	}
+	if modalWidth < 50 {
+		modalWidth = 50
+	}
+	if m.windowWidth > 0 && m.windowWidth < modalWidth+4 {
+		modalWidth = m.windowWidth - 4
+	}
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("214")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	if m.windowHeight > 0 {
+		topPadding := (m.windowHeight - lipgloss.Height(modal)) / 2
+		if topPadding > 0 {
+			return lipgloss.NewStyle().PaddingTop(topPadding).Render(modal)
+		}
+	}
+	return modal
+}
+
+// formatInboxAge renders a coarse age such as 45s, 12m, 3h or 2d.
+func formatInboxAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(max(d, 0)/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+}
+
+func hasWaitingTasks(g plan.WorkGraph) bool {
+	for _, it := range g.Items {
+		if it.Status == plan.StatusWaitingUser {
+			return true
+		}
+	}
+	return false
+}
@@ -322,12 +322,14 @@ func startReviewDecision(m Model, action execution.DecisionState, feedback strin
 		return m, tea.Batch(
 			ResolveDecisionCmdWithContextAndStream(
 				ctx,
+				m.planPath(),
 				taskID,
 				runID,
 				action,
 				feedback,
 				m.config.Execution.StopAfterEachTask,
 				m.config.Execution.ParentReviewEnabled,
+				m.config.Execution.AutoContinue,
 				stdout,
 				stderr,
 				streamCh,
@@ -343,12 +345,14 @@ func startReviewDecision(m Model, action execution.DecisionState, feedback strin
 		return m, tea.Batch(
 			ResolveDecisionCmdWithContextAndStream(
 				ctx,
+				m.planPath(),
 				taskID,
 				runID,
 				action,
 				feedback,
 				m.config.Execution.StopAfterEachTask,
 				m.config.Execution.ParentReviewEnabled,
+				m.config.Execution.AutoContinue,
 				nil,
 				nil,
 				nil,
@@ -362,12 +366,14 @@ func startReviewDecision(m Model, action execution.DecisionState, feedback strin
 	return m, tea.Batch(
 		ResolveDecisionCmdWithContext(
 			ctx,
+			m.planPath(),
 			taskID,
 			runID,
 			action,
 			feedback,
 			m.config.Execution.StopAfterEachTask,
 			m.config.Execution.ParentReviewEnabled,
+			m.config.Execution.AutoContinue,
 		),
 		spinnerTickCmd(),
 	)
@@ -1,7 +1,7 @@
 package tui
 
 import (
-	"os"
+	"path/filepath"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,18 +18,7 @@ type runDataRefreshMsg struct{}
 
 func (m Model) LoadRunData() tea.Cmd {
 	return func() tea.Msg {
-		baseDir := m.projectRoot
-		if baseDir == "" {
-			var err error
-			baseDir, err = os.Getwd()
-			if err != nil {
-				return RunDataLoaded{
-					Data:                  map[string]execution.RunRecord{},
-					PendingParentFeedback: map[string]execution.PendingParentReviewFeedback{},
-					Err:                   err,
-				}
-			}
-		}
+		baseDir := filepath.Dir(m.planPath())
 
 		data := make(map[string]execution.RunRecord)
 		pendingFeedback := make(map[string]execution.PendingParentReviewFeedback)
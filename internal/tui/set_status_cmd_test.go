@@ -41,7 +41,7 @@ func TestSetStatusCmdUpdatesPlanInProcess(t *testing.T) {
 		t.Fatalf("save plan: %v", err)
 	}
 
-	cmd := SetStatusCmd(childID, "done")
+	cmd := SetStatusCmd(plan.PlanPath(), childID, "done")
 	msg := cmd()
 	result, ok := msg.(ExecuteActionComplete)
 	if !ok {
@@ -76,3 +76,49 @@ func TestSetStatusCmdUpdatesPlanInProcess(t *testing.T) {
 		t.Fatalf("unexpected updatedAt in the future")
 	}
 }
+
+func TestHistoryStepCmdUndoesSetStatus(t *testing.T) {
+	tmp := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	if err := os.Chdir(tmp); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+
+	graph := plan.WorkGraph{
+		SchemaVersion: plan.SchemaVersion,
+		Items:         map[string]plan.WorkItem{"task": makeTestItem("task", plan.StatusTodo)},
+	}
+	planFile := plan.PlanPath()
+	if err := plan.SaveAtomic(planFile, graph); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	if result := SetStatusCmd(plan.PlanPath(), "task", "done")().(ExecuteActionComplete); result.Err != nil {
+		t.Fatalf("SetStatusCmd failed: %v", result.Err)
+	}
+
+	msg := HistoryStepCmd(plan.PlanPath(), false)()
+	result, ok := msg.(PlanActionComplete)
+	if !ok {
+		t.Fatalf("expected PlanActionComplete, got %T", msg)
+	}
+	if result.Err != nil || result.Output != "undo #1: set-status task done" {
+		t.Fatalf("unexpected undo result: %+v", result)
+	}
+	updated, err := plan.Load(planFile)
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if updated.Items["task"].Status != plan.StatusTodo {
+		t.Fatalf("expected status restored to todo, got %s", updated.Items["task"].Status)
+	}
+
+	if result := HistoryStepCmd(plan.PlanPath(), false)().(PlanActionComplete); result.Err == nil {
+		t.Fatalf("expected nothing-to-undo error")
+	}
+}
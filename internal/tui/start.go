@@ -28,6 +28,7 @@ func newStartupModel(projectRoot string) Model {
 	model.planExists = false
 	model.viewMode = ViewModeHome
 	model.projectRoot = root
+	model.planRef = os.Getenv(plan.PlanEnvVar)
 	model.config = cfg
 	model.settings = NewSettingsState(root, cfg)
 	return model
@@ -0,0 +1,246 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+// TemplateForm picks a plan template, then collects its params and the parent
+// to instantiate it under.
+type TemplateForm struct {
+	templates []plan.Template
+	selected  int
+	// chosen is set once a template is picked; inputs holds one field per
+	// param followed by the parent field.
+	chosen *plan.Template
+	inputs []textinput.Model
+	focus  int
+	err    string
+	width  int
+	height int
+}
+
+// NewTemplateForm starts on the template list.
+func NewTemplateForm(templates []plan.Template) TemplateForm {
+	return TemplateForm{templates: templates, width: 80, height: 24}
+}
+
+func (f *TemplateForm) SetSize(width, height int) {
+	f.width = width
+	f.height = height
+}
+
+func (f *TemplateForm) choose(parentID string) {
+	t := f.templates[f.selected]
+	f.chosen = &t
+	f.inputs = nil
+	for _, p := range t.Params {
+		ti := textinput.New()
+		ti.Placeholder = p.Description
+		ti.CharLimit = 200
+		ti.Width = 50
+		if p.Default != nil {
+			ti.SetValue(*p.Default)
+		}
+		f.inputs = append(f.inputs, ti)
+	}
+	parent := textinput.New()
+	parent.Placeholder = "root"
+	parent.CharLimit = 200
+	parent.Width = 50
+	parent.SetValue(parentID)
+	f.inputs = append(f.inputs, parent)
+	f.focus = 0
+	f.inputs[0].Focus()
+	f.err = ""
+}
+
+func (f *TemplateForm) moveFocus(delta int) {
+	f.inputs[f.focus].Blur()
+	f.focus = (f.focus + delta + len(f.inputs)) % len(f.inputs)
+	f.inputs[f.focus].Focus()
+}
+
+// values returns the entered params and the parent id (nil for root).
+func (f TemplateForm) values() (map[string]string, *string) {
+	params := map[string]string{}
+	for i, p := range f.chosen.Params {
+		if v := f.inputs[i].Value(); v != "" || p.Default != nil {
+			params[p.Name] = v
+		}
+	}
+	parent := strings.TrimSpace(f.inputs[len(f.inputs)-1].Value())
+	if parent == "" || parent == "root" {
+		return params, nil
+	}
+	return params, &parent
+}
+
+func (m Model) openTemplatePicker() (Model, tea.Cmd) {
+	if m.actionMode != ActionModeNone || m.actionInProgress || !m.planExists {
+		return m, nil
+	}
+	dirs := plan.TemplateDirs(m.planPath())
+	templates, err := plan.LoadTemplates(dirs...)
+	if err != nil {
+		m.actionOutput = &ActionOutput{Message: fmt.Sprintf("Load templates failed: %v", err), IsError: true}
+		return m, nil
+	}
+	if len(templates) == 0 {
+		m.actionOutput = &ActionOutput{Message: "No templates in " + strings.Join(dirs, ", "), IsError: false}
+		return m, nil
+	}
+	form := NewTemplateForm(templates)
+	form.SetSize(m.windowWidth, m.windowHeight)
+	m.templateForm = &form
+	m.actionMode = ActionModeTemplate
+	return m, nil
+}
+
+// HandleTemplateKey handles key presses while the template modal is open.
+func HandleTemplateKey(m Model, msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.templateForm == nil {
+		m.actionMode = ActionModeNone
+		return m, nil
+	}
+	form := *m.templateForm
+
+	if form.chosen == nil {
+		switch msg.String() {
+		case "esc":
+			m.actionMode = ActionModeNone
+			m.templateForm = nil
+			return m, nil
+		case "up", "k":
+			if form.selected > 0 {
+				form.selected--
+			}
+		case "down", "j":
+			if form.selected < len(form.templates)-1 {
+				form.selected++
+			}
+		case "enter":
+			form.choose(m.selectedID)
+		}
+		m.templateForm = &form
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		form.chosen = nil
+		form.inputs = nil
+		form.err = ""
+	case "tab", "down":
+		form.moveFocus(1)
+	case "shift+tab", "up":
+		form.moveFocus(-1)
+	case "enter":
+		params, parentID := form.values()
+		next := plan.Clone(m.plan)
+		ids, err := plan.InstantiateTemplate(&next, *form.chosen, params, parentID, nil, time.Now().UTC())
+		if err == nil {
+			if errs := plan.Validate(next); len(errs) != 0 {
+				err = fmt.Errorf("invalid plan: %s", errs[0].Message)
+			}
+		}
+		if err != nil {
+			form.err = err.Error()
+			break
+		}
+		name := form.chosen.Name
+		m.actionMode = ActionModeNone
+		m.templateForm = nil
+		m.plan = next
+		if parentID != nil {
+			if m.expandedItems == nil {
+				m.expandedItems = map[string]bool{}
+			}
+			m.expandedItems[*parentID] = true
+		}
+		m.selectedID = ids[0]
+		m.ensureSelectionVisible()
+		message := fmt.Sprintf("Added %d item(s) from template %s", len(ids), name)
+		return m, SavePlanCmdWithAction(m.planPath(), m.plan, "add --template "+name, message)
+	default:
+		var cmd tea.Cmd
+		form.inputs[form.focus], cmd = form.inputs[form.focus].Update(msg)
+		m.templateForm = &form
+		return m, cmd
+	}
+	m.templateForm = &form
+	return m, nil
+}
+
+// RenderTemplateModal renders the template list or the param fields of the
+// chosen template.
+func RenderTemplateModal(m Model, form TemplateForm) string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("69"))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("15"))
+	selectedStyle := textStyle.Copy().Background(lipgloss.Color("69")).Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+
+	var lines []string
+	if form.chosen == nil {
+		lines = append(lines, titleStyle.Render("Add from template"), "")
+		for i, t := range form.templates {
+			row := t.Name
+			if t.Description != "" {
+				row += ": " + t.Description
+			}
+			if i == form.selected {
+				lines = append(lines, selectedStyle.Render(row))
+			} else {
+				lines = append(lines, textStyle.Render(row))
+			}
+		}
+		lines = append(lines, "", labelStyle.Render(form.templates[form.selected].Path))
+		lines = append(lines, "", labelStyle.Render("↑/↓: select • Enter: choose • ESC: cancel"))
+	} else {
+		lines = append(lines, titleStyle.Render("Template "+form.chosen.Name), "")
+		for i, p := range form.chosen.Params {
+			label := p.Name
+			if p.Default == nil {
+				label += " (required)"
+			}
+			lines = append(lines, labelStyle.Render(label), form.inputs[i].View())
+		}
+		lines = append(lines, labelStyle.Render("parent (id or root)"), form.inputs[len(form.inputs)-1].View())
+		if form.err != "" {
+			lines = append(lines, "", errorStyle.Render(form.err))
+		}
+		lines = append(lines, "", labelStyle.Render("Tab/↑/↓: field • Enter: add • ESC: back"))
+	}
+
+	modalWidth := form.width
+	if modalWidth > 90 {
+		modalWidth = 90
+	}
+	if modalWidth < 50 {
+		modalWidth = 50
+	}
+	if m.windowWidth > 0 && m.windowWidth < modalWidth+4 {
+		modalWidth = m.windowWidth - 4
+	}
+	modal := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("69")).
+		Padding(1, 2).
+		Width(modalWidth).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	if m.windowHeight > 0 {
+		topPadding := (m.windowHeight - lipgloss.Height(modal)) / 2
+		if topPadding > 0 {
+			return lipgloss.NewStyle().PaddingTop(topPadding).Render(modal)
+		}
+	}
+	return modal
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jbonatakis/blackbird/internal/plan"
+)
+
+func TestTemplateModalInstantiatesUnderSelectedItem(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(tempDir, filepath.FromSlash(plan.TemplatesDirName))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	template := `{"description": "REST endpoint", "params": [{"name": "name"}], "items": [
+  {"id": "{{name}}-handler", "title": "Handler for {{name}}"},
+  {"id": "{{name}}-tests", "title": "Tests for {{name}}", "deps": ["{{name}}-handler"]}
+]}`
+	if err := os.WriteFile(filepath.Join(dir, "endpoint.json"), []byte(template), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	api := plan.WorkItem{ID: "api", Title: "API", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo, CreatedAt: now, UpdatedAt: now}
+	m := Model{
+		plan:       plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": api}},
+		planExists: true,
+		viewMode:   ViewModeMain,
+		selectedID: "api",
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = updated.(Model)
+	if m.actionMode != ActionModeTemplate || m.templateForm == nil {
+		t.Fatalf("expected template modal, got mode %v", m.actionMode)
+	}
+	if view := RenderTemplateModal(m, *m.templateForm); !strings.Contains(view, "endpoint: REST endpoint") {
+		t.Fatalf("view missing template:\n%s", view)
+	}
+
+	m, _ = HandleTemplateKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = HandleTemplateKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.templateForm.err, "missing param name") {
+		t.Fatalf("expected missing param error, got %q", m.templateForm.err)
+	}
+	m, _ = HandleTemplateKey(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("users")})
+	m, cmd := HandleTemplateKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || m.actionMode != ActionModeNone || m.templateForm != nil {
+		t.Fatalf("expected save after add, mode %v", m.actionMode)
+	}
+	if got := m.plan.Items["api"].ChildIDs; len(got) != 2 || got[0] != "users-handler" {
+		t.Fatalf("api children = %v", got)
+	}
+	if deps := m.plan.Items["users-tests"].Deps; len(deps) != 1 || deps[0] != "users-handler" {
+		t.Fatalf("tests deps = %v", deps)
+	}
+	if m.selectedID != "users-handler" {
+		t.Fatalf("selected = %q", m.selectedID)
+	}
+}
+
+func TestTemplateModalRejectsUnknownExternalDep(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(tempDir, filepath.FromSlash(plan.TemplatesDirName))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	template := `{"items": [{"id": "client", "title": "Client", "deps": ["plan:backend#missing"]}]}`
+	if err := os.WriteFile(filepath.Join(dir, "client.json"), []byte(template), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	now := time.Date(2026, 2, 1, 12, 0, 0, 0, time.UTC)
+	api := plan.WorkItem{ID: "api", Title: "API", AcceptanceCriteria: []string{}, ChildIDs: []string{}, Deps: []string{}, Status: plan.StatusTodo, CreatedAt: now, UpdatedAt: now}
+	backend := plan.WorkGraph{SchemaVersion: plan.SchemaVersion, Items: map[string]plan.WorkItem{"api": api}}
+	if err := plan.SaveAtomic(filepath.Join(plan.NamedPlanDir(tempDir, "backend"), plan.DefaultPlanFilename), backend); err != nil {
+		t.Fatalf("save backend plan: %v", err)
+	}
+	planPath := filepath.Join(tempDir, plan.DefaultPlanFilename)
+	if err := plan.SaveAtomic(planPath, plan.NewEmptyWorkGraph()); err != nil {
+		t.Fatalf("save plan: %v", err)
+	}
+	g, err := plan.Load(planPath)
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	m := Model{plan: g, planExists: true, viewMode: ViewModeMain}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = updated.(Model)
+	if m.templateForm == nil {
+		t.Fatalf("expected template modal, got mode %v", m.actionMode)
+	}
+	m, _ = HandleTemplateKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd := HandleTemplateKey(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.templateForm == nil || !strings.Contains(m.templateForm.err, `unknown dep id "missing" in plan "backend"`) {
+		t.Fatalf("expected the unknown external item to be rejected, got %+v", m.templateForm)
+	}
+	if _, ok := m.plan.Items["client"]; ok {
+		t.Fatalf("rejected template was added to the plan")
+	}
+}
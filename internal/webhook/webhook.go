@@ -0,0 +1,261 @@
+// Package webhook delivers execution events to configured HTTP endpoints.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/config"
+)
+
+// LogFileName is where failed deliveries are logged, relative to the project root.
+const LogFileName = ".blackbird/webhooks.log"
+
+// SignatureHeader carries "sha256=<hex HMAC of the body>" when the endpoint has a secret.
+const SignatureHeader = "X-Blackbird-Signature"
+
+// EventType names an execution event.
+type EventType string
+
+const (
+	EventRunStarted         EventType = "run_started"
+	EventRunFinished        EventType = "run_finished"
+	EventWaitingUser        EventType = "waiting_user"
+	EventDecisionRequired   EventType = "decision_required"
+	EventParentReviewFailed EventType = "parent_review_failed"
+	// EventTest is sent by `blackbird webhooks test`.
+	EventTest EventType = "test"
+)
+
+// Event is the JSON body POSTed to each endpoint.
+type Event struct {
+	Type      EventType `json:"event"`
+	Time      time.Time `json:"time"`
+	PlanPath  string    `json:"planPath,omitempty"`
+	TaskID    string    `json:"taskId,omitempty"`
+	TaskTitle string    `json:"taskTitle,omitempty"`
+	RunID     string    `json:"runId,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	// Message is a short human-readable detail: the error, the first
+	// question, or the review feedback.
+	Message string `json:"message,omitempty"`
+	// TaskIDs lists the child tasks a failed parent review sent back.
+	TaskIDs []string `json:"taskIds,omitempty"`
+}
+
+// Endpoint is one delivery target.
+type Endpoint struct {
+	URL    string
+	Secret string
+	Events []EventType
+}
+
+func (e Endpoint) wants(t EventType) bool {
+	return len(e.Events) == 0 || t == EventTest || slices.Contains(e.Events, t)
+}
+
+// Dispatcher sends events to its endpoints in the background, retrying with
+// exponential backoff. A nil Dispatcher ignores events.
+type Dispatcher struct {
+	Endpoints   []Endpoint
+	MaxAttempts int
+	// Backoff is the delay before the second attempt; it doubles after each retry.
+	Backoff time.Duration
+	// LogPath receives one line per delivery that ran out of attempts.
+	LogPath string
+	Client  *http.Client
+
+	wg    sync.WaitGroup
+	logMu sync.Mutex
+}
+
+// New builds a dispatcher from resolved config, logging under baseDir. It
+// returns nil when no endpoints are configured.
+func New(cfg config.ResolvedWebhooks, baseDir string) *Dispatcher {
+	if len(cfg.Endpoints) == 0 {
+		return nil
+	}
+	d := &Dispatcher{
+		MaxAttempts: cfg.MaxAttempts,
+		Backoff:     time.Second,
+		LogPath:     filepath.Join(baseDir, LogFileName),
+		Client:      &http.Client{Timeout: 10 * time.Second},
+	}
+	for _, raw := range cfg.Endpoints {
+		endpoint := Endpoint{URL: strings.TrimSpace(raw.URL), Secret: raw.Secret}
+		if raw.SecretEnv != "" {
+			endpoint.Secret = os.Getenv(raw.SecretEnv)
+		}
+		for _, name := range raw.Events {
+			endpoint.Events = append(endpoint.Events, EventType(strings.TrimSpace(name)))
+		}
+		d.Endpoints = append(d.Endpoints, endpoint)
+	}
+	return d
+}
+
+// Notify queues ev for every endpoint subscribed to it and returns immediately.
+func (d *Dispatcher) Notify(ev Event) {
+	if d == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	body, err := json.Marshal(ev)
+	if err != nil {
+		d.logFailure(ev, "", 0, fmt.Errorf("encode event: %w", err))
+		return
+	}
+	for _, endpoint := range d.Endpoints {
+		if !endpoint.wants(ev.Type) {
+			continue
+		}
+		d.wg.Add(1)
+		go func(endpoint Endpoint) {
+			defer d.wg.Done()
+			if attempts, err := d.deliver(context.Background(), endpoint, ev.Type, body); err != nil {
+				d.logFailure(ev, endpoint.URL, attempts, err)
+			}
+		}(endpoint)
+	}
+}
+
+// Send delivers ev to every subscribed endpoint and waits for the result.
+func (d *Dispatcher) Send(ctx context.Context, ev Event) error {
+	if d == nil {
+		return nil
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	body, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+	var errs []string
+	for _, endpoint := range d.Endpoints {
+		if !endpoint.wants(ev.Type) {
+			continue
+		}
+		if attempts, err := d.deliver(ctx, endpoint, ev.Type, body); err != nil {
+			d.logFailure(ev, endpoint.URL, attempts, err)
+			errs = append(errs, fmt.Sprintf("%s: %v", endpoint.URL, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("webhook delivery failed: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// Wait blocks until queued deliveries (including retries) finish.
+func (d *Dispatcher) Wait() {
+	if d == nil {
+		return
+	}
+	d.wg.Wait()
+}
+
+// deliver POSTs body until it succeeds or attempts run out. It returns the
+// number of attempts made.
+func (d *Dispatcher) deliver(ctx context.Context, endpoint Endpoint, eventType EventType, body []byte) (int, error) {
+	attempts := max(d.MaxAttempts, 1)
+	delay := d.Backoff
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return attempt - 1, ctx.Err()
+			case <-time.After(delay):
+			}
+			delay *= 2
+		}
+		retry, err := d.post(ctx, endpoint, eventType, body)
+		if err == nil {
+			return attempt, nil
+		}
+		lastErr = err
+		if !retry {
+			return attempt, err
+		}
+	}
+	return attempts, lastErr
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying.
+func (d *Dispatcher) post(ctx context.Context, endpoint Endpoint, eventType EventType, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "blackbird-webhook")
+	req.Header.Set("X-Blackbird-Event", string(eventType))
+	req.Header.Set("X-Blackbird-Delivery", deliveryID())
+	if endpoint.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(endpoint.Secret, body))
+	}
+
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// Sign returns the signature header value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliveryID() string {
+	var b [8]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+func (d *Dispatcher) logFailure(ev Event, url string, attempts int, deliverErr error) {
+	if d.LogPath == "" {
+		return
+	}
+	d.logMu.Lock()
+	defer d.logMu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(d.LogPath), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(d.LogPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "%s event=%s task=%s run=%s url=%s attempts=%d error=%q\n",
+		time.Now().UTC().Format(time.RFC3339), ev.Type, ev.TaskID, ev.RunID, url, attempts, deliverErr.Error())
+}
@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jbonatakis/blackbird/internal/config"
+)
+
+type received struct {
+	header http.Header
+	body   []byte
+}
+
+// standIn records requests and answers with the queued status codes (then 204).
+type standIn struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []received
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, received{header: r.Header.Clone(), body: body})
+	status := http.StatusNoContent
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (s *standIn) snapshot() []received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]received(nil), s.requests...)
+}
+
+func newTestDispatcher(t *testing.T, endpoints ...config.RawWebhookEndpoint) *Dispatcher {
+	t.Helper()
+	d := New(config.ResolvedWebhooks{Endpoints: endpoints, MaxAttempts: 3}, t.TempDir())
+	d.Backoff = time.Millisecond
+	return d
+}
+
+func TestNotifySignsAndFiltersEvents(t *testing.T) {
+	all := &standIn{}
+	allSrv := httptest.NewServer(all)
+	defer allSrv.Close()
+	waiting := &standIn{}
+	waitingSrv := httptest.NewServer(waiting)
+	defer waitingSrv.Close()
+
+	t.Setenv("TEST_WEBHOOK_SECRET", "s3cret")
+	d := newTestDispatcher(t,
+		config.RawWebhookEndpoint{URL: allSrv.URL, SecretEnv: "TEST_WEBHOOK_SECRET"},
+		config.RawWebhookEndpoint{URL: waitingSrv.URL, Events: []string{"waiting_user"}},
+	)
+	d.Notify(Event{Type: EventRunStarted, TaskID: "a"})
+	d.Notify(Event{Type: EventWaitingUser, TaskID: "a", RunID: "r1", Message: "Which DB?"})
+	d.Wait()
+
+	if got := len(all.snapshot()); got != 2 {
+		t.Fatalf("unfiltered endpoint got %d requests, want 2", got)
+	}
+	reqs := waiting.snapshot()
+	if len(reqs) != 1 {
+		t.Fatalf("filtered endpoint got %d requests, want 1", len(reqs))
+	}
+	var ev Event
+	if err := json.Unmarshal(reqs[0].body, &ev); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if ev.Type != EventWaitingUser || ev.RunID != "r1" || ev.Message != "Which DB?" || ev.Time.IsZero() {
+		t.Fatalf("event = %+v", ev)
+	}
+	if reqs[0].header.Get(SignatureHeader) != "" {
+		t.Fatalf("unsigned endpoint got a signature")
+	}
+
+	for _, req := range all.snapshot() {
+		if got, want := req.header.Get(SignatureHeader), Sign("s3cret", req.body); got != want {
+			t.Fatalf("signature = %q, want %q", got, want)
+		}
+		if req.header.Get("Content-Type") != "application/json" || req.header.Get("X-Blackbird-Event") == "" {
+			t.Fatalf("headers = %v", req.header)
+		}
+	}
+}
+
+func TestDeliveryRetriesServerErrors(t *testing.T) {
+	stand := &standIn{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(stand)
+	defer srv.Close()
+
+	d := newTestDispatcher(t, config.RawWebhookEndpoint{URL: srv.URL})
+	if err := d.Send(context.Background(), Event{Type: EventRunFinished}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if got := len(stand.snapshot()); got != 3 {
+		t.Fatalf("attempts = %d, want 3", got)
+	}
+	if _, err := os.Stat(d.LogPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no failure log after eventual success")
+	}
+}
+
+func TestDeliveryFailureIsLogged(t *testing.T) {
+	failing := &standIn{statuses: []int{500, 500, 500, 500}}
+	failingSrv := httptest.NewServer(failing)
+	defer failingSrv.Close()
+	rejecting := &standIn{statuses: []int{http.StatusBadRequest}}
+	rejectingSrv := httptest.NewServer(rejecting)
+	defer rejectingSrv.Close()
+
+	d := newTestDispatcher(t,
+		config.RawWebhookEndpoint{URL: failingSrv.URL},
+		config.RawWebhookEndpoint{URL: rejectingSrv.URL},
+	)
+	d.Notify(Event{Type: EventDecisionRequired, TaskID: "a", RunID: "r1"})
+	d.Wait()
+
+	if got := len(failing.snapshot()); got != 3 {
+		t.Fatalf("attempts = %d, want MaxAttempts (3)", got)
+	}
+	if got := len(rejecting.snapshot()); got != 1 {
+		t.Fatalf("4xx attempts = %d, want 1 (no retry)", got)
+	}
+	data, err := os.ReadFile(d.LogPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("log lines = %q", lines)
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "event=decision_required task=a run=r1") {
+			t.Fatalf("log line = %q", line)
+		}
+	}
+	if filepath.Base(filepath.Dir(d.LogPath)) != ".blackbird" {
+		t.Fatalf("log path = %s", d.LogPath)
+	}
+}
+
+func TestNewWithoutEndpointsIsNil(t *testing.T) {
+	d := New(config.DefaultResolvedConfig().Webhooks, t.TempDir())
+	if d != nil {
+		t.Fatalf("expected nil dispatcher")
+	}
+	// A nil dispatcher is safe to use.
+	d.Notify(Event{Type: EventRunStarted})
+	d.Wait()
+	if err := d.Send(context.Background(), Event{Type: EventTest}); err != nil {
+		t.Fatalf("nil send: %v", err)
+	}
+}